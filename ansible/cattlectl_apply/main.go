@@ -81,12 +81,14 @@ func main() {
 		utils.BuildRancherConfig(moduleArgs.AccessArgs),
 	)
 
+	// the partial result is part of the response even if the apply failed
+	response.ApplyResult = result
+	// a failed apply may still have changed resources, a rolled back resource counts as changed
+	response.Changed = len(result.CreatedResources) > 0 || len(result.UpgradedResources) > 0 || len(result.DeletedResources) > 0 || len(result.RolledBackResources) > 0
 	if err != nil {
 		response.Msg = "Failed to apply descriptor: " + err.Error()
 		response.Failed = true
 		utils.FailJson(response)
 	}
-	response.ApplyResult = result
-	response.Changed = len(result.CreatedResources) > 0 || len(result.UpgradedResources) > 0
 	utils.ExitJson(response)
 }
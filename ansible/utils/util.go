@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/config"
 	"github.com/bitgrip/cattlectl/internal/pkg/ctl"
@@ -35,6 +36,12 @@ type AccessArgs struct {
 	ClusterName string `json:"cluster_name"`
 	ConfigFile  string `json:"config_file"`
 	DryRun      bool   `json:"dry_run"`
+	Prune       bool   `json:"prune"`
+	KeepGoing   bool   `json:"keep_going"`
+	Parallelism int    `json:"parallelism"`
+	Wait        bool   `json:"wait"`
+	// Timeout in seconds to wait for resources to become ready
+	Timeout int `json:"timeout"`
 }
 
 type BaseResponse struct {
@@ -128,6 +135,10 @@ func BuildRancherConfig(args AccessArgs) config.Config {
 	if args.ClusterName == "" {
 		args.ClusterName = viper.GetString("rancher.cluster_name")
 	}
+	timeout := config.DefaultTimeout
+	if args.Timeout > 0 {
+		timeout = time.Duration(args.Timeout) * time.Second
+	}
 	return config.SimpleConfig(
 		args.RancherURL,
 		args.InsecureAPI,
@@ -138,5 +149,12 @@ func BuildRancherConfig(args AccessArgs) config.Config {
 		"",
 		false,
 		args.DryRun,
+		args.Prune,
+		args.KeepGoing,
+		args.Parallelism,
+		args.Wait,
+		timeout,
+		config.DefaultInitScriptShell,
+		config.DefaultInitScriptTimeout,
 	)
 }
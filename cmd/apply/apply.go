@@ -16,7 +16,9 @@ package apply
 
 import (
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/bitgrip/cattlectl/cmd/utils"
 	"github.com/bitgrip/cattlectl/internal/pkg/config"
@@ -37,6 +39,7 @@ var (
 	}
 	applyFile   string
 	valuesFiles []string
+	output      string
 	rootConfig  config.Config
 	initCommand = func() {}
 )
@@ -56,6 +59,10 @@ func BaseCommand(config config.Config, init func()) *cobra.Command {
 
 func apply(cmd *cobra.Command, args []string) {
 	initCommand()
+	if output != "" && !ctl.IsOutputFormat(output) {
+		logrus.WithField("output", output).
+			Fatalf("Unsupported output format, use one of [%s]", strings.Join(ctl.OutputFormats, ", "))
+	}
 	values, err := utils.LoadValues(valuesFiles...)
 	if err != nil {
 		logrus.WithField("apply_file", applyFile).
@@ -74,6 +81,13 @@ func apply(cmd *cobra.Command, args []string) {
 	}
 
 	result, err := doApplyDescriptor(applyFile, projectData, values, rootConfig)
+	if output != "" {
+		// the report is written even if the apply failed, to show the failed resource
+		if reportErr := ctl.WriteReport(os.Stdout, output, "cattlectl apply "+applyFile, result.Reports); reportErr != nil {
+			logrus.WithField("output", output).
+				Error(reportErr)
+		}
+	}
 	if err != nil {
 		logrus.WithFields(values).
 			WithField("apply_file", applyFile).
@@ -82,14 +96,37 @@ func apply(cmd *cobra.Command, args []string) {
 	logrus.
 		WithField("upgraded-resouces", len(result.UpgradedResources)).
 		WithField("created-resouces", len(result.CreatedResources)).
+		WithField("deleted-resouces", len(result.DeletedResources)).
 		Info("Finished Apply")
 }
 
 func init() {
 	applyCmd.Flags().StringVarP(&applyFile, "file", "f", "project.yaml", "project file to apply")
 	applyCmd.Flags().StringSliceVar(&valuesFiles, "values", []string{"values.yaml"}, "values file(s) to apply")
+	applyCmd.Flags().StringVarP(&output, "output", "o", "", "write a report of all applied resources to stdout (json|yaml|junit)")
 
 	applyCmd.Flags().Bool("merge-answers", false, "If answers of existing apps should be merged with the new apply answers")
 	viper.BindPFlag("rancher.merge_answers", applyCmd.Flags().Lookup("merge-answers"))
 	viper.BindEnv("rancher.merge_answers", "RANCHER_MERGE_ANSWERS")
+
+	applyCmd.Flags().Bool("prune", false, "If resources created by cattlectl but removed from the project or cluster descriptor should be deleted")
+	viper.BindPFlag("prune", applyCmd.Flags().Lookup("prune"))
+
+	applyCmd.Flags().Bool("keep-going", false, "If all resources should be applied even if some of them fail, all failures are reported at the end")
+	viper.BindPFlag("keep_going", applyCmd.Flags().Lookup("keep-going"))
+
+	applyCmd.Flags().Int("parallelism", 1, "The number of independent project resources applied concurrently")
+	viper.BindPFlag("parallelism", applyCmd.Flags().Lookup("parallelism"))
+
+	applyCmd.Flags().Bool("wait", false, "If created or upgraded apps and workloads should be ready before the apply continues")
+	viper.BindPFlag("wait", applyCmd.Flags().Lookup("wait"))
+
+	applyCmd.Flags().Duration("timeout", config.DefaultTimeout, "The time to wait for each app or workload to become ready")
+	viper.BindPFlag("timeout", applyCmd.Flags().Lookup("timeout"))
+
+	applyCmd.Flags().String("init-script-shell", config.DefaultInitScriptShell, "The shell command running the init scripts of persistent volumes")
+	viper.BindPFlag("init_script_shell", applyCmd.Flags().Lookup("init-script-shell"))
+
+	applyCmd.Flags().Duration("init-script-timeout", config.DefaultInitScriptTimeout, "The time each init script of a persistent volume may run")
+	viper.BindPFlag("init_script_timeout", applyCmd.Flags().Lookup("init-script-timeout"))
 }
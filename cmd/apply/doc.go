@@ -29,6 +29,9 @@ var applyLongDescription = `Apply a deployment descriptor to your rancher.
   * Deployment
   * DaemonSet
   * StatefulSet
+  * Service
+  * Ingress
+* MultiClusterApp - An app of a global catalog installed into projects of several clusters [more...](multi_cluster_app_descriptor.md)
 
 ### Directory layout
 
@@ -39,4 +42,69 @@ By default this command expects in the current folder:
 | __project.yaml__ | The project descriptor with support for go template syntax. |
 | __values.yaml__  | The set of values used to render the project descriptor.    |
 
+### Failures
+
+By default ` + "`apply`" + ` stops at the first failing resource.
+With ` + "`--keep-going`" + ` all other resources of all descriptors are still applied,
+all failures are reported at the end and the command exits non-zero.
+Only the resources which depend on a failed resource via depends_on are skipped,
+a failure does not skip the other resources of the following phases.
+
+### Parallelism
+
+With ` + "`--parallelism N`" + ` up to N resources of a project are applied concurrently.
+The resources are applied in phases, all resources of a phase are independent of each other:
+
+1. catalogs, namespaces and storage classes
+2. certificates, config maps, docker credentials, secrets and persistent volumes
+3. apps
+
+### Waiting
+
+With ` + "`--wait`" + ` each created or upgraded app and workload has to become ready within ` + "`--timeout`" + ` (default 5m):
+
+| Resource    | Ready if                                    |
+|-------------|---------------------------------------------|
+| App         | the app is active in its upgraded revision  |
+| Deployment  | all replicas are updated and available      |
+| Job         | all completions succeeded                   |
+| StatefulSet | all replicas are ready                      |
+| DaemonSet   | the pods on all scheduled nodes are ready   |
+
+A resource in a failed state fails the apply with the transitioning message of rancher.
+CronJobs are not waited for.
+
+An app which does not become ready after an upgrade is rolled back to the revision
+it had before the upgrade. The app is reported as rolled-back and the apply fails.
+
+### Persistent volume init scripts
+
+The ` + "`init_script`" + ` of a new persistent volume is run with ` + "`--init-script-shell`" + ` (default ` + "`sh -c`" + `)
+before the volume is created and has to finish within ` + "`--init-script-timeout`" + ` (default 1m).
+With ` + "`--dry-run`" + ` the script is only logged.
+
+### Dependencies
+
+Resources and descriptors can declare ` + "`depends_on`" + ` to be applied after the resources they depend on.
+The descriptors of a file are applied one after another in the order of the file and their dependencies.
+Unknown dependencies and dependency cycles are rejected before anything is applied.
+
+### Reports
+
+With ` + "`--output json|yaml|junit`" + ` a report of all handled resources is written to stdout.
+Each resource is reported with its type, name, namespace, action
+(created, upgraded, unchanged, deleted, rolled-back or failed), duration in seconds and error.
+Some resources report outputs, e.g. a created RKE cluster reports the command to register its custom nodes.
+The JUnit report lists each resource as a test case, e.g. to show the result in a GitLab merge request:
+
+` + "```yaml" + `
+deploy:
+  script:
+    - cattlectl apply --output junit > cattlectl-report.xml
+  artifacts:
+    when: always
+    reports:
+      junit: cattlectl-report.xml
+` + "```" + `
+
 See the [Project Descriptor data model](project_descriptor.md)`
@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -64,3 +65,31 @@ func (config) MergeAnswers() bool {
 func (config) DryRun() bool {
 	return viper.GetBool("dry_run")
 }
+
+func (config) Prune() bool {
+	return viper.GetBool("prune")
+}
+
+func (config) KeepGoing() bool {
+	return viper.GetBool("keep_going")
+}
+
+func (config) Parallelism() int {
+	return viper.GetInt("parallelism")
+}
+
+func (config) Wait() bool {
+	return viper.GetBool("wait")
+}
+
+func (config) Timeout() time.Duration {
+	return viper.GetDuration("timeout")
+}
+
+func (config) InitScriptShell() string {
+	return viper.GetString("init_script_shell")
+}
+
+func (config) InitScriptTimeout() time.Duration {
+	return viper.GetDuration("init_script_timeout")
+}
@@ -15,8 +15,12 @@
 package delete
 
 import (
+	"os"
+	"strings"
+
 	"github.com/bitgrip/cattlectl/internal/pkg/config"
 	"github.com/bitgrip/cattlectl/internal/pkg/ctl"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -31,6 +35,7 @@ var (
 		Run:       delete,
 		ValidArgs: validArgs,
 	}
+	output      string
 	rootConfig  config.Config
 	initCommand = func() {}
 )
@@ -47,9 +52,22 @@ func delete(cmd *cobra.Command, args []string) {
 		logrus.Warn(cmd.UsageString())
 		return
 	}
+	if output != "" && !ctl.IsOutputFormat(output) {
+		logrus.WithField("output", output).
+			Fatalf("Unsupported output format, use one of [%s]", strings.Join(ctl.OutputFormats, ", "))
+	}
 	kind := args[0]
+	if viper.GetBool("delete_cmd.cluster") {
+		deleteClusterResources(kind, args[1:])
+		return
+	}
+	if viper.GetBool("delete_cmd.global") {
+		deleteRancherResources(kind, args[1:])
+		return
+	}
 	projectName := viper.GetString("delete_cmd.project_name")
 	namespace := viper.GetString("delete_cmd.namespace")
+	reports := make([]descriptor.ResourceReport, 0)
 	for _, resourceName := range args[1:] {
 		logrus.
 			WithField("project-name", projectName).
@@ -57,8 +75,10 @@ func delete(cmd *cobra.Command, args []string) {
 			WithField("resouce-name", resourceName).
 			WithField("cluster-name", rootConfig.ClusterName()).
 			Info("Delete project resouce")
-		_, err := ctl.DeleteProjectResouce(projectName, namespace, kind, resourceName, rootConfig)
+		report, err := ctl.DeleteProjectResouceWithReport(projectName, namespace, kind, resourceName, rootConfig)
+		reports = append(reports, report)
 		if err != nil {
+			writeReport(reports)
 			logrus.
 				WithField("project-name", projectName).
 				WithField("kind", kind).
@@ -67,12 +87,73 @@ func delete(cmd *cobra.Command, args []string) {
 				Fatal(err)
 		}
 	}
+	writeReport(reports)
+}
+
+func deleteClusterResources(kind string, names []string) {
+	reports := make([]descriptor.ResourceReport, 0)
+	for _, resourceName := range names {
+		logrus.
+			WithField("kind", kind).
+			WithField("resouce-name", resourceName).
+			WithField("cluster-name", rootConfig.ClusterName()).
+			Info("Delete cluster resouce")
+		report, err := ctl.DeleteClusterResouceWithReport(kind, resourceName, rootConfig)
+		reports = append(reports, report)
+		if err != nil {
+			writeReport(reports)
+			logrus.
+				WithField("kind", kind).
+				WithField("resouce-name", resourceName).
+				WithField("cluster-name", rootConfig.ClusterName()).
+				Fatal(err)
+		}
+	}
+	writeReport(reports)
+}
+
+func deleteRancherResources(kind string, names []string) {
+	reports := make([]descriptor.ResourceReport, 0)
+	for _, resourceName := range names {
+		logrus.
+			WithField("kind", kind).
+			WithField("resouce-name", resourceName).
+			Info("Delete global resouce")
+		report, err := ctl.DeleteRancherResouceWithReport(kind, resourceName, rootConfig)
+		reports = append(reports, report)
+		if err != nil {
+			writeReport(reports)
+			logrus.
+				WithField("kind", kind).
+				WithField("resouce-name", resourceName).
+				Fatal(err)
+		}
+	}
+	writeReport(reports)
+}
+
+func writeReport(reports []descriptor.ResourceReport) {
+	if output == "" {
+		return
+	}
+	if err := ctl.WriteReport(os.Stdout, output, "cattlectl delete", reports); err != nil {
+		logrus.WithField("output", output).
+			Error(err)
+	}
 }
 
 func init() {
+	deleteCmd.Flags().StringVarP(&output, "output", "o", "", "write a report of all deleted resources to stdout (json|yaml|junit)")
+
 	deleteCmd.Flags().String("project-name", "", "The name of the project to delete resouces from")
 	viper.BindPFlag("delete_cmd.project_name", deleteCmd.Flags().Lookup("project-name"))
 
+	deleteCmd.Flags().Bool("cluster", false, "Delete cluster scoped resouces (storage-class, persistent-volume) instead of project resouces")
+	viper.BindPFlag("delete_cmd.cluster", deleteCmd.Flags().Lookup("cluster"))
+
+	deleteCmd.Flags().Bool("global", false, "Delete global resouces (multi-cluster-app) instead of project resouces")
+	viper.BindPFlag("delete_cmd.global", deleteCmd.Flags().Lookup("global"))
+
 	deleteCmd.Flags().String("namespace", "", "The namespace of the project to delete resouces from")
 	viper.BindPFlag("delete_cmd.namespace", deleteCmd.Flags().Lookup("namespace"))
 }
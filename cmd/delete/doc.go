@@ -28,4 +28,17 @@ var deleteLongDescription = `Deletes an rancher resouce.
 * cron-job - NOT YET IMPLEMENTED
 * deployment - NOT YET IMPLEMENTED
 * daemon-set - NOT YET IMPLEMENTED
-* stateful-set - NOT YET IMPLEMENTED`
+* stateful-set - NOT YET IMPLEMENTED
+* service
+* ingress
+* network-policy
+* persistent-volume-claim
+
+### Supported cluster resource types (with ` + "`--cluster`" + `):
+
+* storage-class
+* persistent-volume
+
+### Supported global resource types (with ` + "`--global`" + `):
+
+* multi-cluster-app`
@@ -0,0 +1,97 @@
+// Copyright © 2018 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/bitgrip/cattlectl/cmd/utils"
+	"github.com/bitgrip/cattlectl/internal/pkg/config"
+	"github.com/bitgrip/cattlectl/internal/pkg/ctl"
+	"github.com/bitgrip/cattlectl/internal/pkg/template"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	diffCmd = &cobra.Command{
+		Use:    "diff",
+		Short:  "Show the changes apply would make to your rancher",
+		Long:   diffLongDescription,
+		PreRun: bindFlags,
+		Run:    diff,
+	}
+	diffFile    string
+	valuesFiles []string
+	noColor     bool
+	rootConfig  config.Config
+	initCommand = func() {}
+)
+
+// used services
+var (
+	doDiffDescriptor = ctl.DiffDescriptor
+)
+
+// BaseCommand is accessor to the package base command
+func BaseCommand(config config.Config, init func()) *cobra.Command {
+	rootConfig = config
+	initCommand = init
+	return diffCmd
+}
+
+// bindFlags binds the flags shared with apply when diff is executed,
+// binding them in init would override the flags of apply
+func bindFlags(cmd *cobra.Command, args []string) {
+	viper.BindPFlag("prune", cmd.Flags().Lookup("prune"))
+}
+
+func diff(cmd *cobra.Command, args []string) {
+	initCommand()
+	values, err := utils.LoadValues(valuesFiles...)
+	if err != nil {
+		logrus.WithField("diff_file", diffFile).
+			Fatal(err)
+	}
+	fileContent, err := ioutil.ReadFile(diffFile)
+	if err != nil {
+		logrus.WithField("diff_file", diffFile).
+			Fatal(err)
+	}
+	projectData, err := template.BuildTemplate(fileContent, values, filepath.Dir(diffFile), false)
+	if err != nil {
+		logrus.WithField("diff_file", diffFile).
+			Fatal(err)
+	}
+
+	result, err := doDiffDescriptor(diffFile, projectData, values, rootConfig)
+	if err != nil {
+		logrus.WithFields(values).
+			WithField("diff_file", diffFile).
+			Fatal(err)
+	}
+	fmt.Print(ctl.FormatDiff(result, !noColor))
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffFile, "file", "f", "project.yaml", "project file to diff")
+	diffCmd.Flags().StringSliceVar(&valuesFiles, "values", []string{"values.yaml"}, "values file(s) to diff")
+	diffCmd.Flags().BoolVar(&noColor, "no-color", false, "Print the diff without terminal colors")
+
+	diffCmd.Flags().Bool("prune", false, "If resources created by cattlectl but removed from the project descriptor should be listed as deleted")
+}
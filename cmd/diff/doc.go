@@ -0,0 +1,37 @@
+// Copyright © 2018 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+var diffLongDescription = `Show the changes an apply of a descriptor would make to your rancher.
+
+The descriptor is parsed exactly like in apply, but instead of changing any
+resource the live state is read from rancher and compared with the descriptor.
+
+### Resource states
+
+| State          | Description                                                       |
+|----------------|-------------------------------------------------------------------|
+| __created__    | The resource dose not exist and would be created.                 |
+| __changed__    | The resource exists and would be upgraded.                        |
+| __unchanged__  | The resource exists and matches the descriptor.                   |
+| __would-skip__ | The resource differs but cattlectl does not upgrade it (e.g. apps with skip_upgrade or jobs without upgradeStrategy recreate). |
+
+Workloads, config maps and apps are compared like apply does, e.g. by the
+cattlectl.io/hash label of the existing resource. The members set by the
+descriptor are shown as unified diff, values added by rancher are left out.
+All other resources are only checked for existence and are marked with
+"(content not compared)".
+
+Use --no-color to get a plain unified diff e.g. to paste it into a merge request.`
@@ -50,6 +50,14 @@ func list(cmd *cobra.Command, args []string) {
 		return
 	}
 	kind := args[0]
+	if viper.GetBool("list_cmd.cluster") {
+		listClusterResources(kind)
+		return
+	}
+	if viper.GetBool("list_cmd.global") {
+		listRancherResources(kind)
+		return
+	}
 	projectName := viper.GetString("list_cmd.project_name")
 	namespace := viper.GetString("list_cmd.namespace")
 	pattern := viper.GetString("list_cmd.pattern")
@@ -71,6 +79,40 @@ func list(cmd *cobra.Command, args []string) {
 	}
 }
 
+func listClusterResources(kind string) {
+	pattern := viper.GetString("list_cmd.pattern")
+	logrus.
+		WithField("kind", kind).
+		WithField("cluster-name", rootConfig.ClusterName()).
+		Debug("List cluster resouces")
+	matches, err := ctl.ListClusterResouces(kind, pattern, rootConfig)
+	if err != nil {
+		logrus.
+			WithField("kind", kind).
+			WithField("cluster-name", rootConfig.ClusterName()).
+			Fatal(err)
+	}
+	for _, match := range matches {
+		fmt.Println(match)
+	}
+}
+
+func listRancherResources(kind string) {
+	pattern := viper.GetString("list_cmd.pattern")
+	logrus.
+		WithField("kind", kind).
+		Debug("List global resouces")
+	matches, err := ctl.ListRancherResouces(kind, pattern, rootConfig)
+	if err != nil {
+		logrus.
+			WithField("kind", kind).
+			Fatal(err)
+	}
+	for _, match := range matches {
+		fmt.Println(match)
+	}
+}
+
 func init() {
 
 	listCmd.Flags().String("project-name", "", "The name of the project to list resouces from")
@@ -79,6 +121,12 @@ func init() {
 	listCmd.Flags().String("namespace", "", "The namespace of the project to list resouces from")
 	viper.BindPFlag("list_cmd.namespace", listCmd.Flags().Lookup("namespace"))
 
+	listCmd.Flags().Bool("cluster", false, "List cluster scoped resouces (storage-classes, persistent-volumes) instead of project resouces")
+	viper.BindPFlag("list_cmd.cluster", listCmd.Flags().Lookup("cluster"))
+
+	listCmd.Flags().Bool("global", false, "List global resouces (multi-cluster-apps) instead of project resouces")
+	viper.BindPFlag("list_cmd.global", listCmd.Flags().Lookup("global"))
+
 	listCmd.Flags().String("pattern", "", "Match pattern to filter resouce names")
 	viper.BindPFlag("list_cmd.pattern", listCmd.Flags().Lookup("pattern"))
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/bitgrip/cattlectl/cmd/apply"
 	"github.com/bitgrip/cattlectl/cmd/delete"
+	"github.com/bitgrip/cattlectl/cmd/diff"
 	"github.com/bitgrip/cattlectl/cmd/list"
 	"github.com/bitgrip/cattlectl/cmd/show"
 	homedir "github.com/mitchellh/go-homedir"
@@ -94,6 +95,7 @@ func init() {
 
 	rootCmd.AddCommand(apply.BaseCommand(rancherConfig, initSubCommand))
 	rootCmd.AddCommand(delete.BaseCommand(rancherConfig, initSubCommand))
+	rootCmd.AddCommand(diff.BaseCommand(rancherConfig, initSubCommand))
 	rootCmd.AddCommand(list.BaseCommand(rancherConfig, initSubCommand))
 	rootCmd.AddCommand(show.BaseCommand(rancherConfig, initSubCommand))
 	rootCmd.AddCommand(versionCmd)
@@ -16,4 +16,7 @@ package show
 
 var showLongDescription = `Show the resulting project descriptor after values.yaml is applyed
 
-This is useful for debugging the template without interacting with an actual rancher.`
+This is useful for debugging the template without interacting with an actual rancher.
+
+With ` + "`--output json`" + ` the descriptors are shown as JSON, with ` + "`--output junit`" + `
+each descriptor is reported as a test case which fails if the descriptor can not be parsed.`
@@ -17,7 +17,9 @@ package show
 import (
 	"io/ioutil"
 	"log"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/bitgrip/cattlectl/cmd/utils"
 	"github.com/bitgrip/cattlectl/internal/pkg/config"
@@ -37,6 +39,7 @@ var (
 	}
 	showFile    string
 	valuesFiles []string
+	output      string
 	rootConfig  config.Config
 	initCommand func()
 )
@@ -52,6 +55,10 @@ func BaseCommand(config config.Config, init func()) *cobra.Command {
 
 func show(cmd *cobra.Command, args []string) {
 	initCommand()
+	if output != "" && !ctl.IsOutputFormat(output) {
+		logrus.WithField("output", output).
+			Fatalf("Unsupported output format, use one of [%s]", strings.Join(ctl.OutputFormats, ", "))
+	}
 	values, err := utils.LoadValues(valuesFiles...)
 	if err != nil {
 		log.Fatal(err)
@@ -66,7 +73,20 @@ func show(cmd *cobra.Command, args []string) {
 		logrus.WithField("show_file", showFile).
 			Fatal(err)
 	}
-	err = ctl.ParseAndPrintDescriptor(showFile, projectData, values, rootConfig)
+	switch output {
+	case "":
+		err = ctl.ParseAndPrintDescriptor(showFile, projectData, values, rootConfig)
+	case ctl.OutputJUnit:
+		_, reports, parseErr := ctl.ParseDescriptors(showFile, projectData, values)
+		if err = ctl.WriteReport(os.Stdout, output, "cattlectl show "+showFile, reports); err == nil {
+			err = parseErr
+		}
+	default:
+		var descriptors []interface{}
+		if descriptors, _, err = ctl.ParseDescriptors(showFile, projectData, values); err == nil {
+			err = ctl.WriteDescriptors(os.Stdout, output, descriptors)
+		}
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -75,4 +95,5 @@ func show(cmd *cobra.Command, args []string) {
 func init() {
 	showCmd.Flags().StringVarP(&showFile, "file", "f", "project.yaml", "project file to show")
 	showCmd.Flags().StringSliceVar(&valuesFiles, "values", []string{"values.yaml"}, "values file(s) to show")
+	showCmd.Flags().StringVarP(&output, "output", "o", "", "format of the shown descriptors (json|yaml) or a junit report of the parsed descriptors")
 }
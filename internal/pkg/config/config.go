@@ -14,7 +14,19 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTimeout is the default time to wait for resources to become ready
+const DefaultTimeout = 5 * time.Minute
+
+// DefaultInitScriptShell is the default shell command running the init scripts of persistent volumes
+const DefaultInitScriptShell = "sh -c"
+
+// DefaultInitScriptTimeout is the default time an init script of a persistent volume may run
+const DefaultInitScriptTimeout = time.Minute
 
 // Config provides rancher access informations
 type Config interface {
@@ -28,6 +40,13 @@ type Config interface {
 	ClusterID() string
 	MergeAnswers() bool
 	DryRun() bool
+	Prune() bool
+	KeepGoing() bool
+	Parallelism() int
+	Wait() bool
+	Timeout() time.Duration
+	InitScriptShell() string
+	InitScriptTimeout() time.Duration
 }
 
 func SimpleConfig(
@@ -40,30 +59,51 @@ func SimpleConfig(
 	clusterID string,
 	mergeAnswers bool,
 	dryRun bool,
+	prune bool,
+	keepGoing bool,
+	parallelism int,
+	wait bool,
+	timeout time.Duration,
+	initScriptShell string,
+	initScriptTimeout time.Duration,
 ) Config {
 	return simpleConfig{
-		rancherURL:   rancherURL,
-		insecureAPI:  insecureAPI,
-		caCerts:      caCerts,
-		accessKey:    accessKey,
-		secretKey:    secretKey,
-		clusterName:  clusterName,
-		clusterID:    clusterID,
-		mergeAnswers: mergeAnswers,
-		dryRun:       dryRun,
+		rancherURL:        rancherURL,
+		insecureAPI:       insecureAPI,
+		caCerts:           caCerts,
+		accessKey:         accessKey,
+		secretKey:         secretKey,
+		clusterName:       clusterName,
+		clusterID:         clusterID,
+		mergeAnswers:      mergeAnswers,
+		dryRun:            dryRun,
+		prune:             prune,
+		keepGoing:         keepGoing,
+		parallelism:       parallelism,
+		wait:              wait,
+		timeout:           timeout,
+		initScriptShell:   initScriptShell,
+		initScriptTimeout: initScriptTimeout,
 	}
 }
 
 type simpleConfig struct {
-	rancherURL   string
-	insecureAPI  bool
-	caCerts      string
-	accessKey    string
-	secretKey    string
-	clusterName  string
-	clusterID    string
-	mergeAnswers bool
-	dryRun       bool
+	rancherURL        string
+	insecureAPI       bool
+	caCerts           string
+	accessKey         string
+	secretKey         string
+	clusterName       string
+	clusterID         string
+	mergeAnswers      bool
+	dryRun            bool
+	prune             bool
+	keepGoing         bool
+	parallelism       int
+	wait              bool
+	timeout           time.Duration
+	initScriptShell   string
+	initScriptTimeout time.Duration
 }
 
 func (config simpleConfig) RancherURL() string {
@@ -105,3 +145,31 @@ func (config simpleConfig) MergeAnswers() bool {
 func (config simpleConfig) DryRun() bool {
 	return config.dryRun
 }
+
+func (config simpleConfig) Prune() bool {
+	return config.prune
+}
+
+func (config simpleConfig) KeepGoing() bool {
+	return config.keepGoing
+}
+
+func (config simpleConfig) Parallelism() int {
+	return config.parallelism
+}
+
+func (config simpleConfig) Wait() bool {
+	return config.wait
+}
+
+func (config simpleConfig) Timeout() time.Duration {
+	return config.timeout
+}
+
+func (config simpleConfig) InitScriptShell() string {
+	return config.initScriptShell
+}
+
+func (config simpleConfig) InitScriptTimeout() time.Duration {
+	return config.initScriptTimeout
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/config"
 	rancher "github.com/bitgrip/cattlectl/internal/pkg/rancher"
@@ -36,27 +37,66 @@ var (
 
 	newRancherClient = rancher_client.NewRancherClient
 
-	newRancherConverger     = rancher.NewRancherConverger
-	newClusterConverger     = cluster.NewClusterConverger
-	newProjectConverger     = project.NewProjectConverger
-	newJobConverger         = project.NewJobConverger
-	newCronJobConverger     = project.NewCronJobConverger
-	newDeploymentConverger  = project.NewDeploymentConverger
-	newDaemonSetConverger   = project.NewDaemonSetConverger
-	newStatefulSetConverger = project.NewStatefulSetConverger
-
-	newRancherParser     = rancher.NewRancherParser
-	newClusterParser     = cluster.NewClusterParser
-	newProjectParser     = project.NewProjectParser
-	newJobParser         = project.NewJobParser
-	newCronJobParser     = project.NewCronJobParser
-	newDeploymentParser  = project.NewDeploymentParser
-	newDaemonSetParser   = project.NewDaemonSetParser
-	newStatefulSetParser = project.NewStatefulSetParser
+	newRancherConverger         = rancher.NewRancherConverger
+	newClusterConverger         = cluster.NewClusterConverger
+	newPruningClusterConverger  = cluster.NewPruningClusterConverger
+	newProjectConverger         = project.NewProjectConverger
+	newPruningProjectConverger  = project.NewPruningProjectConverger
+	newJobConverger             = project.NewJobConverger
+	newCronJobConverger         = project.NewCronJobConverger
+	newDeploymentConverger      = project.NewDeploymentConverger
+	newDaemonSetConverger       = project.NewDaemonSetConverger
+	newStatefulSetConverger     = project.NewStatefulSetConverger
+	newServiceConverger         = project.NewServiceConverger
+	newIngressConverger         = project.NewIngressConverger
+	newMultiClusterAppConverger = rancher.NewMultiClusterAppConverger
+
+	newRancherParser         = rancher.NewRancherParser
+	newClusterParser         = cluster.NewClusterParser
+	newProjectParser         = project.NewProjectParser
+	newJobParser             = project.NewJobParser
+	newCronJobParser         = project.NewCronJobParser
+	newDeploymentParser      = project.NewDeploymentParser
+	newDaemonSetParser       = project.NewDaemonSetParser
+	newStatefulSetParser     = project.NewStatefulSetParser
+	newServiceParser         = project.NewServiceParser
+	newIngressParser         = project.NewIngressParser
+	newMultiClusterAppParser = rancher.NewMultiClusterAppParser
 )
 
 // ApplyDescriptor the the CTL perform a apply action
+//
+// The descriptors are applied in the order of the file, descriptors declaring
+// metadata.depends_on are applied after the descriptors they depend on.
+//
+// With config.KeepGoing() all descriptors and all their resources are applied even if
+// some of them fail, all failures are returned as descriptor.MultiError
 func ApplyDescriptor(file string, fullData []byte, values map[string]interface{}, config config.Config) (result descriptor.ConvergeResult, err error) {
+	nodes, decodeErr := descriptorNodes(file, fullData, values, config)
+	converger, err := descriptor.NewParallelConverger(nodes, 1)
+	if err != nil {
+		return
+	}
+	if config.KeepGoing() {
+		result, err = converger.ConvergeAll(config.DryRun())
+	} else {
+		result, err = converger.Converge(config.DryRun())
+	}
+	if decodeErr != nil && (err == nil || config.KeepGoing()) {
+		// the documents following a broken document can not be read
+		var errs descriptor.MultiError
+		if err != nil {
+			errs = errs.Append(err)
+		}
+		err = errs.Append(decodeErr)
+	}
+	return
+}
+
+// descriptorNodes decodes all documents of the 'data' into graph nodes
+// which are referenced by kind, namespace and name of the descriptor.
+// The nodes decoded before a broken document are returned together with the decode error.
+func descriptorNodes(file string, fullData []byte, values map[string]interface{}, config config.Config) (nodes []descriptor.ParallelNode, err error) {
 	decoder := yaml.NewDecoder(bytes.NewReader(fullData))
 	for {
 		apiVersion, kind, object, decodeErr := DecodeToApply(decoder)
@@ -66,113 +106,236 @@ func ApplyDescriptor(file string, fullData []byte, values map[string]interface{}
 			} else if decodeErr.Error() == "EOF" {
 				break
 			}
-			err = decodeErr
-			return
+			return nodes, decodeErr
 		}
-		var (
-			singleObjectData []byte
-			singleResult     descriptor.ConvergeResult
-		)
-		if singleObjectData, err = yaml.Marshal(object); err != nil {
-			return
-		}
-		if !isSupportedAPIVersion(apiVersion) {
-			return result, fmt.Errorf("Unsupported api version %s", apiVersion)
-		}
-		switch kind {
-		case rancherModel.RancherKind:
-			descriptor := rancherModel.Rancher{}
-			if err = newRancherParser(file, values).Parse(singleObjectData, &descriptor); err != nil {
-				return
-			}
-			if singleResult, err = ApplyRancher(descriptor, config); err != nil {
-				return
-			}
-		case rancherModel.ClusterKind:
-			descriptor := clusterModel.Cluster{}
-			if err = newClusterParser(file, values).Parse(singleObjectData, &descriptor); err != nil {
-				return
-			}
-			if singleResult, err = ApplyCluster(descriptor, config); err != nil {
-				return
-			}
-		case rancherModel.ProjectKind:
-			project := projectModel.Project{}
-			if err = newProjectParser(file, values).Parse(singleObjectData, &project); err != nil {
+		namespace, name := descriptorName(object)
+		keys := project.DependencyKeys(kind, namespace, name)
+		if kind == rancherModel.ProjectKind {
+			var resourceKeys []string
+			if resourceKeys, err = projectResourceKeys(object); err != nil {
 				return
 			}
-			if singleResult, err = ApplyProject(project, config); err != nil {
-				return
-			}
-		case rancherModel.JobKind:
-			jobDescriptor := projectModel.JobDescriptor{}
-			if err = newJobParser(file, values).Parse(singleObjectData, &jobDescriptor); err != nil {
-				return
-			}
-			if singleResult, err = ApplyJob(jobDescriptor, config); err != nil {
-				return
-			}
-		case rancherModel.CronJobKind:
-			cronJobDescriptor := projectModel.CronJobDescriptor{}
-			if err = newCronJobParser(file, values).Parse(singleObjectData, &cronJobDescriptor); err != nil {
-				return
-			}
-			if singleResult, err = ApplyCronJob(cronJobDescriptor, config); err != nil {
-				return
-			}
-		case rancherModel.DeploymentKind:
-			deploymentDescriptor := projectModel.DeploymentDescriptor{}
-			if err = newDeploymentParser(file, values).Parse(singleObjectData, &deploymentDescriptor); err != nil {
-				return
-			}
-			if singleResult, err = ApplyDeployment(deploymentDescriptor, config); err != nil {
-				return
-			}
-		case rancherModel.DaemonSetKind:
-			daemonSetDescriptor := projectModel.DaemonSetDescriptor{}
-			if err = newDaemonSetParser(file, values).Parse(singleObjectData, &daemonSetDescriptor); err != nil {
-				return
-			}
-			if singleResult, err = ApplyDaemonSet(daemonSetDescriptor, config); err != nil {
-				return
-			}
-		case rancherModel.StatefulSetKind:
-			statefulSetDescriptor := projectModel.StatefulSetDescriptor{}
-			if err = newStatefulSetParser(file, values).Parse(singleObjectData, &statefulSetDescriptor); err != nil {
-				return
-			}
-			if singleResult, err = ApplyStatefulSet(statefulSetDescriptor, config); err != nil {
-				return
-			}
-		default:
-			return result, fmt.Errorf("Unknown descriptor %s", kind)
+			keys = append(keys, resourceKeys...)
+		}
+		node := descriptor.ParallelNode{
+			Keys: keys,
+			Converger: &documentConverger{
+				file:       file,
+				apiVersion: apiVersion,
+				kind:       kind,
+				object:     object,
+				values:     values,
+				config:     config,
+			},
+		}
+		var dependencies []projectModel.Dependency
+		if dependencies, err = descriptorDependencies(object); err != nil {
+			return
 		}
-		result.CreatedResources = append(result.CreatedResources, singleResult.CreatedResources...)
-		result.UpgradedResources = append(result.UpgradedResources, singleResult.UpgradedResources...)
+		for _, dependency := range dependencies {
+			node.DependsOn = append(node.DependsOn, project.DependencyKey(dependency))
+		}
+		nodes = append(nodes, node)
 	}
 	return
 }
 
-// ApplyCronJob the the CTL perform a apply action to a cronjob descriptor
-func ApplyCronJob(cronJobDescriptor projectModel.CronJobDescriptor, config config.Config) (result descriptor.ConvergeResult, err error) {
-	_, _, projectClient, err := fillWorkloadMetadata(&cronJobDescriptor.Metadata, config)
+// descriptorDependencies reads metadata.depends_on of a raw descriptor
+func descriptorDependencies(object map[string]interface{}) ([]projectModel.Dependency, error) {
+	metadata := struct {
+		DependsOn []projectModel.Dependency `yaml:"depends_on,omitempty"`
+	}{}
+	data, err := yaml.Marshal(object["metadata"])
 	if err != nil {
-		return
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, err
 	}
-	converger, err := newCronJobConverger(cronJobDescriptor, projectClient)
+	return metadata.DependsOn, nil
+}
+
+// declaredResource is the name of a resource declared inside a project descriptor
+type declaredResource struct {
+	Name      string `yaml:"name,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// projectResourceKeys are the keys of the resources declared inside a raw project descriptor,
+// a dependency on one of them is a dependency on the whole project document.
+// Resources of included files are read when the project is applied and can not be referenced.
+func projectResourceKeys(object map[string]interface{}) ([]string, error) {
+	resources := struct {
+		Namespaces             []declaredResource `yaml:"namespaces,omitempty"`
+		StorageClasses         []declaredResource `yaml:"storage_classes,omitempty"`
+		PersistentVolumes      []declaredResource `yaml:"persistent_volumes,omitempty"`
+		Apps                   []declaredResource `yaml:"apps,omitempty"`
+		NetworkPolicies        []declaredResource `yaml:"network_policies,omitempty"`
+		PersistentVolumeClaims []declaredResource `yaml:"persistent_volume_claims,omitempty"`
+		Resources              struct {
+			Certificates      []declaredResource `yaml:"certificates,omitempty"`
+			ConfigMaps        []declaredResource `yaml:"config_maps,omitempty"`
+			DockerCredentials []declaredResource `yaml:"docker_credentials,omitempty"`
+			Secrets           []declaredResource `yaml:"secrets,omitempty"`
+			Services          []declaredResource `yaml:"services,omitempty"`
+			Ingresses         []declaredResource `yaml:"ingresses,omitempty"`
+		} `yaml:"resources,omitempty"`
+	}{}
+	data, err := yaml.Marshal(object)
 	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, &resources); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0)
+	add := func(kind string, namespaced bool, declared []declaredResource) {
+		for _, resource := range declared {
+			namespace := ""
+			if namespaced {
+				namespace = resource.Namespace
+			}
+			keys = append(keys, project.DependencyKeys(kind, namespace, resource.Name)...)
+		}
+	}
+	add(rancherModel.Namespace, false, resources.Namespaces)
+	add(rancherModel.StorageClass, false, resources.StorageClasses)
+	add(rancherModel.PersistentVolume, false, resources.PersistentVolumes)
+	add(rancherModel.App, false, resources.Apps)
+	add(rancherModel.NetworkPolicy, true, resources.NetworkPolicies)
+	add(rancherModel.PersistentVolumeClaim, true, resources.PersistentVolumeClaims)
+	add(rancherModel.Certificate, true, resources.Resources.Certificates)
+	add(rancherModel.ConfigMap, true, resources.Resources.ConfigMaps)
+	add(rancherModel.DockerCredential, true, resources.Resources.DockerCredentials)
+	add(rancherModel.Secret, true, resources.Resources.Secrets)
+	add(rancherModel.ServiceKind, true, resources.Resources.Services)
+	add(rancherModel.IngressKind, true, resources.Resources.Ingresses)
+	return keys, nil
+}
+
+// documentConverger applies a single document of a descriptor file
+type documentConverger struct {
+	file       string
+	apiVersion string
+	kind       string
+	object     map[string]interface{}
+	values     map[string]interface{}
+	config     config.Config
+}
+
+func (converger *documentConverger) Converge(bool) (descriptor.ConvergeResult, error) {
+	return applySingleDescriptor(converger.file, converger.apiVersion, converger.kind, converger.object, converger.values, converger.config)
+}
+
+func applySingleDescriptor(file, apiVersion, kind string, object map[string]interface{}, values map[string]interface{}, config config.Config) (result descriptor.ConvergeResult, err error) {
+	var (
+		singleObjectData []byte
+		converger        descriptor.Converger
+	)
+	if singleObjectData, err = yaml.Marshal(object); err != nil {
+		return
+	}
+	if !isSupportedAPIVersion(apiVersion) {
+		return result, fmt.Errorf("Unsupported api version %s", apiVersion)
+	}
+	if converger, err = newDescriptorConverger(file, kind, singleObjectData, values, config); err != nil {
+		if config.KeepGoing() {
+			namespace, name := descriptorName(object)
+			err = descriptor.ResourceError{Type: kind, Namespace: namespace, Name: name, Err: err}
+		}
 		return
 	}
+	if keepGoingConverger, isKeepGoing := converger.(descriptor.KeepGoingConverger); isKeepGoing && config.KeepGoing() {
+		return keepGoingConverger.ConvergeAll(config.DryRun())
+	}
 	return converger.Converge(config.DryRun())
 }
 
-// ApplyJob the the CTL perform a apply action to a job descriptor
-func ApplyJob(jobDescriptor projectModel.JobDescriptor, config config.Config) (result descriptor.ConvergeResult, err error) {
-	_, _, projectClient, err := fillWorkloadMetadata(&jobDescriptor.Metadata, config)
+func newDescriptorConverger(file, kind string, data []byte, values map[string]interface{}, config config.Config) (descriptor.Converger, error) {
+	switch kind {
+	case rancherModel.RancherKind:
+		rancher := rancherModel.Rancher{}
+		if err := newRancherParser(file, values).Parse(data, &rancher); err != nil {
+			return nil, err
+		}
+		return newRancherDescriptorConverger(rancher, config)
+	case rancherModel.ClusterKind:
+		cluster := clusterModel.Cluster{}
+		if err := newClusterParser(file, values).Parse(data, &cluster); err != nil {
+			return nil, err
+		}
+		return newClusterDescriptorConverger(cluster, config)
+	case rancherModel.ProjectKind:
+		project := projectModel.Project{}
+		if err := newProjectParser(file, values).Parse(data, &project); err != nil {
+			return nil, err
+		}
+		return newProjectDescriptorConverger(project, config)
+	case rancherModel.JobKind:
+		jobDescriptor := projectModel.JobDescriptor{}
+		if err := newJobParser(file, values).Parse(data, &jobDescriptor); err != nil {
+			return nil, err
+		}
+		return newJobDescriptorConverger(jobDescriptor, config)
+	case rancherModel.CronJobKind:
+		cronJobDescriptor := projectModel.CronJobDescriptor{}
+		if err := newCronJobParser(file, values).Parse(data, &cronJobDescriptor); err != nil {
+			return nil, err
+		}
+		return newCronJobDescriptorConverger(cronJobDescriptor, config)
+	case rancherModel.DeploymentKind:
+		deploymentDescriptor := projectModel.DeploymentDescriptor{}
+		if err := newDeploymentParser(file, values).Parse(data, &deploymentDescriptor); err != nil {
+			return nil, err
+		}
+		return newDeploymentDescriptorConverger(deploymentDescriptor, config)
+	case rancherModel.DaemonSetKind:
+		daemonSetDescriptor := projectModel.DaemonSetDescriptor{}
+		if err := newDaemonSetParser(file, values).Parse(data, &daemonSetDescriptor); err != nil {
+			return nil, err
+		}
+		return newDaemonSetDescriptorConverger(daemonSetDescriptor, config)
+	case rancherModel.StatefulSetKind:
+		statefulSetDescriptor := projectModel.StatefulSetDescriptor{}
+		if err := newStatefulSetParser(file, values).Parse(data, &statefulSetDescriptor); err != nil {
+			return nil, err
+		}
+		return newStatefulSetDescriptorConverger(statefulSetDescriptor, config)
+	case rancherModel.ServiceKind:
+		serviceDescriptor := projectModel.ServiceDescriptor{}
+		if err := newServiceParser(file, values).Parse(data, &serviceDescriptor); err != nil {
+			return nil, err
+		}
+		return newServiceDescriptorConverger(serviceDescriptor, config)
+	case rancherModel.IngressKind:
+		ingressDescriptor := projectModel.IngressDescriptor{}
+		if err := newIngressParser(file, values).Parse(data, &ingressDescriptor); err != nil {
+			return nil, err
+		}
+		return newIngressDescriptorConverger(ingressDescriptor, config)
+	case rancherModel.MultiClusterAppKind:
+		multiClusterAppDescriptor := rancherModel.MultiClusterAppDescriptor{}
+		if err := newMultiClusterAppParser(file, values).Parse(data, &multiClusterAppDescriptor); err != nil {
+			return nil, err
+		}
+		return newMultiClusterAppDescriptorConverger(multiClusterAppDescriptor, config)
+	default:
+		return nil, fmt.Errorf("Unknown descriptor %s", kind)
+	}
+}
+
+// ApplyCronJob the the CTL perform a apply action to a cronjob descriptor
+func ApplyCronJob(cronJobDescriptor projectModel.CronJobDescriptor, config config.Config) (result descriptor.ConvergeResult, err error) {
+	converger, err := newCronJobDescriptorConverger(cronJobDescriptor, config)
 	if err != nil {
 		return
 	}
-	converger, err := newJobConverger(jobDescriptor, projectClient)
+	return converger.Converge(config.DryRun())
+}
+
+// ApplyJob the the CTL perform a apply action to a job descriptor
+func ApplyJob(jobDescriptor projectModel.JobDescriptor, config config.Config) (result descriptor.ConvergeResult, err error) {
+	converger, err := newJobDescriptorConverger(jobDescriptor, config)
 	if err != nil {
 		return
 	}
@@ -181,11 +344,7 @@ func ApplyJob(jobDescriptor projectModel.JobDescriptor, config config.Config) (r
 
 // ApplyDeployment the the CTL perform a apply action to a deployment descriptor
 func ApplyDeployment(deploymentDescriptor projectModel.DeploymentDescriptor, config config.Config) (result descriptor.ConvergeResult, err error) {
-	_, _, projectClient, err := fillWorkloadMetadata(&deploymentDescriptor.Metadata, config)
-	if err != nil {
-		return
-	}
-	converger, err := newDeploymentConverger(deploymentDescriptor, projectClient)
+	converger, err := newDeploymentDescriptorConverger(deploymentDescriptor, config)
 	if err != nil {
 		return
 	}
@@ -194,11 +353,7 @@ func ApplyDeployment(deploymentDescriptor projectModel.DeploymentDescriptor, con
 
 // ApplyDaemonSet the the CTL perform a apply action to a daemon set descriptor
 func ApplyDaemonSet(daemonSetDescriptor projectModel.DaemonSetDescriptor, config config.Config) (result descriptor.ConvergeResult, err error) {
-	_, _, projectClient, err := fillWorkloadMetadata(&daemonSetDescriptor.Metadata, config)
-	if err != nil {
-		return
-	}
-	converger, err := newDaemonSetConverger(daemonSetDescriptor, projectClient)
+	converger, err := newDaemonSetDescriptorConverger(daemonSetDescriptor, config)
 	if err != nil {
 		return
 	}
@@ -207,24 +362,34 @@ func ApplyDaemonSet(daemonSetDescriptor projectModel.DaemonSetDescriptor, config
 
 // ApplyStatefulSet the the CTL perform a apply action to a stateful set descriptor
 func ApplyStatefulSet(statefulSetDescriptor projectModel.StatefulSetDescriptor, config config.Config) (result descriptor.ConvergeResult, err error) {
-	_, _, projectClient, err := fillWorkloadMetadata(&statefulSetDescriptor.Metadata, config)
+	converger, err := newStatefulSetDescriptorConverger(statefulSetDescriptor, config)
 	if err != nil {
 		return
 	}
-	converger, err := newStatefulSetConverger(statefulSetDescriptor, projectClient)
+	return converger.Converge(config.DryRun())
+}
+
+// ApplyService the the CTL perform a apply action to a service descriptor
+func ApplyService(serviceDescriptor projectModel.ServiceDescriptor, config config.Config) (result descriptor.ConvergeResult, err error) {
+	converger, err := newServiceDescriptorConverger(serviceDescriptor, config)
 	if err != nil {
 		return
 	}
 	return converger.Converge(config.DryRun())
 }
 
-// ApplyProject the the CTL perform a apply action to a project descriptor
-func ApplyProject(project projectModel.Project, config config.Config) (result descriptor.ConvergeResult, err error) {
-	_, clusterClient, err := fillProjectMetadata(&project.Metadata, config)
+// ApplyIngress the the CTL perform a apply action to an ingress descriptor
+func ApplyIngress(ingressDescriptor projectModel.IngressDescriptor, config config.Config) (result descriptor.ConvergeResult, err error) {
+	converger, err := newIngressDescriptorConverger(ingressDescriptor, config)
 	if err != nil {
 		return
 	}
-	converger, err := newProjectConverger(project, clusterClient)
+	return converger.Converge(config.DryRun())
+}
+
+// ApplyProject the the CTL perform a apply action to a project descriptor
+func ApplyProject(project projectModel.Project, config config.Config) (result descriptor.ConvergeResult, err error) {
+	converger, err := newProjectDescriptorConverger(project, config)
 	if err != nil {
 		return
 	}
@@ -233,28 +398,114 @@ func ApplyProject(project projectModel.Project, config config.Config) (result de
 
 // ApplyCluster the the CTL perform a apply action to a cluster descriptor
 func ApplyCluster(cluster clusterModel.Cluster, config config.Config) (result descriptor.ConvergeResult, err error) {
-	rancherClient, err := fillClusterMetadata(&cluster.Metadata, config)
+	converger, err := newClusterDescriptorConverger(cluster, config)
 	if err != nil {
 		return
 	}
-	converger, err := newClusterConverger(cluster, rancherClient)
+	return converger.Converge(config.DryRun())
+}
+
+// ApplyRancher the the CTL perform a apply action to a cluster descriptor
+func ApplyRancher(rancher rancherModel.Rancher, config config.Config) (result descriptor.ConvergeResult, err error) {
+	converger, err := newRancherDescriptorConverger(rancher, config)
 	if err != nil {
 		return
 	}
 	return converger.Converge(config.DryRun())
 }
 
-// ApplyRancher the the CTL perform a apply action to a cluster descriptor
-func ApplyRancher(rancher rancherModel.Rancher, config config.Config) (result descriptor.ConvergeResult, err error) {
+func newCronJobDescriptorConverger(cronJobDescriptor projectModel.CronJobDescriptor, config config.Config) (descriptor.Converger, error) {
+	_, _, projectClient, err := fillWorkloadMetadata(&cronJobDescriptor.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+	return newCronJobConverger(cronJobDescriptor, projectClient)
+}
+
+func newJobDescriptorConverger(jobDescriptor projectModel.JobDescriptor, config config.Config) (descriptor.Converger, error) {
+	_, _, projectClient, err := fillWorkloadMetadata(&jobDescriptor.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+	return newJobConverger(jobDescriptor, projectClient)
+}
+
+func newDeploymentDescriptorConverger(deploymentDescriptor projectModel.DeploymentDescriptor, config config.Config) (descriptor.Converger, error) {
+	_, _, projectClient, err := fillWorkloadMetadata(&deploymentDescriptor.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+	return newDeploymentConverger(deploymentDescriptor, projectClient)
+}
+
+func newDaemonSetDescriptorConverger(daemonSetDescriptor projectModel.DaemonSetDescriptor, config config.Config) (descriptor.Converger, error) {
+	_, _, projectClient, err := fillWorkloadMetadata(&daemonSetDescriptor.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+	return newDaemonSetConverger(daemonSetDescriptor, projectClient)
+}
+
+func newStatefulSetDescriptorConverger(statefulSetDescriptor projectModel.StatefulSetDescriptor, config config.Config) (descriptor.Converger, error) {
+	_, _, projectClient, err := fillWorkloadMetadata(&statefulSetDescriptor.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+	return newStatefulSetConverger(statefulSetDescriptor, projectClient)
+}
+
+func newServiceDescriptorConverger(serviceDescriptor projectModel.ServiceDescriptor, config config.Config) (descriptor.Converger, error) {
+	_, _, projectClient, err := fillWorkloadMetadata(&serviceDescriptor.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+	return newServiceConverger(serviceDescriptor, projectClient)
+}
+
+func newIngressDescriptorConverger(ingressDescriptor projectModel.IngressDescriptor, config config.Config) (descriptor.Converger, error) {
+	_, _, projectClient, err := fillWorkloadMetadata(&ingressDescriptor.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+	return newIngressConverger(ingressDescriptor, projectClient)
+}
+
+func newProjectDescriptorConverger(project projectModel.Project, config config.Config) (descriptor.Converger, error) {
+	_, clusterClient, err := fillProjectMetadata(&project.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Prune() {
+		return newPruningProjectConverger(project, clusterClient, config.Parallelism())
+	}
+	return newProjectConverger(project, clusterClient, config.Parallelism())
+}
+
+func newClusterDescriptorConverger(cluster clusterModel.Cluster, config config.Config) (descriptor.Converger, error) {
+	rancherClient, err := fillClusterMetadata(&cluster.Metadata, config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Prune() {
+		return newPruningClusterConverger(cluster, rancherClient)
+	}
+	return newClusterConverger(cluster, rancherClient)
+}
+
+func newRancherDescriptorConverger(rancher rancherModel.Rancher, config config.Config) (descriptor.Converger, error) {
 	rancherConfig, err := fillRancherMetadata(&rancher.Metadata, config)
 	if err != nil {
-		return
+		return nil, err
 	}
-	converger, err := newRancherConverger(rancher, rancherConfig)
+	return newRancherConverger(rancher, rancherConfig)
+}
+
+func newMultiClusterAppDescriptorConverger(multiClusterAppDescriptor rancherModel.MultiClusterAppDescriptor, config config.Config) (descriptor.Converger, error) {
+	rancherConfig, err := fillRancherMetadata(&multiClusterAppDescriptor.Metadata, config)
 	if err != nil {
-		return
+		return nil, err
 	}
-	return converger.Converge(config.DryRun())
+	return newMultiClusterAppConverger(multiClusterAppDescriptor, rancherConfig)
 }
 
 // DecodeToApply will decode the next object from the decoder
@@ -302,53 +553,9 @@ func ParseAndPrintDescriptor(file string, fullData []byte, values map[string]int
 		if !isSupportedAPIVersion(apiVersion) {
 			return fmt.Errorf("Unsupported api version %s", apiVersion)
 		}
-		var descriptor interface{}
-		switch kind {
-		case rancherModel.RancherKind:
-			descriptor = rancherModel.Rancher{}
-			if err = newRancherParser(file, values).Parse(singleObjectData, &descriptor); err != nil {
-				return err
-			}
-		case rancherModel.ClusterKind:
-			descriptor = clusterModel.Cluster{}
-			if err = newClusterParser(file, values).Parse(singleObjectData, &descriptor); err != nil {
-				return err
-			}
-		case rancherModel.ProjectKind:
-			project := projectModel.Project{}
-			if err = newProjectParser(file, values).Parse(singleObjectData, &project); err != nil {
-				return err
-			}
-			descriptor = project
-		case rancherModel.JobKind:
-			jobDescriptor := projectModel.JobDescriptor{}
-			if err = newJobParser(file, values).Parse(singleObjectData, &jobDescriptor); err != nil {
-				return err
-			}
-			descriptor = jobDescriptor
-		case rancherModel.CronJobKind:
-			cronJobDescriptor := projectModel.CronJobDescriptor{}
-			if err = newCronJobParser(file, values).Parse(singleObjectData, &cronJobDescriptor); err != nil {
-				return err
-			}
-			descriptor = cronJobDescriptor
-		case rancherModel.DeploymentKind:
-			descriptor = projectModel.Deployment{}
-			if err = newDeploymentParser(file, values).Parse(singleObjectData, &descriptor); err != nil {
-				return err
-			}
-		case rancherModel.StatefulSetKind:
-			descriptor = projectModel.StatefulSet{}
-			if err = newStatefulSetParser(file, values).Parse(singleObjectData, &descriptor); err != nil {
-				return err
-			}
-		case rancherModel.DaemonSetKind:
-			descriptor = projectModel.DaemonSet{}
-			if err = newDaemonSetParser(file, values).Parse(singleObjectData, &descriptor); err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("Unknown descriptor %s", kind)
+		descriptor, err := parseDescriptor(file, kind, singleObjectData, values)
+		if err != nil {
+			return err
 		}
 		out, err := yaml.Marshal(descriptor)
 		if err != nil {
@@ -361,6 +568,128 @@ func ParseAndPrintDescriptor(file string, fullData []byte, values map[string]int
 	return nil
 }
 
+// ParseDescriptors parse all descriptors of the 'data' and report the outcome of each descriptor
+func ParseDescriptors(file string, fullData []byte, values map[string]interface{}) (descriptors []interface{}, reports []descriptor.ResourceReport, err error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(fullData))
+	for {
+		apiVersion, kind, object, decodeErr := DecodeToApply(decoder)
+		if decodeErr != nil {
+			if decodeErr.Error() == "EMPTY" {
+				continue
+			} else if decodeErr.Error() == "EOF" {
+				break
+			}
+			err = decodeErr
+			return
+		}
+		var (
+			start            = time.Now()
+			singleObjectData []byte
+			parsed           interface{}
+		)
+		if singleObjectData, err = yaml.Marshal(object); err == nil {
+			if isSupportedAPIVersion(apiVersion) {
+				parsed, err = parseDescriptor(file, kind, singleObjectData, values)
+			} else {
+				err = fmt.Errorf("Unsupported api version %s", apiVersion)
+			}
+		}
+		namespace, name := descriptorName(object)
+		reports = append(reports, descriptor.NewReport(kind, namespace, name, actionParsed, start, err))
+		if err != nil {
+			return
+		}
+		descriptors = append(descriptors, parsed)
+	}
+	return
+}
+
+func parseDescriptor(file, kind string, data []byte, values map[string]interface{}) (interface{}, error) {
+	var parsed interface{}
+	switch kind {
+	case rancherModel.RancherKind:
+		parsed = rancherModel.Rancher{}
+		if err := newRancherParser(file, values).Parse(data, &parsed); err != nil {
+			return nil, err
+		}
+	case rancherModel.ClusterKind:
+		parsed = clusterModel.Cluster{}
+		if err := newClusterParser(file, values).Parse(data, &parsed); err != nil {
+			return nil, err
+		}
+	case rancherModel.ProjectKind:
+		project := projectModel.Project{}
+		if err := newProjectParser(file, values).Parse(data, &project); err != nil {
+			return nil, err
+		}
+		parsed = project
+	case rancherModel.JobKind:
+		jobDescriptor := projectModel.JobDescriptor{}
+		if err := newJobParser(file, values).Parse(data, &jobDescriptor); err != nil {
+			return nil, err
+		}
+		parsed = jobDescriptor
+	case rancherModel.CronJobKind:
+		cronJobDescriptor := projectModel.CronJobDescriptor{}
+		if err := newCronJobParser(file, values).Parse(data, &cronJobDescriptor); err != nil {
+			return nil, err
+		}
+		parsed = cronJobDescriptor
+	case rancherModel.DeploymentKind:
+		parsed = projectModel.Deployment{}
+		if err := newDeploymentParser(file, values).Parse(data, &parsed); err != nil {
+			return nil, err
+		}
+	case rancherModel.StatefulSetKind:
+		parsed = projectModel.StatefulSet{}
+		if err := newStatefulSetParser(file, values).Parse(data, &parsed); err != nil {
+			return nil, err
+		}
+	case rancherModel.DaemonSetKind:
+		parsed = projectModel.DaemonSet{}
+		if err := newDaemonSetParser(file, values).Parse(data, &parsed); err != nil {
+			return nil, err
+		}
+	case rancherModel.ServiceKind:
+		serviceDescriptor := projectModel.ServiceDescriptor{}
+		if err := newServiceParser(file, values).Parse(data, &serviceDescriptor); err != nil {
+			return nil, err
+		}
+		parsed = serviceDescriptor
+	case rancherModel.IngressKind:
+		ingressDescriptor := projectModel.IngressDescriptor{}
+		if err := newIngressParser(file, values).Parse(data, &ingressDescriptor); err != nil {
+			return nil, err
+		}
+		parsed = ingressDescriptor
+	case rancherModel.MultiClusterAppKind:
+		multiClusterAppDescriptor := rancherModel.MultiClusterAppDescriptor{}
+		if err := newMultiClusterAppParser(file, values).Parse(data, &multiClusterAppDescriptor); err != nil {
+			return nil, err
+		}
+		parsed = multiClusterAppDescriptor
+	default:
+		return nil, fmt.Errorf("Unknown descriptor %s", kind)
+	}
+	return parsed, nil
+}
+
+// descriptorName reads namespace and name of a raw descriptor,
+// projects are named by their metadata and workloads by their spec
+func descriptorName(object map[string]interface{}) (namespace, name string) {
+	metadata, _ := object["metadata"].(map[interface{}]interface{})
+	spec, _ := object["spec"].(map[interface{}]interface{})
+	if value, found := metadata["namespace"]; found {
+		namespace = fmt.Sprint(value)
+	}
+	if value, found := metadata["name"]; found {
+		name = fmt.Sprint(value)
+	} else if value, found := spec["name"]; found {
+		name = fmt.Sprint(value)
+	}
+	return
+}
+
 func fillWorkloadMetadata(metadata *projectModel.WorkloadMetadata, config config.Config) (rancher_client.RancherClient, rancher_client.ClusterClient, rancher_client.ProjectClient, error) {
 	if config.RancherURL() != "" {
 		metadata.RancherURL = config.RancherURL()
@@ -382,12 +711,15 @@ func fillWorkloadMetadata(metadata *projectModel.WorkloadMetadata, config config
 	}
 
 	rancherClient, err := newRancherClient(rancher_client.RancherConfig{
-		RancherURL:   metadata.RancherURL,
-		AccessKey:    metadata.AccessKey,
-		SecretKey:    metadata.SecretKey,
-		Insecure:     config.InsecureAPI(),
-		CACerts:      config.CACerts(),
-		MergeAnswers: config.MergeAnswers(),
+		RancherURL:        metadata.RancherURL,
+		AccessKey:         metadata.AccessKey,
+		SecretKey:         metadata.SecretKey,
+		Insecure:          config.InsecureAPI(),
+		CACerts:           config.CACerts(),
+		MergeAnswers:      config.MergeAnswers(),
+		WaitTimeout:       waitTimeout(config),
+		InitScriptShell:   config.InitScriptShell(),
+		InitScriptTimeout: config.InitScriptTimeout(),
 	})
 	if err != nil {
 		return nil, nil, nil, err
@@ -424,12 +756,16 @@ func fillProjectMetadata(metadata *projectModel.ProjectMetadata, config config.C
 	}
 
 	rancherClient, err := newRancherClient(rancher_client.RancherConfig{
-		RancherURL:   metadata.RancherURL,
-		AccessKey:    metadata.AccessKey,
-		SecretKey:    metadata.SecretKey,
-		Insecure:     config.InsecureAPI(),
-		CACerts:      config.CACerts(),
-		MergeAnswers: config.MergeAnswers(),
+		RancherURL:        metadata.RancherURL,
+		AccessKey:         metadata.AccessKey,
+		SecretKey:         metadata.SecretKey,
+		Insecure:          config.InsecureAPI(),
+		CACerts:           config.CACerts(),
+		MergeAnswers:      config.MergeAnswers(),
+		WaitTimeout:       waitTimeout(config),
+		InitScriptShell:   config.InitScriptShell(),
+		InitScriptTimeout: config.InitScriptTimeout(),
+		Prune:             config.Prune(),
 	})
 	if err != nil {
 		return nil, nil, err
@@ -462,11 +798,15 @@ func fillClusterMetadata(metadata *clusterModel.ClusterMetadata, config config.C
 	}
 
 	rancherClient, err := newRancherClient(rancher_client.RancherConfig{
-		RancherURL: metadata.RancherURL,
-		AccessKey:  metadata.AccessKey,
-		SecretKey:  metadata.SecretKey,
-		Insecure:   config.InsecureAPI(),
-		CACerts:    config.CACerts(),
+		RancherURL:        metadata.RancherURL,
+		AccessKey:         metadata.AccessKey,
+		SecretKey:         metadata.SecretKey,
+		Insecure:          config.InsecureAPI(),
+		CACerts:           config.CACerts(),
+		WaitTimeout:       waitTimeout(config),
+		InitScriptShell:   config.InitScriptShell(),
+		InitScriptTimeout: config.InitScriptTimeout(),
+		Prune:             config.Prune(),
 	})
 	if err != nil {
 		return nil, err
@@ -489,10 +829,15 @@ func fillRancherMetadata(metadata *rancherModel.RancherMetadata, config config.C
 	}
 
 	return rancher_client.RancherConfig{
-		RancherURL: metadata.RancherURL,
-		AccessKey:  metadata.AccessKey,
-		SecretKey:  metadata.SecretKey,
-		Insecure:   config.InsecureAPI(),
-		CACerts:    config.CACerts(),
+		RancherURL:        metadata.RancherURL,
+		AccessKey:         metadata.AccessKey,
+		SecretKey:         metadata.SecretKey,
+		Insecure:          config.InsecureAPI(),
+		CACerts:           config.CACerts(),
+		MergeAnswers:      config.MergeAnswers(),
+		WaitTimeout:       waitTimeout(config),
+		InitScriptShell:   config.InitScriptShell(),
+		InitScriptTimeout: config.InitScriptTimeout(),
+		Prune:             config.Prune(),
 	}, nil
 }
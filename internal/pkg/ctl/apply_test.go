@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/assert"
 	"github.com/bitgrip/cattlectl/internal/pkg/config"
@@ -31,24 +32,32 @@ import (
 )
 
 var (
-	origPrintln              = println
-	origRancherClient        = newRancherClient
-	origRancherConverger     = newRancherConverger
-	origClusterConverger     = newClusterConverger
-	origProjectConverger     = newProjectConverger
-	origJobConverger         = newJobConverger
-	origCronJobConverger     = newCronJobConverger
-	origDeploymentConverger  = newDeploymentConverger
-	origDaemonSetConverger   = newDaemonSetConverger
-	origStatefulSetConverger = newStatefulSetConverger
-	origRancherParser        = newRancherParser
-	origClusterParser        = newClusterParser
-	origProjectParser        = newProjectParser
-	origJobParser            = newJobParser
-	origCronJobParser        = newCronJobParser
-	origDeploymentParser     = newDeploymentParser
-	origDaemonSetParser      = newDaemonSetParser
-	origStatefulSetParser    = newStatefulSetParser
+	origPrintln                  = println
+	origRancherClient            = newRancherClient
+	origRancherConverger         = newRancherConverger
+	origClusterConverger         = newClusterConverger
+	origPruningClusterConverger  = newPruningClusterConverger
+	origProjectConverger         = newProjectConverger
+	origPruningProjectConverger  = newPruningProjectConverger
+	origJobConverger             = newJobConverger
+	origCronJobConverger         = newCronJobConverger
+	origDeploymentConverger      = newDeploymentConverger
+	origDaemonSetConverger       = newDaemonSetConverger
+	origStatefulSetConverger     = newStatefulSetConverger
+	origServiceConverger         = newServiceConverger
+	origIngressConverger         = newIngressConverger
+	origMultiClusterAppConverger = newMultiClusterAppConverger
+	origRancherParser            = newRancherParser
+	origClusterParser            = newClusterParser
+	origProjectParser            = newProjectParser
+	origJobParser                = newJobParser
+	origCronJobParser            = newCronJobParser
+	origDeploymentParser         = newDeploymentParser
+	origDaemonSetParser          = newDaemonSetParser
+	origStatefulSetParser        = newStatefulSetParser
+	origServiceParser            = newServiceParser
+	origIngressParser            = newIngressParser
+	origMultiClusterAppParser    = newMultiClusterAppParser
 )
 
 func TestDecodeToApply(t *testing.T) {
@@ -173,6 +182,53 @@ func TestApplyDescriptor(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "one_multi_cluster_app_object",
+			args: args{
+				file:     "test-descriptor.yaml",
+				fullData: []byte("---\napi_version: \"2.0\"\nkind: MultiClusterApp"),
+				config:   testConfig{},
+			},
+			setExpectedBackends: func(t *testing.T) {
+				newMultiClusterAppParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+					return testParser{
+						expected:     true,
+						expectedData: []byte("api_version: \"2.0\"\nkind: MultiClusterApp\n"),
+						t:            t,
+					}
+				}
+				newMultiClusterAppConverger = func(multiClusterAppDescriptor rancherModel.MultiClusterAppDescriptor, rancherConfig client.RancherConfig) (descriptor.Converger, error) {
+					return testConverger{
+						expected: true,
+					}, nil
+				}
+			},
+		},
+		{
+			name: "multi_cluster_app_with_apply_flags",
+			args: args{
+				file:     "test-descriptor.yaml",
+				fullData: []byte("---\napi_version: \"2.0\"\nkind: MultiClusterApp"),
+				config:   testConfig{mergeAnswers: true, prune: true, wait: true, timeout: time.Minute},
+			},
+			setExpectedBackends: func(t *testing.T) {
+				newMultiClusterAppParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+					return testParser{
+						expected:     true,
+						expectedData: []byte("api_version: \"2.0\"\nkind: MultiClusterApp\n"),
+						t:            t,
+					}
+				}
+				newMultiClusterAppConverger = func(multiClusterAppDescriptor rancherModel.MultiClusterAppDescriptor, rancherConfig client.RancherConfig) (descriptor.Converger, error) {
+					assert.Equals(t, true, rancherConfig.MergeAnswers)
+					assert.Equals(t, true, rancherConfig.Prune)
+					assert.Equals(t, time.Minute, rancherConfig.WaitTimeout)
+					return testConverger{
+						expected: true,
+					}, nil
+				}
+			},
+		},
 		{
 			name: "one_cluster_object",
 			args: args{
@@ -202,6 +258,37 @@ func TestApplyDescriptor(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "one_cluster_object_with_prune",
+			args: args{
+				file:     "test-descriptor.yaml",
+				fullData: []byte("---\napi_version: \"2.0\"\nkind: Cluster"),
+				config: testConfig{
+					prune: true,
+				},
+			},
+			setExpectedBackends: func(t *testing.T) {
+				newClusterParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+					return testParser{
+						expected:     true,
+						expectedData: []byte("api_version: \"2.0\"\nkind: Cluster\n"),
+						t:            t,
+					}
+				}
+				var expectedRancherClient client.RancherClient
+				newRancherClient = func(config client.RancherConfig) (client.RancherClient, error) {
+					var err error
+					expectedRancherClient, err = rancher_client.NewRancherClient(config)
+					return expectedRancherClient, err
+				}
+				newPruningClusterConverger = func(cluster clusterModel.Cluster, rancherClient client.RancherClient) (descriptor.Converger, error) {
+					assert.Assert(t, expectedRancherClient == rancherClient, "Unexpecte rancher client")
+					return testConverger{
+						expected: true,
+					}, nil
+				}
+			},
+		},
 		{
 			name: "one_project_object",
 			args: args{
@@ -225,7 +312,38 @@ func TestApplyDescriptor(t *testing.T) {
 					expectedClusterClient, _ = rancherClient.Cluster("test-cluster")
 					return rancherClient, err
 				}
-				newProjectConverger = func(project projectModel.Project, clusterClient client.ClusterClient) (descriptor.Converger, error) {
+				newProjectConverger = func(project projectModel.Project, clusterClient client.ClusterClient, parallelism int) (descriptor.Converger, error) {
+					assert.Assert(t, expectedClusterClient == clusterClient, "Unexpecte cluster client")
+					return testConverger{
+						expected: true,
+					}, nil
+				}
+			},
+		},
+		{
+			name: "one_project_object_with_prune",
+			args: args{
+				file:     "test-descriptor.yaml",
+				fullData: []byte("---\napi_version: \"2.0\"\nkind: Project"),
+				config: testConfig{
+					clusterName: "test-cluster",
+					prune:       true,
+				},
+			},
+			setExpectedBackends: func(t *testing.T) {
+				newProjectParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+					return testParser{
+						expected: true,
+						t:        t,
+					}
+				}
+				var expectedClusterClient client.ClusterClient
+				newRancherClient = func(config client.RancherConfig) (client.RancherClient, error) {
+					rancherClient, err := rancher_client.NewRancherClient(config)
+					expectedClusterClient, _ = rancherClient.Cluster("test-cluster")
+					return rancherClient, err
+				}
+				newPruningProjectConverger = func(project projectModel.Project, clusterClient client.ClusterClient, parallelism int) (descriptor.Converger, error) {
 					assert.Assert(t, expectedClusterClient == clusterClient, "Unexpecte cluster client")
 					return testConverger{
 						expected: true,
@@ -329,6 +447,70 @@ func TestApplyDescriptor(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "one_service_object",
+			args: args{
+				file:     "test-descriptor.yaml",
+				fullData: []byte("---\napi_version: \"2.0\"\nkind: Service\nmetadata:\n  project_name: test-project"),
+				config: testConfig{
+					clusterName: "test-cluster",
+				},
+			},
+			setExpectedBackends: func(t *testing.T) {
+				newServiceParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+					return testParser{
+						expected:     true,
+						expectedData: []byte("api_version: \"2.0\"\nkind: Service\nmetadata:\n  project_name: test-project\n"),
+						t:            t,
+					}
+				}
+				var expectedProjectClient client.ProjectClient
+				newRancherClient = func(config client.RancherConfig) (client.RancherClient, error) {
+					rancherClient, err := rancher_client.NewRancherClient(config)
+					clusterClient, _ := rancherClient.Cluster("test-cluster")
+					expectedProjectClient, _ = clusterClient.Project("test-project")
+					return rancherClient, err
+				}
+				newServiceConverger = func(serviceDescriptor projectModel.ServiceDescriptor, projectClient client.ProjectClient) (descriptor.Converger, error) {
+					assert.Assert(t, expectedProjectClient == projectClient, "Unexpecte project client")
+					return testConverger{
+						expected: true,
+					}, nil
+				}
+			},
+		},
+		{
+			name: "one_ingress_object",
+			args: args{
+				file:     "test-descriptor.yaml",
+				fullData: []byte("---\napi_version: \"2.0\"\nkind: Ingress\nmetadata:\n  project_name: test-project"),
+				config: testConfig{
+					clusterName: "test-cluster",
+				},
+			},
+			setExpectedBackends: func(t *testing.T) {
+				newIngressParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+					return testParser{
+						expected:     true,
+						expectedData: []byte("api_version: \"2.0\"\nkind: Ingress\nmetadata:\n  project_name: test-project\n"),
+						t:            t,
+					}
+				}
+				var expectedProjectClient client.ProjectClient
+				newRancherClient = func(config client.RancherConfig) (client.RancherClient, error) {
+					rancherClient, err := rancher_client.NewRancherClient(config)
+					clusterClient, _ := rancherClient.Cluster("test-cluster")
+					expectedProjectClient, _ = clusterClient.Project("test-project")
+					return rancherClient, err
+				}
+				newIngressConverger = func(ingressDescriptor projectModel.IngressDescriptor, projectClient client.ProjectClient) (descriptor.Converger, error) {
+					assert.Assert(t, expectedProjectClient == projectClient, "Unexpecte project client")
+					return testConverger{
+						expected: true,
+					}, nil
+				}
+			},
+		},
 		{
 			name: "one_daemonset_object",
 			args: args{
@@ -415,7 +597,7 @@ func TestApplyDescriptor(t *testing.T) {
 					expectedClusterClient, _ = rancherClient.Cluster("test-cluster")
 					return rancherClient, err
 				}
-				newProjectConverger = func(project projectModel.Project, clusterClient client.ClusterClient) (descriptor.Converger, error) {
+				newProjectConverger = func(project projectModel.Project, clusterClient client.ClusterClient, parallelism int) (descriptor.Converger, error) {
 					assert.Assert(t, expectedClusterClient == clusterClient, "Unexpecte cluster client")
 					assert.Assert(t, "project1" == project.Metadata.Name || "project2" == project.Metadata.Name, fmt.Sprintf("Unexpected project name %s", project.Metadata.Name))
 					return testConverger{
@@ -439,6 +621,119 @@ func TestApplyDescriptor(t *testing.T) {
 	}
 }
 
+func TestApplyDescriptor_KeepGoing(t *testing.T) {
+	defer resetBackendCalls()
+	tests := []struct {
+		name          string
+		keepGoing     bool
+		wantConverged int
+		wantErr       string
+	}{
+		{
+			name:          "stop_at_first_failure",
+			wantConverged: 1,
+			wantErr:       "Unexpected Call",
+		},
+		{
+			name:          "keep_going",
+			keepGoing:     true,
+			wantConverged: 3,
+			wantErr:       "2 resources failed:\n\t* Unexpected Call\n\t* Unexpected Call",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unexpectAllBackendCalls()
+			newRancherParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+				return testParser{
+					expected: true,
+					t:        t,
+				}
+			}
+			converged := 0
+			newRancherConverger = func(rancher rancherModel.Rancher, rancherConfig client.RancherConfig) (descriptor.Converger, error) {
+				converged++
+				// the first and the third descriptor are failing
+				return testConverger{
+					expected: converged%2 == 0,
+				}, nil
+			}
+			_, err := ApplyDescriptor(
+				"test-descriptor.yaml",
+				[]byte("---\napi_version: \"2.0\"\nkind: Rancher\n---\napi_version: \"2.0\"\nkind: Rancher\n---\napi_version: \"2.0\"\nkind: Rancher"),
+				nil,
+				testConfig{keepGoing: tt.keepGoing},
+			)
+			assert.NotOk(t, err, tt.wantErr)
+			assert.Equals(t, tt.wantConverged, converged)
+		})
+	}
+}
+
+func TestApplyDescriptor_DependsOn(t *testing.T) {
+	defer resetBackendCalls()
+	tests := []struct {
+		name      string
+		fullData  string
+		wantOrder []string
+		wantErr   string
+	}{
+		{
+			name:      "file_order",
+			fullData:  "---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: project1\n---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: project2\n",
+			wantOrder: []string{"project1", "project2"},
+		},
+		{
+			name:      "dependency_first",
+			fullData:  "---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: project1\n  depends_on:\n  - kind: Project\n    name: project2\n---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: project2\n",
+			wantOrder: []string{"project2", "project1"},
+		},
+		{
+			name:      "dependency_on_project_resource",
+			fullData:  "---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: project1\n  depends_on:\n  - kind: ConfigMap\n    namespace: namespace1\n    name: config1\n---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: project2\nresources:\n  config_maps:\n  - name: config1\n    namespace: namespace1\n",
+			wantOrder: []string{"project2", "project1"},
+		},
+		{
+			name:      "unknown_dependency",
+			fullData:  "---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: project1\n  depends_on:\n  - kind: Project\n    name: project3\n",
+			wantOrder: []string{},
+			wantErr:   "Unknown dependency Project/project3 of Project/project1",
+		},
+		{
+			name:      "cycle",
+			fullData:  "---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: project1\n  depends_on:\n  - kind: Project\n    name: project2\n---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: project2\n  depends_on:\n  - kind: Project\n    name: project1\n",
+			wantOrder: []string{},
+			wantErr:   "Dependency cycle: Project/project1 -> Project/project2 -> Project/project1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unexpectAllBackendCalls()
+			newProjectParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+				return testParser{
+					expected: true,
+					t:        t,
+				}
+			}
+			newRancherClient = rancher_client.NewRancherClient
+			order := make([]string, 0)
+			newProjectConverger = func(project projectModel.Project, clusterClient client.ClusterClient, parallelism int) (descriptor.Converger, error) {
+				order = append(order, project.Metadata.Name)
+				return testConverger{
+					expected: true,
+				}, nil
+			}
+			_, err := ApplyDescriptor("test-descriptor.yaml", []byte(tt.fullData), nil, testConfig{clusterName: "test-cluster"})
+			if tt.wantErr != "" {
+				assert.NotOk(t, err, tt.wantErr)
+			} else {
+				assert.Ok(t, err)
+			}
+			assert.Equals(t, tt.wantOrder, order)
+		})
+	}
+}
+
 func TestParseAndPrintDescriptor(t *testing.T) {
 	defer resetBackendCalls()
 
@@ -651,12 +946,17 @@ func resetBackendCalls() {
 	newRancherClient = origRancherClient
 	newRancherConverger = origRancherConverger
 	newClusterConverger = origClusterConverger
+	newPruningClusterConverger = origPruningClusterConverger
 	newProjectConverger = origProjectConverger
+	newPruningProjectConverger = origPruningProjectConverger
 	newJobConverger = origJobConverger
 	newCronJobConverger = origCronJobConverger
 	newDeploymentConverger = origDeploymentConverger
 	newDaemonSetConverger = origDaemonSetConverger
 	newStatefulSetConverger = origStatefulSetConverger
+	newServiceConverger = origServiceConverger
+	newIngressConverger = origIngressConverger
+	newMultiClusterAppConverger = origMultiClusterAppConverger
 	newRancherParser = origRancherParser
 	newClusterParser = origClusterParser
 	newProjectParser = origProjectParser
@@ -665,6 +965,9 @@ func resetBackendCalls() {
 	newDeploymentParser = origDeploymentParser
 	newDaemonSetParser = origDaemonSetParser
 	newStatefulSetParser = origStatefulSetParser
+	newServiceParser = origServiceParser
+	newIngressParser = origIngressParser
+	newMultiClusterAppParser = origMultiClusterAppParser
 }
 
 func unexpectAllBackendCalls() {
@@ -677,9 +980,15 @@ func unexpectAllBackendCalls() {
 	newClusterConverger = func(cluster clusterModel.Cluster, rancherClient client.RancherClient) (descriptor.Converger, error) {
 		return nil, fmt.Errorf("Unexpected Call newClusterConverger(...)")
 	}
-	newProjectConverger = func(project projectModel.Project, clusterClient client.ClusterClient) (descriptor.Converger, error) {
+	newPruningClusterConverger = func(cluster clusterModel.Cluster, rancherClient client.RancherClient) (descriptor.Converger, error) {
+		return nil, fmt.Errorf("Unexpected Call newPruningClusterConverger(...)")
+	}
+	newProjectConverger = func(project projectModel.Project, clusterClient client.ClusterClient, parallelism int) (descriptor.Converger, error) {
 		return nil, fmt.Errorf("Unexpected Call newProjectConverger(...)")
 	}
+	newPruningProjectConverger = func(project projectModel.Project, clusterClient client.ClusterClient, parallelism int) (descriptor.Converger, error) {
+		return nil, fmt.Errorf("Unexpected Call newPruningProjectConverger(...)")
+	}
 	newJobConverger = func(jobDescriptor projectModel.JobDescriptor, projectClient client.ProjectClient) (descriptor.Converger, error) {
 		return nil, fmt.Errorf("Unexpected Call newJobConverger(...)")
 	}
@@ -695,6 +1004,15 @@ func unexpectAllBackendCalls() {
 	newStatefulSetConverger = func(statefulSetDescriptor projectModel.StatefulSetDescriptor, projectClient client.ProjectClient) (descriptor.Converger, error) {
 		return nil, fmt.Errorf("Unexpected Call newStatefulSetConverger(...)")
 	}
+	newServiceConverger = func(serviceDescriptor projectModel.ServiceDescriptor, projectClient client.ProjectClient) (descriptor.Converger, error) {
+		return nil, fmt.Errorf("Unexpected Call newServiceConverger(...)")
+	}
+	newIngressConverger = func(ingressDescriptor projectModel.IngressDescriptor, projectClient client.ProjectClient) (descriptor.Converger, error) {
+		return nil, fmt.Errorf("Unexpected Call newIngressConverger(...)")
+	}
+	newMultiClusterAppConverger = func(multiClusterAppDescriptor rancherModel.MultiClusterAppDescriptor, rancherConfig client.RancherConfig) (descriptor.Converger, error) {
+		return nil, fmt.Errorf("Unexpected Call newMultiClusterAppConverger(...)")
+	}
 	newRancherParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
 		return testParser{}
 	}
@@ -719,6 +1037,15 @@ func unexpectAllBackendCalls() {
 	newStatefulSetParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
 		return testParser{}
 	}
+	newServiceParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+		return testParser{}
+	}
+	newIngressParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+		return testParser{}
+	}
+	newMultiClusterAppParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+		return testParser{}
+	}
 }
 
 type testConverger struct {
@@ -760,6 +1087,11 @@ type testConfig struct {
 	clusterID    string
 	mergeAnswers bool
 	dryRun       bool
+	prune        bool
+	keepGoing    bool
+	parallelism  int
+	wait         bool
+	timeout      time.Duration
 }
 
 func (config testConfig) RancherURL() string {
@@ -792,3 +1124,26 @@ func (config testConfig) MergeAnswers() bool {
 func (config testConfig) DryRun() bool {
 	return config.dryRun
 }
+func (config testConfig) Prune() bool {
+	return config.prune
+}
+func (config testConfig) KeepGoing() bool {
+	return config.keepGoing
+}
+func (config testConfig) Parallelism() int {
+	return config.parallelism
+}
+func (config testConfig) Wait() bool {
+	return config.wait
+}
+func (config testConfig) Timeout() time.Duration {
+	return config.timeout
+}
+
+func (config testConfig) InitScriptShell() string {
+	return ""
+}
+
+func (config testConfig) InitScriptTimeout() time.Duration {
+	return 0
+}
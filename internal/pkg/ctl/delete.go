@@ -9,25 +9,38 @@ package ctl
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/config"
 	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
 	"github.com/sirupsen/logrus"
 )
 
 var (
 	deletableProjectResouceTypes = map[string]func(string, string, string, config.Config) (bool, error){
-		"namespace":         deleteNamespace,
-		"certificate":       deleteCertificate,
-		"config-map":        deleteConfigMap,
-		"docker-credential": deleteDockerCredential,
-		"secret":            deleteSecret,
-		"app":               deleteApp,
-		"job":               deleteJob,
-		"cron-job":          deleteCronJob,
-		"deployment":        deleteDeployment,
-		"daemon-set":        deleteDaemonSet,
-		"stateful-set":      deleteStatefulSet,
+		"namespace":               deleteNamespace,
+		"certificate":             deleteCertificate,
+		"config-map":              deleteConfigMap,
+		"docker-credential":       deleteDockerCredential,
+		"secret":                  deleteSecret,
+		"app":                     deleteApp,
+		"job":                     deleteJob,
+		"cron-job":                deleteCronJob,
+		"deployment":              deleteDeployment,
+		"daemon-set":              deleteDaemonSet,
+		"stateful-set":            deleteStatefulSet,
+		"service":                 deleteService,
+		"ingress":                 deleteIngress,
+		"network-policy":          deleteNetworkPolicy,
+		"persistent-volume-claim": deletePersistentVolumeClaim,
+	}
+	deletableClusterResouceTypes = map[string]func(string, config.Config) (bool, error){
+		"storage-class":     deleteStorageClass,
+		"persistent-volume": deletePersistentVolume,
+	}
+	deletableRancherResouceTypes = map[string]func(string, config.Config) (bool, error){
+		"multi-cluster-app": deleteMultiClusterApp,
 	}
 )
 
@@ -44,6 +57,63 @@ func DeleteProjectResouce(projectName, namespace, kind, name string, config conf
 	return deleteFunc(projectName, namespace, name, config)
 }
 
+// DeleteProjectResouceWithReport is deleting one project resource from project and reports the outcome
+func DeleteProjectResouceWithReport(projectName, namespace, kind, name string, config config.Config) (descriptor.ResourceReport, error) {
+	start := time.Now()
+	deleted, err := DeleteProjectResouce(projectName, namespace, kind, name, config)
+	action := descriptor.ActionUnchanged
+	if deleted {
+		action = descriptor.ActionDeleted
+	}
+	return descriptor.NewReport(kind, namespace, name, action, start, err), err
+}
+
+// DeleteClusterResouce is deleting one cluster scoped resource from the cluster
+//
+// * resourceType: the type of the resource to delete
+// * name: the name of the resource to delete
+func DeleteClusterResouce(kind, name string, config config.Config) (bool, error) {
+	deleteFunc, supportedType := deletableClusterResouceTypes[kind]
+	if !supportedType {
+		return false, fmt.Errorf("Not supported cluster resouce type [%s]", kind)
+	}
+	return deleteFunc(name, config)
+}
+
+// DeleteClusterResouceWithReport is deleting one cluster scoped resource from the cluster and reports the outcome
+func DeleteClusterResouceWithReport(kind, name string, config config.Config) (descriptor.ResourceReport, error) {
+	start := time.Now()
+	deleted, err := DeleteClusterResouce(kind, name, config)
+	action := descriptor.ActionUnchanged
+	if deleted {
+		action = descriptor.ActionDeleted
+	}
+	return descriptor.NewReport(kind, "", name, action, start, err), err
+}
+
+// DeleteRancherResouce is deleting one global resource from rancher
+//
+// * resourceType: the type of the resource to delete
+// * name: the name of the resource to delete
+func DeleteRancherResouce(kind, name string, config config.Config) (bool, error) {
+	deleteFunc, supportedType := deletableRancherResouceTypes[kind]
+	if !supportedType {
+		return false, fmt.Errorf("Not supported global resouce type [%s]", kind)
+	}
+	return deleteFunc(name, config)
+}
+
+// DeleteRancherResouceWithReport is deleting one global resource from rancher and reports the outcome
+func DeleteRancherResouceWithReport(kind, name string, config config.Config) (descriptor.ResourceReport, error) {
+	start := time.Now()
+	deleted, err := DeleteRancherResouce(kind, name, config)
+	action := descriptor.ActionUnchanged
+	if deleted {
+		action = descriptor.ActionDeleted
+	}
+	return descriptor.NewReport(kind, "", name, action, start, err), err
+}
+
 func deleteNamespace(projectName, _namespace, name string, config config.Config) (deleted bool, err error) {
 	_, _, projectClient, err := getProjectClient(projectName, config)
 	if err != nil {
@@ -207,6 +277,34 @@ func deleteStatefulSet(projectName, namespace, name string, config config.Config
 	return deleteNamespaceResouce(statefulSet, config.ClusterName(), projectName, namespace, "stateful-set", name, config.DryRun())
 }
 
+func deleteService(projectName, namespace, name string, config config.Config) (deleted bool, err error) {
+	_, _, projectClient, err := getProjectClient(projectName, config)
+	if err != nil {
+		return
+	}
+
+	service, err := projectClient.Service(name, namespace)
+	if err != nil {
+		return
+	}
+
+	return deleteNamespaceResouce(service, config.ClusterName(), projectName, namespace, "service", name, config.DryRun())
+}
+
+func deleteIngress(projectName, namespace, name string, config config.Config) (deleted bool, err error) {
+	_, _, projectClient, err := getProjectClient(projectName, config)
+	if err != nil {
+		return
+	}
+
+	ingress, err := projectClient.Ingress(name, namespace)
+	if err != nil {
+		return
+	}
+
+	return deleteNamespaceResouce(ingress, config.ClusterName(), projectName, namespace, "ingress", name, config.DryRun())
+}
+
 func deleteProjectResouce(resource client.ResourceClient, clusterName, projectName, kind, name string, dryRun bool) (deleted bool, err error) {
 	if exists, err := resource.Exists(); err != nil || !exists {
 		if err != nil {
@@ -241,3 +339,99 @@ func deleteNamespaceResouce(resource client.ResourceClient, clusterName, project
 	deleted, err = resource.Delete(dryRun)
 	return
 }
+
+func deleteNetworkPolicy(projectName, namespace, name string, config config.Config) (deleted bool, err error) {
+	_, _, projectClient, err := getProjectClient(projectName, config)
+	if err != nil {
+		return
+	}
+
+	networkPolicy, err := projectClient.NetworkPolicy(name, namespace)
+	if err != nil {
+		return
+	}
+
+	return deleteNamespaceResouce(networkPolicy, config.ClusterName(), projectName, namespace, "network-policy", name, config.DryRun())
+}
+
+func deletePersistentVolumeClaim(projectName, namespace, name string, config config.Config) (deleted bool, err error) {
+	_, _, projectClient, err := getProjectClient(projectName, config)
+	if err != nil {
+		return
+	}
+
+	volumeClaim, err := projectClient.PersistentVolumeClaim(name, namespace)
+	if err != nil {
+		return
+	}
+
+	return deleteNamespaceResouce(volumeClaim, config.ClusterName(), projectName, namespace, "persistent-volume-claim", name, config.DryRun())
+}
+
+func deleteStorageClass(name string, config config.Config) (deleted bool, err error) {
+	_, clusterClient, err := getClusterClient(config)
+	if err != nil {
+		return
+	}
+
+	storageClass, err := clusterClient.StorageClass(name)
+	if err != nil {
+		return
+	}
+
+	return deleteClusterResouce(storageClass, config.ClusterName(), "storage-class", name, config.DryRun())
+}
+
+func deletePersistentVolume(name string, config config.Config) (deleted bool, err error) {
+	_, clusterClient, err := getClusterClient(config)
+	if err != nil {
+		return
+	}
+
+	persistentVolume, err := clusterClient.PersistentVolume(name)
+	if err != nil {
+		return
+	}
+
+	return deleteClusterResouce(persistentVolume, config.ClusterName(), "persistent-volume", name, config.DryRun())
+}
+
+func deleteClusterResouce(resource client.ResourceClient, clusterName, kind, name string, dryRun bool) (deleted bool, err error) {
+	if exists, err := resource.Exists(); err != nil || !exists {
+		if err != nil {
+			return false, err
+		}
+		logrus.
+			WithField("resouce-name", name).
+			WithField("cluster-name", clusterName).
+			Infof("No %s skip delete", kind)
+		return false, nil
+	}
+
+	deleted, err = resource.Delete(dryRun)
+	return
+}
+
+func deleteMultiClusterApp(name string, config config.Config) (deleted bool, err error) {
+	rancherClient, err := getRancherClient(config)
+	if err != nil {
+		return
+	}
+
+	multiClusterApp, err := rancherClient.MultiClusterApp(name)
+	if err != nil {
+		return
+	}
+
+	if exists, err := multiClusterApp.Exists(); err != nil || !exists {
+		if err != nil {
+			return false, err
+		}
+		logrus.
+			WithField("resouce-name", name).
+			Info("No multi-cluster-app skip delete")
+		return false, nil
+	}
+
+	return multiClusterApp.Delete(config.DryRun())
+}
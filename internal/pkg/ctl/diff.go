@@ -0,0 +1,213 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/config"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	diffContextLines = 3
+
+	colorReset  = "\x1b[0m"
+	colorBold   = "\x1b[1m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorCyan   = "\x1b[36m"
+)
+
+// DiffDescriptor the the CTL compares a descriptor with the live state of rancher
+func DiffDescriptor(file string, fullData []byte, values map[string]interface{}, config config.Config) (result descriptor.DiffResult, err error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(fullData))
+	for {
+		apiVersion, kind, object, decodeErr := DecodeToApply(decoder)
+		if decodeErr != nil {
+			if decodeErr.Error() == "EMPTY" {
+				continue
+			} else if decodeErr.Error() == "EOF" {
+				break
+			}
+			err = decodeErr
+			return
+		}
+		var (
+			singleObjectData []byte
+			singleResult     descriptor.DiffResult
+			converger        descriptor.Converger
+		)
+		if singleObjectData, err = yaml.Marshal(object); err != nil {
+			return
+		}
+		if !isSupportedAPIVersion(apiVersion) {
+			return result, fmt.Errorf("Unsupported api version %s", apiVersion)
+		}
+		if converger, err = newDescriptorConverger(file, kind, singleObjectData, values, config); err != nil {
+			return
+		}
+		differ, isDiffer := converger.(descriptor.Differ)
+		if !isDiffer {
+			return result, fmt.Errorf("Diff not supported for %s", kind)
+		}
+		if singleResult, err = differ.Diff(); err != nil {
+			return
+		}
+		result.Resources = append(result.Resources, singleResult.Resources...)
+	}
+	return
+}
+
+// FormatDiff renders a unified diff for each resource of the result
+func FormatDiff(result descriptor.DiffResult, colored bool) string {
+	out := &strings.Builder{}
+	for _, resource := range result.Resources {
+		writeColored(out, colored, colorBold+actionColor(resource.Action), fmt.Sprintf("%s %s %s", resource.Action, resource.Type, resource.Name))
+		if !resource.Compared {
+			out.WriteString(" (content not compared)")
+		}
+		out.WriteString("\n")
+		if !resource.Compared || resource.Existing == resource.Desired {
+			continue
+		}
+		writeColored(out, colored, colorBold, fmt.Sprintf("--- existing/%s/%s", resource.Type, resource.Name))
+		out.WriteString("\n")
+		writeColored(out, colored, colorBold, fmt.Sprintf("+++ desired/%s/%s", resource.Type, resource.Name))
+		out.WriteString("\n")
+		writeUnifiedDiff(out, colored, resource.Existing, resource.Desired)
+	}
+	return out.String()
+}
+
+type diffLine struct {
+	operation diffmatchpatch.Operation
+	text      string
+}
+
+func writeUnifiedDiff(out *strings.Builder, colored bool, existing, desired string) {
+	lines := diffLines(existing, desired)
+	for start := 0; start < len(lines); {
+		if lines[start].operation == diffmatchpatch.DiffEqual {
+			start++
+			continue
+		}
+		hunkStart := start - diffContextLines
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		// extend the hunk until there are more than two times the context lines without change
+		hunkEnd, unchanged := start, 0
+		for ; hunkEnd < len(lines) && unchanged <= 2*diffContextLines; hunkEnd++ {
+			if lines[hunkEnd].operation == diffmatchpatch.DiffEqual {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+		}
+		if unchanged > diffContextLines {
+			hunkEnd -= unchanged - diffContextLines
+		}
+		writeHunk(out, colored, lines, hunkStart, hunkEnd)
+		start = hunkEnd
+	}
+}
+
+func writeHunk(out *strings.Builder, colored bool, lines []diffLine, start, end int) {
+	existingStart, desiredStart := 1, 1
+	for _, line := range lines[:start] {
+		if line.operation != diffmatchpatch.DiffInsert {
+			existingStart++
+		}
+		if line.operation != diffmatchpatch.DiffDelete {
+			desiredStart++
+		}
+	}
+	existingCount, desiredCount := 0, 0
+	for _, line := range lines[start:end] {
+		if line.operation != diffmatchpatch.DiffInsert {
+			existingCount++
+		}
+		if line.operation != diffmatchpatch.DiffDelete {
+			desiredCount++
+		}
+	}
+	if existingCount == 0 {
+		existingStart--
+	}
+	if desiredCount == 0 {
+		desiredStart--
+	}
+	writeColored(out, colored, colorCyan, fmt.Sprintf("@@ -%d,%d +%d,%d @@", existingStart, existingCount, desiredStart, desiredCount))
+	out.WriteString("\n")
+	for _, line := range lines[start:end] {
+		switch line.operation {
+		case diffmatchpatch.DiffDelete:
+			writeColored(out, colored, colorRed, "-"+line.text)
+		case diffmatchpatch.DiffInsert:
+			writeColored(out, colored, colorGreen, "+"+line.text)
+		default:
+			out.WriteString(" " + line.text)
+		}
+		out.WriteString("\n")
+	}
+}
+
+func diffLines(existing, desired string) []diffLine {
+	differ := diffmatchpatch.New()
+	existingChars, desiredChars, lineArray := differ.DiffLinesToChars(existing, desired)
+	diffs := differ.DiffCharsToLines(differ.DiffMain(existingChars, desiredChars, false), lineArray)
+	result := make([]diffLine, 0)
+	for _, diff := range diffs {
+		for _, text := range strings.SplitAfter(diff.Text, "\n") {
+			if text == "" {
+				continue
+			}
+			result = append(result, diffLine{
+				operation: diff.Type,
+				text:      strings.TrimSuffix(text, "\n"),
+			})
+		}
+	}
+	return result
+}
+
+func actionColor(action string) string {
+	switch action {
+	case descriptor.DiffCreated:
+		return colorGreen
+	case descriptor.DiffChanged:
+		return colorYellow
+	case descriptor.DiffDeleted:
+		return colorRed
+	case descriptor.DiffWouldSkip:
+		return colorCyan
+	default:
+		return ""
+	}
+}
+
+func writeColored(out *strings.Builder, colored bool, color, text string) {
+	if colored {
+		out.WriteString(color + text + colorReset)
+	} else {
+		out.WriteString(text)
+	}
+}
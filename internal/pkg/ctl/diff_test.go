@@ -0,0 +1,139 @@
+// Copyright © 2020 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+)
+
+func TestDiffDescriptor(t *testing.T) {
+	defer resetBackendCalls()
+	unexpectAllBackendCalls()
+	newRancherParser = func(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+		return testParser{
+			expected: true,
+		}
+	}
+	newRancherConverger = func(rancher rancherModel.Rancher, rancherConfig client.RancherConfig) (descriptor.Converger, error) {
+		return testConverger{
+			expected: true,
+		}, nil
+	}
+
+	result, err := DiffDescriptor(
+		"test-descriptor.yaml",
+		[]byte("---\napi_version: \"2.0\"\nkind: Rancher\n---\napi_version: \"2.0\"\nkind: Rancher"),
+		nil,
+		testConfig{},
+	)
+	assert.Ok(t, err)
+	assert.Equals(t, 2, len(result.Resources))
+	assert.Equals(t, "test-catalog", result.Resources[0].Name)
+
+	_, err = DiffDescriptor("test-descriptor.yaml", []byte("---\napi_version: \"2.0\"\nkind: Unknown"), nil, testConfig{})
+	assert.NotOk(t, err, "Unknown descriptor Unknown")
+}
+
+func TestFormatDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  descriptor.DiffResult
+		colored bool
+		want    string
+	}{
+		{
+			name: "unchanged",
+			result: descriptor.DiffResult{Resources: []descriptor.ResourceDiff{
+				{Type: "ConfigMap", Name: "test-config", Action: descriptor.DiffUnchanged, Compared: true, Existing: "a: b\n", Desired: "a: b\n"},
+			}},
+			want: "unchanged ConfigMap test-config\n",
+		},
+		{
+			name: "not_compared",
+			result: descriptor.DiffResult{Resources: []descriptor.ResourceDiff{
+				{Type: "Secret", Name: "test-secret", Action: descriptor.DiffCreated},
+			}},
+			want: "created Secret test-secret (content not compared)\n",
+		},
+		{
+			name: "created",
+			result: descriptor.DiffResult{Resources: []descriptor.ResourceDiff{
+				{Type: "ConfigMap", Name: "test-config", Action: descriptor.DiffCreated, Compared: true, Desired: "a: b\nc: d\n"},
+			}},
+			want: "created ConfigMap test-config\n" +
+				"--- existing/ConfigMap/test-config\n" +
+				"+++ desired/ConfigMap/test-config\n" +
+				"@@ -0,0 +1,2 @@\n" +
+				"+a: b\n" +
+				"+c: d\n",
+		},
+		{
+			name: "changed_with_context",
+			result: descriptor.DiffResult{Resources: []descriptor.ResourceDiff{
+				{
+					Type:     "Deployment",
+					Name:     "test-deployment",
+					Action:   descriptor.DiffChanged,
+					Compared: true,
+					Existing: "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nl12\nl13\nl14\n",
+					Desired:  "l1\nl2\nl3\nl4\nl5\nchanged\nl7\nl8\nl9\nl10\nl11\nl12\nl13\nadded\nl14\n",
+				},
+			}},
+			want: "changed Deployment test-deployment\n" +
+				"--- existing/Deployment/test-deployment\n" +
+				"+++ desired/Deployment/test-deployment\n" +
+				"@@ -3,7 +3,7 @@\n" +
+				" l3\n l4\n l5\n-l6\n+changed\n l7\n l8\n l9\n" +
+				"@@ -11,4 +11,5 @@\n" +
+				" l11\n l12\n l13\n+added\n l14\n",
+		},
+		{
+			name: "colored",
+			result: descriptor.DiffResult{Resources: []descriptor.ResourceDiff{
+				{Type: "App", Name: "test-app", Action: descriptor.DiffWouldSkip, Compared: true, Existing: "a: b\n", Desired: "a: c\n"},
+			}},
+			colored: true,
+			want: fmt.Sprintf("%s%swould-skip App test-app%s\n", colorBold, colorCyan, colorReset) +
+				fmt.Sprintf("%s--- existing/App/test-app%s\n", colorBold, colorReset) +
+				fmt.Sprintf("%s+++ desired/App/test-app%s\n", colorBold, colorReset) +
+				fmt.Sprintf("%s@@ -1,1 +1,1 @@%s\n", colorCyan, colorReset) +
+				fmt.Sprintf("%s-a: b%s\n", colorRed, colorReset) +
+				fmt.Sprintf("%s+a: c%s\n", colorGreen, colorReset),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equals(t, tt.want, FormatDiff(tt.result, tt.colored))
+		})
+	}
+}
+
+func (converger testConverger) Diff() (result descriptor.DiffResult, err error) {
+	if !converger.expected {
+		return result, fmt.Errorf("Unexpected Call")
+	}
+	result.Resources = append(result.Resources, descriptor.ResourceDiff{
+		Type:   rancherModel.RancherCatalog,
+		Name:   "test-catalog",
+		Action: descriptor.DiffUnchanged,
+	})
+	return
+}
@@ -16,28 +16,46 @@ import (
 
 var (
 	listableProjectResouceTypes = map[string]func(string, string, config.Config) ([]string, error){
-		"namespace":          listNamespaces,
-		"namespaces":         listNamespaces,
-		"certificate":        listCertificates,
-		"certificates":       listCertificates,
-		"config-map":         listConfigMaps,
-		"config-maps":        listConfigMaps,
-		"docker-credential":  listDockerCredentials,
-		"docker-credentials": listDockerCredentials,
-		"secret":             listSecrets,
-		"secrets":            listSecrets,
-		"app":                listApps,
-		"apps":               listApps,
-		"job":                listJobs,
-		"jobs":               listJobs,
-		"cron-job":           listCronJobs,
-		"cron-jobs":          listCronJobs,
-		"deployment":         listDeployments,
-		"deployments":        listDeployments,
-		"daemon-set":         listDaemonSets,
-		"daemon-sets":        listDaemonSets,
-		"stateful-set":       listStatefulSets,
-		"stateful-sets":      listStatefulSets,
+		"namespace":                listNamespaces,
+		"namespaces":               listNamespaces,
+		"certificate":              listCertificates,
+		"certificates":             listCertificates,
+		"config-map":               listConfigMaps,
+		"config-maps":              listConfigMaps,
+		"docker-credential":        listDockerCredentials,
+		"docker-credentials":       listDockerCredentials,
+		"secret":                   listSecrets,
+		"secrets":                  listSecrets,
+		"app":                      listApps,
+		"apps":                     listApps,
+		"job":                      listJobs,
+		"jobs":                     listJobs,
+		"cron-job":                 listCronJobs,
+		"cron-jobs":                listCronJobs,
+		"deployment":               listDeployments,
+		"deployments":              listDeployments,
+		"daemon-set":               listDaemonSets,
+		"daemon-sets":              listDaemonSets,
+		"stateful-set":             listStatefulSets,
+		"stateful-sets":            listStatefulSets,
+		"service":                  listServices,
+		"services":                 listServices,
+		"ingress":                  listIngresses,
+		"ingresses":                listIngresses,
+		"network-policy":           listNetworkPolicies,
+		"network-policies":         listNetworkPolicies,
+		"persistent-volume-claim":  listPersistentVolumeClaims,
+		"persistent-volume-claims": listPersistentVolumeClaims,
+	}
+	listableClusterResouceTypes = map[string]func(config.Config) ([]string, error){
+		"storage-class":      listStorageClasses,
+		"storage-classes":    listStorageClasses,
+		"persistent-volume":  listPersistentVolumes,
+		"persistent-volumes": listPersistentVolumes,
+	}
+	listableRancherResouceTypes = map[string]func(config.Config) ([]string, error){
+		"multi-cluster-app":  listMultiClusterApps,
+		"multi-cluster-apps": listMultiClusterApps,
 	}
 )
 
@@ -56,6 +74,42 @@ func ListProjectResouces(projectName, namespace, kind, pattern string, config co
 	if err != nil {
 		return
 	}
+	return matchNames(names, pattern), nil
+}
+
+// ListClusterResouces list all cluster scoped resources of a cluster to stdout
+//
+// * resourceType: the type of the resources to list
+// * pattern: a match pattern to filter the results
+func ListClusterResouces(kind, pattern string, config config.Config) (matches []string, err error) {
+	listFunc, supportedType := listableClusterResouceTypes[kind]
+	if !supportedType {
+		return matches, fmt.Errorf("Not supported cluster resouce type [%s]", kind)
+	}
+	names, err := listFunc(config)
+	if err != nil {
+		return
+	}
+	return matchNames(names, pattern), nil
+}
+
+// ListRancherResouces list all global resources of rancher to stdout
+//
+// * resourceType: the type of the resources to list
+// * pattern: a match pattern to filter the results
+func ListRancherResouces(kind, pattern string, config config.Config) (matches []string, err error) {
+	listFunc, supportedType := listableRancherResouceTypes[kind]
+	if !supportedType {
+		return matches, fmt.Errorf("Not supported global resouce type [%s]", kind)
+	}
+	names, err := listFunc(config)
+	if err != nil {
+		return
+	}
+	return matchNames(names, pattern), nil
+}
+
+func matchNames(names []string, pattern string) (matches []string) {
 	for _, name := range names {
 		matched, _ := regexp.MatchString(pattern, name)
 		if !matched {
@@ -307,3 +361,157 @@ func listStatefulSets(projectName, namespace string, config config.Config) (name
 
 	return
 }
+
+func listServices(projectName, namespace string, config config.Config) (names []string, err error) {
+	_, _, projectClient, err := getProjectClient(projectName, config)
+	if err != nil {
+		return
+	}
+
+	services, err := projectClient.Services(namespace)
+	if err != nil {
+		return
+	}
+
+	for _, service := range services {
+		name, err := service.Name()
+		if err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+
+	return
+}
+
+func listIngresses(projectName, namespace string, config config.Config) (names []string, err error) {
+	_, _, projectClient, err := getProjectClient(projectName, config)
+	if err != nil {
+		return
+	}
+
+	ingresses, err := projectClient.Ingresses(namespace)
+	if err != nil {
+		return
+	}
+
+	for _, ingress := range ingresses {
+		name, err := ingress.Name()
+		if err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+
+	return
+}
+
+func listNetworkPolicies(projectName, namespace string, config config.Config) (names []string, err error) {
+	_, _, projectClient, err := getProjectClient(projectName, config)
+	if err != nil {
+		return
+	}
+
+	networkPolicies, err := projectClient.NetworkPolicies(namespace)
+	if err != nil {
+		return
+	}
+
+	for _, networkPolicy := range networkPolicies {
+		name, err := networkPolicy.Name()
+		if err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+
+	return
+}
+
+func listPersistentVolumeClaims(projectName, namespace string, config config.Config) (names []string, err error) {
+	_, _, projectClient, err := getProjectClient(projectName, config)
+	if err != nil {
+		return
+	}
+
+	volumeClaims, err := projectClient.PersistentVolumeClaims(namespace)
+	if err != nil {
+		return
+	}
+
+	for _, volumeClaim := range volumeClaims {
+		name, err := volumeClaim.Name()
+		if err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+
+	return
+}
+
+func listStorageClasses(config config.Config) (names []string, err error) {
+	_, clusterClient, err := getClusterClient(config)
+	if err != nil {
+		return
+	}
+
+	storageClasses, err := clusterClient.StorageClasses()
+	if err != nil {
+		return
+	}
+
+	for _, storageClass := range storageClasses {
+		name, err := storageClass.Name()
+		if err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+
+	return
+}
+
+func listPersistentVolumes(config config.Config) (names []string, err error) {
+	_, clusterClient, err := getClusterClient(config)
+	if err != nil {
+		return
+	}
+
+	persistentVolumes, err := clusterClient.PersistentVolumes()
+	if err != nil {
+		return
+	}
+
+	for _, persistentVolume := range persistentVolumes {
+		name, err := persistentVolume.Name()
+		if err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+
+	return
+}
+
+func listMultiClusterApps(config config.Config) (names []string, err error) {
+	rancherClient, err := getRancherClient(config)
+	if err != nil {
+		return
+	}
+
+	multiClusterApps, err := rancherClient.MultiClusterApps()
+	if err != nil {
+		return
+	}
+
+	for _, multiClusterApp := range multiClusterApps {
+		name, err := multiClusterApp.Name()
+		if err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+
+	return
+}
@@ -0,0 +1,236 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// OutputJSON renders a report as JSON
+	OutputJSON = "json"
+	// OutputYAML renders a report as YAML
+	OutputYAML = "yaml"
+	// OutputJUnit renders a report as JUnit XML, one test case per resource
+	OutputJUnit = "junit"
+
+	actionParsed = "parsed"
+)
+
+// OutputFormats are all supported report formats
+var OutputFormats = []string{OutputJSON, OutputYAML, OutputJUnit}
+
+// Report lists the outcome of all resources handled by a command
+type Report struct {
+	Resources []descriptor.ResourceReport `json:"resources" yaml:"resources"`
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     float64          `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      float64         `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// IsOutputFormat checks if format is one of the OutputFormats
+func IsOutputFormat(format string) bool {
+	for _, supported := range OutputFormats {
+		if format == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteReport renders the reports of all handled resources in the given format
+func WriteReport(out io.Writer, format, name string, reports []descriptor.ResourceReport) error {
+	report := Report{Resources: reports}
+	if report.Resources == nil {
+		report.Resources = []descriptor.ResourceReport{}
+	}
+	switch format {
+	case OutputJSON:
+		return writeJSON(out, report)
+	case OutputYAML:
+		return writeYAML(out, report)
+	case OutputJUnit:
+		return writeJUnit(out, name, report)
+	default:
+		return fmt.Errorf("Unsupported output format [%s]", format)
+	}
+}
+
+// WriteDescriptors renders parsed descriptors in the given format
+func WriteDescriptors(out io.Writer, format string, descriptors []interface{}) error {
+	switch format {
+	case OutputJSON:
+		// the descriptor models are only tagged for YAML
+		objects := make([]interface{}, 0, len(descriptors))
+		for _, parsed := range descriptors {
+			object, err := toJSONObject(parsed)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, object)
+		}
+		return writeJSON(out, objects)
+	case OutputYAML:
+		for _, parsed := range descriptors {
+			if _, err := io.WriteString(out, "---\n"); err != nil {
+				return err
+			}
+			if err := writeYAML(out, parsed); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("Unsupported output format [%s]", format)
+	}
+}
+
+func writeJSON(out io.Writer, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+func writeYAML(out io.Writer, value interface{}) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+func writeJUnit(out io.Writer, name string, report Report) error {
+	suite := junitTestSuite{Name: name}
+	for _, resource := range report.Resources {
+		testCase := junitTestCase{
+			ClassName: resource.Type,
+			Name:      resource.Name,
+			Time:      resource.Duration,
+			SystemOut: resource.Action + junitOutputs(resource.Outputs),
+		}
+		if resource.Namespace != "" {
+			testCase.Name = resource.Namespace + "/" + resource.Name
+		}
+		if resource.Error != "" {
+			testCase.Failure = &junitFailure{
+				Message: resource.Error,
+				Type:    resource.Action,
+				Text:    resource.Error,
+			}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Time += resource.Duration
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suites := junitTestSuites{
+		Name:     name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, xml.Header+string(data))
+	return err
+}
+
+// junitOutputs renders the outputs of a resource as sorted lines
+func junitOutputs(outputs map[string]string) string {
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := ""
+	for _, key := range keys {
+		result += fmt.Sprintf("\n%s: %s", key, outputs[key])
+	}
+	return result
+}
+
+func toJSONObject(value interface{}) (interface{}, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var object interface{}
+	if err = yaml.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return stringKeys(object), nil
+}
+
+// stringKeys converts the maps created by yaml.v2 into maps supported by encoding/json
+func stringKeys(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(typed))
+		for key, member := range typed {
+			result[fmt.Sprint(key)] = stringKeys(member)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		for index, member := range typed {
+			result[index] = stringKeys(member)
+		}
+		return result
+	default:
+		return value
+	}
+}
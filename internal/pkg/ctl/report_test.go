@@ -0,0 +1,180 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+)
+
+var testReports = []descriptor.ResourceReport{
+	{Type: "Namespace", Name: "web", Action: descriptor.ActionCreated, Duration: 0.5},
+	{Type: "Deployment", Name: "nginx", Namespace: "web", Action: descriptor.ActionFailed, Duration: 1.5, Error: "Failed to create"},
+}
+
+func TestWriteReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		reports []descriptor.ResourceReport
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "json",
+			format:  OutputJSON,
+			reports: testReports,
+			want: `{
+  "resources": [
+    {
+      "type": "Namespace",
+      "name": "web",
+      "action": "created",
+      "duration": 0.5
+    },
+    {
+      "type": "Deployment",
+      "name": "nginx",
+      "namespace": "web",
+      "action": "failed",
+      "duration": 1.5,
+      "error": "Failed to create"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:   "json_empty",
+			format: OutputJSON,
+			want:   "{\n  \"resources\": []\n}\n",
+		},
+		{
+			name:    "yaml",
+			format:  OutputYAML,
+			reports: testReports,
+			want: `resources:
+- type: Namespace
+  name: web
+  action: created
+  duration: 0.5
+- type: Deployment
+  name: nginx
+  namespace: web
+  action: failed
+  duration: 1.5
+  error: Failed to create
+`,
+		},
+		{
+			name:    "junit",
+			format:  OutputJUnit,
+			reports: testReports,
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="test-suite" tests="2" failures="1" time="2">
+  <testsuite name="test-suite" tests="2" failures="1" time="2">
+    <testcase classname="Namespace" name="web" time="0.5">
+      <system-out>created</system-out>
+    </testcase>
+    <testcase classname="Deployment" name="web/nginx" time="1.5">
+      <failure message="Failed to create" type="failed">Failed to create</failure>
+      <system-out>failed</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+		{
+			name:   "yaml_outputs",
+			format: OutputYAML,
+			reports: []descriptor.ResourceReport{
+				{Type: "Cluster", Name: "test-cluster", Action: descriptor.ActionCreated, Duration: 2, Outputs: map[string]string{"registration_command": "sudo docker run rancher/rancher-agent"}},
+			},
+			want: `resources:
+- type: Cluster
+  name: test-cluster
+  action: created
+  duration: 2
+  outputs:
+    registration_command: sudo docker run rancher/rancher-agent
+`,
+		},
+		{
+			name:   "junit_outputs",
+			format: OutputJUnit,
+			reports: []descriptor.ResourceReport{
+				{Type: "Cluster", Name: "test-cluster", Action: descriptor.ActionCreated, Duration: 2, Outputs: map[string]string{"registration_command": "sudo docker run rancher/rancher-agent"}},
+			},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="test-suite" tests="1" failures="0" time="2">
+  <testsuite name="test-suite" tests="1" failures="0" time="2">
+    <testcase classname="Cluster" name="test-cluster" time="2">
+      <system-out>created&#xA;registration_command: sudo docker run rancher/rancher-agent</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+		{
+			name:    "unsupported",
+			format:  "xml",
+			wantErr: "Unsupported output format [xml]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &strings.Builder{}
+			err := WriteReport(out, tt.format, "test-suite", tt.reports)
+			if tt.wantErr != "" {
+				assert.NotOk(t, err, tt.wantErr)
+				return
+			}
+			assert.Ok(t, err)
+			assert.Equals(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestParseDescriptors(t *testing.T) {
+	defer resetBackendCalls()
+	unexpectAllBackendCalls()
+	newProjectParser = project.NewProjectParser
+
+	descriptors, reports, err := ParseDescriptors(
+		"test-descriptor.yaml",
+		[]byte("---\napi_version: \"2.0\"\nkind: Project\nmetadata:\n  name: test-project\n---\napi_version: \"2.0\"\nkind: Unknown\nmetadata:\n  name: unknown"),
+		nil,
+	)
+	assert.NotOk(t, err, "Unknown descriptor Unknown")
+	assert.Equals(t, 1, len(descriptors))
+	assert.Equals(t, 2, len(reports))
+	assert.Equals(t, "test-project", reports[0].Name)
+	assert.Equals(t, actionParsed, reports[0].Action)
+	assert.Equals(t, descriptor.ActionFailed, reports[1].Action)
+	assert.Equals(t, "Unknown descriptor Unknown", reports[1].Error)
+}
+
+func TestWriteDescriptors(t *testing.T) {
+	out := &strings.Builder{}
+	err := WriteDescriptors(out, OutputJSON, []interface{}{
+		map[interface{}]interface{}{"kind": "Project", "metadata": map[interface{}]interface{}{"name": "test-project"}},
+	})
+	assert.Ok(t, err)
+	assert.Equals(t, "[\n  {\n    \"kind\": \"Project\",\n    \"metadata\": {\n      \"name\": \"test-project\"\n    }\n  }\n]\n", out.String())
+}
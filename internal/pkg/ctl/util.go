@@ -9,6 +9,7 @@ package ctl
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/config"
 	rancher_client "github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
@@ -52,15 +53,26 @@ func doGetClusterClient(config config.Config) (rancherClient rancher_client.Ranc
 
 func doGetRancherClient(config config.Config) (rancherClient rancher_client.RancherClient, err error) {
 	rancherClient, err = newRancherClient(rancher_client.RancherConfig{
-		RancherURL:   config.RancherURL(),
-		AccessKey:    config.AccessKey(),
-		SecretKey:    config.SecretKey(),
-		Insecure:     config.InsecureAPI(),
-		CACerts:      config.CACerts(),
-		MergeAnswers: config.MergeAnswers(),
+		RancherURL:        config.RancherURL(),
+		AccessKey:         config.AccessKey(),
+		SecretKey:         config.SecretKey(),
+		Insecure:          config.InsecureAPI(),
+		CACerts:           config.CACerts(),
+		MergeAnswers:      config.MergeAnswers(),
+		WaitTimeout:       waitTimeout(config),
+		InitScriptShell:   config.InitScriptShell(),
+		InitScriptTimeout: config.InitScriptTimeout(),
 	})
 	if err != nil {
 		return
 	}
 	return
 }
+
+// waitTimeout is the time to wait for resources to become ready, zero if waiting is disabled
+func waitTimeout(config config.Config) time.Duration {
+	if !config.Wait() {
+		return 0
+	}
+	return config.Timeout()
+}
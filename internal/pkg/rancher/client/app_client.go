@@ -68,6 +68,7 @@ func newAppClient(
 
 type appClient struct {
 	resourceClient
+	projectDeclaration
 	app           projectModel.App
 	backendData   *backendProjectClient.App
 	projectClient ProjectClient
@@ -115,6 +116,7 @@ func (client *appClient) Create(dryRun bool) (changed bool, err error) {
 		Name:            client.app.Name,
 		ExternalID:      externalID,
 		TargetNamespace: client.app.Namespace,
+		Labels:          client.withProjectLabel(withHashLabel(nil, hashOf(client.app))),
 	}
 	if client.app.ValuesYaml != "" {
 		pattern.ValuesYaml = client.app.ValuesYaml
@@ -124,8 +126,8 @@ func (client *appClient) Create(dryRun bool) (changed bool, err error) {
 
 	if dryRun {
 		client.logger.WithField("object", pattern).Info("Do Dry-Run Create")
-	} else {
-		_, err = backendClient.App.Create(pattern)
+	} else if _, err = backendClient.App.Create(pattern); err == nil {
+		err = waitUntilReady(client.projectClient.config(), client.logger, client.readyAfter(""))
 	}
 	return err == nil, err
 }
@@ -141,35 +143,15 @@ func (client *appClient) Upgrade(dryRun bool) (changed bool, err error) {
 	if installedApp == nil {
 		return changed, fmt.Errorf("App %v not found", client.name)
 	}
+	previousRevision := installedApp.AppRevisionID
 
-	externalID, err := client.externalID(client.app.Catalog, client.app.CatalogType, client.app.Chart, client.app.Version)
+	au, unchanged, err := client.upgradeConfig(*installedApp)
 	if err != nil {
 		return
 	}
-	au := &backendProjectClient.AppUpgradeConfig{
-		ExternalID: externalID,
-	}
-	if client.app.ValuesYaml != "" {
-		if strings.TrimSpace(installedApp.ValuesYaml) == strings.TrimSpace(client.app.ValuesYaml) {
-			client.logger.Debug("Skip upgrade app - no changes")
-			return
-		}
-		au.ValuesYaml = client.app.ValuesYaml
-	} else {
-		resultAnswers := map[string]string{}
-		if client.projectClient.config().MergeAnswers {
-			for key, value := range installedApp.Answers {
-				resultAnswers[key] = value
-			}
-		}
-		for key, value := range client.app.Answers {
-			resultAnswers[key] = value
-		}
-		if reflect.DeepEqual(installedApp.Answers, resultAnswers) {
-			client.logger.Debug("Skip upgrade app - no changes")
-			return
-		}
-		au.Answers = resultAnswers
+	if unchanged {
+		client.logger.Debug("Skip upgrade app - no changes")
+		return
 	}
 	if client.app.SkipUpgrade {
 		client.logger.Info("Suppress upgrade app - by config")
@@ -180,8 +162,11 @@ func (client *appClient) Upgrade(dryRun bool) (changed bool, err error) {
 
 	if dryRun {
 		client.logger.WithField("object", installedApp).Info("Do Dry-Run Upgrade")
-	} else {
-		err = backendClient.App.ActionUpgrade(installedApp, au)
+	} else if err = backendClient.App.ActionUpgrade(installedApp, au); err == nil {
+		// the revision is only known for apps installed by rancher 2.1 and newer
+		if err = waitUntilReady(client.projectClient.config(), client.logger, client.readyAfter(previousRevision)); err != nil && previousRevision != "" {
+			err = client.rollback(previousRevision, err)
+		}
 	}
 
 	return err == nil, err
@@ -203,6 +188,55 @@ func (client *appClient) Delete(dryRun bool) (changed bool, err error) {
 	return err == nil, err
 }
 
+func (client *appClient) Desired() (interface{}, error) {
+	externalID, err := client.externalID(client.app.Catalog, client.app.CatalogType, client.app.Chart, client.app.Version)
+	if err != nil {
+		return nil, err
+	}
+	pattern := backendProjectClient.App{
+		Name:            client.app.Name,
+		ExternalID:      externalID,
+		TargetNamespace: client.app.Namespace,
+	}
+	if client.app.ValuesYaml != "" {
+		pattern.ValuesYaml = strings.TrimSpace(client.app.ValuesYaml)
+	} else {
+		pattern.Answers = client.app.Answers
+	}
+	return pattern, nil
+}
+
+func (client *appClient) Existing() (interface{}, error) {
+	installedApp, err := client.loadInstalledApp()
+	if err != nil || installedApp == nil {
+		return nil, err
+	}
+	result := *installedApp
+	result.ValuesYaml = strings.TrimSpace(result.ValuesYaml)
+	return result, nil
+}
+
+func (client *appClient) SkipsUpgrade() bool {
+	return client.app.SkipUpgrade
+}
+
+func (client *appClient) Unchanged() (bool, error) {
+	installedApp, err := client.loadInstalledApp()
+	if err != nil || installedApp == nil {
+		return false, err
+	}
+	_, unchanged, err := client.upgradeConfig(*installedApp)
+	return unchanged, err
+}
+
+func (client *appClient) Owned() (bool, error) {
+	installedApp, err := client.loadInstalledApp()
+	if err != nil || installedApp == nil {
+		return false, err
+	}
+	return isDeclaredByProject(installedApp.Labels), nil
+}
+
 func (client *appClient) Data() (projectModel.App, error) {
 	return client.app, nil
 }
@@ -216,6 +250,75 @@ func (client *appClient) SetData(app projectModel.App) error {
 	return nil
 }
 
+// upgradeConfig is the upgrade of the installed app to the declared values or answers,
+// unchanged is true if the installed app already has them
+func (client *appClient) upgradeConfig(installedApp backendProjectClient.App) (au *backendProjectClient.AppUpgradeConfig, unchanged bool, err error) {
+	externalID, err := client.externalID(client.app.Catalog, client.app.CatalogType, client.app.Chart, client.app.Version)
+	if err != nil {
+		return
+	}
+	au = &backendProjectClient.AppUpgradeConfig{
+		ExternalID: externalID,
+	}
+	if client.app.ValuesYaml != "" {
+		au.ValuesYaml = client.app.ValuesYaml
+		unchanged = strings.TrimSpace(installedApp.ValuesYaml) == strings.TrimSpace(client.app.ValuesYaml)
+		return
+	}
+	resultAnswers := map[string]string{}
+	if client.projectClient.config().MergeAnswers {
+		for key, value := range installedApp.Answers {
+			resultAnswers[key] = value
+		}
+	}
+	for key, value := range client.app.Answers {
+		resultAnswers[key] = value
+	}
+	au.Answers = resultAnswers
+	unchanged = reflect.DeepEqual(installedApp.Answers, resultAnswers)
+	return
+}
+
+// rollback restores the revision of the app before a failed upgrade
+func (client *appClient) rollback(revision string, upgradeErr error) error {
+	backendClient, err := client.projectClient.backendProjectClient()
+	if err != nil {
+		return upgradeErr
+	}
+	installedApp, err := client.loadInstalledApp()
+	if err != nil || installedApp == nil {
+		return upgradeErr
+	}
+	client.logger.WithField("revision", revision).Warn("Rollback app")
+	if err = backendClient.App.ActionRollback(installedApp, &backendProjectClient.RollbackRevision{RevisionID: revision}); err != nil {
+		client.logger.WithError(err).Error("Failed to rollback app")
+		return fmt.Errorf("%v, failed to rollback app, %v", upgradeErr, err)
+	}
+	return RolledBackError{Revision: revision, Err: upgradeErr}
+}
+
+// ready is true if the app is active, a app in error state fails with the message of rancher
+// readyAfter checks if the app is active in a revision other than previousRevision,
+// right after an upgrade rancher may still report the previous revision as active
+func (client *appClient) readyAfter(previousRevision string) func() (bool, error) {
+	return func() (bool, error) {
+		installedApp, err := client.loadInstalledApp()
+		if err != nil {
+			return false, err
+		}
+		if installedApp == nil {
+			return false, fmt.Errorf("App %v not found", client.name)
+		}
+		if err = transitioningError(installedApp.State, installedApp.Transitioning, installedApp.TransitioningMessage); err != nil {
+			return false, err
+		}
+		if previousRevision != "" && installedApp.AppRevisionID == previousRevision {
+			return false, nil
+		}
+		return installedApp.State == "active" && installedApp.Transitioning != "yes", nil
+	}
+}
+
 func (client *appClient) loadInstalledApp() (installedApp *backendProjectClient.App, err error) {
 	backendClient, err := client.projectClient.backendProjectClient()
 	if err != nil {
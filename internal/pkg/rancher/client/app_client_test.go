@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/assert"
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
@@ -250,6 +251,190 @@ key: value
 	}
 }
 
+func Test_appClient_ready(t *testing.T) {
+	tests := []struct {
+		name             string
+		app              backendProjectClient.App
+		previousRevision string
+		wanted           bool
+		wantedErr        string
+	}{
+		{
+			name:   "Active",
+			app:    backendProjectClient.App{State: "active", Transitioning: "no"},
+			wanted: true,
+		},
+		{
+			name:             "Previous_Revision",
+			app:              backendProjectClient.App{State: "active", Transitioning: "no", AppRevisionID: "apprevision-1"},
+			previousRevision: "apprevision-1",
+			wanted:           false,
+		},
+		{
+			name:             "Upgraded_Revision",
+			app:              backendProjectClient.App{State: "active", Transitioning: "no", AppRevisionID: "apprevision-2"},
+			previousRevision: "apprevision-1",
+			wanted:           true,
+		},
+		{
+			name:   "Deploying",
+			app:    backendProjectClient.App{State: "deploying", Transitioning: "yes"},
+			wanted: false,
+		},
+		{
+			name:      "Error",
+			app:       backendProjectClient.App{State: "active", Transitioning: "error", TransitioningMessage: "Failed to install app"},
+			wantedErr: "Resource is active, Failed to install app",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			appOperationsStub := stubs.CreateAppOperationsStub(t)
+			appOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.AppCollection, error) {
+				app := tt.app
+				app.Name = simpleAppName
+				return &backendProjectClient.AppCollection{
+					Data: []backendProjectClient.App{app},
+				}, nil
+			}
+			testClients.ProjectClient.App = appOperationsStub
+			projectClient := simpleProjectClient()
+			projectClient._backendProjectClient = testClients.ProjectClient
+			result, err := newAppClient(simpleAppName, projectClient, logrus.New().WithFields(logrus.Fields{}))
+			assert.Ok(t, err)
+			ready, err := result.(*appClient).readyAfter(tt.previousRevision)()
+			if tt.wantedErr != "" {
+				assert.NotOk(t, err, tt.wantedErr)
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wanted, ready)
+			}
+		})
+	}
+}
+
+func Test_appClient_Upgrade_Rollback(t *testing.T) {
+	tests := []struct {
+		name             string
+		revision         string
+		wantedRolledBack bool
+		wantedErr        string
+	}{
+		{
+			name:             "Rollback",
+			revision:         "apprevision-1",
+			wantedRolledBack: true,
+			wantedErr:        "Resource is active, Failed to upgrade app, rolled back to revision apprevision-1",
+		},
+		{
+			name:      "Without_Revision",
+			wantedErr: "Resource is active, Failed to upgrade app",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgraded, rolledBack := false, false
+			testClients := stubs.CreateBackendStubs(t)
+			appOperationsStub := stubs.CreateAppOperationsStub(t)
+			appOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.AppCollection, error) {
+				app := backendProjectClient.App{
+					Name:          simpleAppName,
+					ExternalID:    "catalog://?catalog=library&template=simple-app&version=1.0.0",
+					AppRevisionID: tt.revision,
+					Answers:       map[string]string{"key": "value"},
+					State:         "active",
+				}
+				if upgraded {
+					app.Transitioning = "error"
+					app.TransitioningMessage = "Failed to upgrade app"
+				}
+				return &backendProjectClient.AppCollection{
+					Data: []backendProjectClient.App{app},
+				}, nil
+			}
+			appOperationsStub.DoActionUpgrade = func(resource *backendProjectClient.App, input *backendProjectClient.AppUpgradeConfig) error {
+				upgraded = true
+				return nil
+			}
+			appOperationsStub.DoActionRollback = func(resource *backendProjectClient.App, input *backendProjectClient.RollbackRevision) error {
+				assert.Equals(t, tt.revision, input.RevisionID)
+				rolledBack = true
+				return nil
+			}
+			testClients.ProjectClient.App = appOperationsStub
+			projectClient := simpleProjectClient()
+			projectClient._backendProjectClient = testClients.ProjectClient
+			projectClient.rancherConfig.WaitTimeout = time.Second
+			result, err := newAppClientWithData(projectModel.App{
+				Name:    simpleAppName,
+				Catalog: "library",
+				Chart:   simpleAppName,
+				Version: "1.1.0",
+				Answers: map[string]string{"key": "changed"},
+			}, projectClient, logrus.New().WithFields(logrus.Fields{}))
+			assert.Ok(t, err)
+			_, err = result.Upgrade(false)
+			assert.NotOk(t, err, tt.wantedErr)
+			_, isRolledBack := err.(RolledBackError)
+			assert.Equals(t, tt.wantedRolledBack, isRolledBack)
+			assert.Equals(t, tt.wantedRolledBack, rolledBack)
+		})
+	}
+}
+
+func Test_appClient_Upgrade_Wait(t *testing.T) {
+	defer func(interval time.Duration) { readyPollInterval = interval }(readyPollInterval)
+	readyPollInterval = time.Millisecond
+	upgraded, polls := false, 0
+	testClients := stubs.CreateBackendStubs(t)
+	appOperationsStub := stubs.CreateAppOperationsStub(t)
+	appOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.AppCollection, error) {
+		app := backendProjectClient.App{
+			Name:          simpleAppName,
+			ExternalID:    "catalog://?catalog=library&template=simple-app&version=1.0.0",
+			AppRevisionID: "apprevision-1",
+			Answers:       map[string]string{"key": "value"},
+			State:         "active",
+		}
+		if upgraded {
+			polls++
+			// the first poll still sees the active previous revision
+			if polls > 1 {
+				app.AppRevisionID = "apprevision-2"
+				app.Transitioning = "error"
+				app.TransitioningMessage = "Failed to upgrade app"
+			}
+		}
+		return &backendProjectClient.AppCollection{
+			Data: []backendProjectClient.App{app},
+		}, nil
+	}
+	appOperationsStub.DoActionUpgrade = func(resource *backendProjectClient.App, input *backendProjectClient.AppUpgradeConfig) error {
+		upgraded = true
+		return nil
+	}
+	appOperationsStub.DoActionRollback = func(resource *backendProjectClient.App, input *backendProjectClient.RollbackRevision) error {
+		assert.Equals(t, "apprevision-1", input.RevisionID)
+		return nil
+	}
+	testClients.ProjectClient.App = appOperationsStub
+	projectClient := simpleProjectClient()
+	projectClient._backendProjectClient = testClients.ProjectClient
+	projectClient.rancherConfig.WaitTimeout = time.Second
+	result, err := newAppClientWithData(projectModel.App{
+		Name:    simpleAppName,
+		Catalog: "library",
+		Chart:   simpleAppName,
+		Version: "1.1.0",
+		Answers: map[string]string{"key": "changed"},
+	}, projectClient, logrus.New().WithFields(logrus.Fields{}))
+	assert.Ok(t, err)
+	_, err = result.Upgrade(false)
+	assert.NotOk(t, err, "Resource is active, Failed to upgrade app, rolled back to revision apprevision-1")
+	assert.Equals(t, true, polls > 1)
+}
+
 func existingAppClient(t *testing.T, name, namespace, catalog, version string, answers, changedAnswers map[string]string, valuesYaml, changedValuesYaml string) *appClient {
 	testClients := stubs.CreateBackendStubs(t)
 	expectedListOpts := &types.ListOpts{
@@ -335,12 +520,25 @@ func notExistingAppClient(t *testing.T, clusterID, projectID, name, namespace, c
 			"name": name,
 		},
 	}
+	app := projectModel.App{
+		Name:        name,
+		Namespace:   namespace,
+		Catalog:     catalog,
+		CatalogType: catalogType,
+		Version:     version,
+		Chart:       name,
+		Answers:     answers,
+		ValuesYaml:  valuesYaml,
+	}
 	expectedApp := &backendProjectClient.App{
 		Name:            name,
 		ExternalID:      externalID,
 		TargetNamespace: namespace,
 		Answers:         answers,
 		ValuesYaml:      valuesYaml,
+		Labels: map[string]string{
+			"cattlectl.io/hash": hashOf(app),
+		},
 	}
 	appOperationsStub := stubs.CreateAppOperationsStub(t)
 	appOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.AppCollection, error) {
@@ -359,16 +557,7 @@ func notExistingAppClient(t *testing.T, clusterID, projectID, name, namespace, c
 	}
 	testClients.ProjectClient.App = appOperationsStub
 	result, err := newAppClientWithData(
-		projectModel.App{
-			Name:        name,
-			Namespace:   namespace,
-			Catalog:     catalog,
-			CatalogType: catalogType,
-			Version:     version,
-			Chart:       name,
-			Answers:     answers,
-			ValuesYaml:  valuesYaml,
-		},
+		app,
 		&projectClient{
 			resourceClient: resourceClient{
 				name: simpleProjectName,
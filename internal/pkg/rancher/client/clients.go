@@ -15,6 +15,7 @@
 package client
 
 import (
+	clusterModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/model"
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 	backendClusterClient "github.com/rancher/types/client/cluster/v3"
@@ -28,6 +29,14 @@ type RancherClient interface {
 	Clusters() ([]ClusterClient, error)
 	Catalog(string) (CatalogClient, error)
 	Catalogs() ([]CatalogClient, error)
+	Setting(string) (SettingClient, error)
+	RoleTemplate(string) (RoleTemplateClient, error)
+	User(string) (UserClient, error)
+	GlobalRoleBinding(rancherModel.GlobalRoleBinding) (GlobalRoleBindingClient, error)
+	GlobalDNSProvider(string) (GlobalDNSProviderClient, error)
+	GlobalDNSEntry(string) (GlobalDNSEntryClient, error)
+	MultiClusterApp(string) (MultiClusterAppClient, error)
+	MultiClusterApps() ([]MultiClusterAppClient, error)
 
 	backendRancherClient() (*backendRancherClient.Client, error)
 }
@@ -50,9 +59,48 @@ type NamespacedResourceClient interface {
 	Namespace() (string, error)
 }
 
+// DiffableResourceClient is a client to a Rancher resource able to expose its desired and its existing state
+type DiffableResourceClient interface {
+	ResourceClient
+	// Desired is the object the client would send to rancher
+	Desired() (interface{}, error)
+	// Existing is the object currently stored in rancher or nil
+	Existing() (interface{}, error)
+	// SkipsUpgrade is true if changes to an existing resource are not applied
+	SkipsUpgrade() bool
+	// Unchanged is true if Upgrade would leave the existing resource as it is,
+	// e.g. because its hash label matches the descriptor
+	Unchanged() (bool, error)
+}
+
+// OutputResourceClient is a client to a Rancher resource exposing values the user needs after apply,
+// e.g. the command to register nodes
+type OutputResourceClient interface {
+	ResourceClient
+	Outputs() (map[string]string, error)
+}
+
+// PendingResourceClient is a client to a Rancher resource which may exist before it accepts the resources inside it,
+// e.g. a new cluster waiting for its custom nodes
+type PendingResourceClient interface {
+	ResourceClient
+	// Pending reports if the resources inside the resource have to be skipped
+	Pending() (bool, error)
+}
+
+// ProjectDeclaredResourceClient is a client to a Rancher resource which can be declared by a project descriptor,
+// Owned of such clients is only true for resources declared by a project descriptor
+type ProjectDeclaredResourceClient interface {
+	ResourceClient
+	// DeclaredBy marks the resource as declared by the descriptor of the given project
+	DeclaredBy(projectName string)
+}
+
 // ClusterClient interacts with a Rancher cluster resource
 type ClusterClient interface {
 	ResourceClient
+	Data() (clusterModel.Cluster, error)
+	SetData(cluster clusterModel.Cluster) error
 	Project(projectName string) (ProjectClient, error)
 	Projects() ([]ProjectClient, error)
 	StorageClass(name string) (StorageClassClient, error)
@@ -63,9 +111,13 @@ type ClusterClient interface {
 	Namespaces(projectName string) ([]NamespaceClient, error)
 	Catalog(string) (CatalogClient, error)
 	Catalogs() ([]CatalogClient, error)
+	ClusterRoleTemplateBinding(principalID string) (ClusterRoleTemplateBindingClient, error)
+	ClusterRoleTemplateBindings() ([]ClusterRoleTemplateBindingClient, error)
 
 	backendRancherClient() (*backendRancherClient.Client, error)
 	backendClusterClient() (*backendClusterClient.Client, error)
+	backendKubernetesClient() (*kubernetesClient, error)
+	config() RancherConfig
 }
 
 // ProjectClient interacts with a Rancher project resource
@@ -100,12 +152,27 @@ type ProjectClient interface {
 	DaemonSets(namespaceName string) ([]DaemonSetClient, error)
 	StatefulSet(name, namespaceName string) (StatefulSetClient, error)
 	StatefulSets(namespaceName string) ([]StatefulSetClient, error)
+	HorizontalPodAutoscaler(name, namespaceName string) (HorizontalPodAutoscalerClient, error)
+	HorizontalPodAutoscalers(namespaceName string) ([]HorizontalPodAutoscalerClient, error)
+	Service(name, namespaceName string) (ServiceClient, error)
+	Services(namespaceName string) ([]ServiceClient, error)
+	Ingress(name, namespaceName string) (IngressClient, error)
+	Ingresses(namespaceName string) ([]IngressClient, error)
+	NetworkPolicy(name, namespaceName string) (NetworkPolicyClient, error)
+	NetworkPolicies(namespaceName string) ([]NetworkPolicyClient, error)
+	PersistentVolumeClaim(name, namespaceName string) (PersistentVolumeClaimClient, error)
+	PersistentVolumeClaims(namespaceName string) ([]PersistentVolumeClaimClient, error)
 	Catalog(string) (CatalogClient, error)
 	Catalogs() ([]CatalogClient, error)
+	ProjectRoleTemplateBinding(principalID string) (ProjectRoleTemplateBindingClient, error)
+	ProjectRoleTemplateBindings() ([]ProjectRoleTemplateBindingClient, error)
+	Data() (projectModel.Project, error)
+	SetData(project projectModel.Project) error
 
 	backendRancherClient() (*backendRancherClient.Client, error)
 	backendClusterClient() (*backendClusterClient.Client, error)
 	backendProjectClient() (*backendProjectClient.Client, error)
+	backendKubernetesClient() (*kubernetesClient, error)
 	config() RancherConfig
 }
 
@@ -116,10 +183,60 @@ type CatalogClient interface {
 	SetData(catalog rancherModel.Catalog) error
 }
 
+// SettingClient interacts with a Rancher global setting
+type SettingClient interface {
+	ResourceClient
+	Data() (rancherModel.Setting, error)
+	SetData(setting rancherModel.Setting) error
+}
+
+// RoleTemplateClient interacts with a Rancher role template resource
+type RoleTemplateClient interface {
+	ResourceClient
+	Data() (rancherModel.RoleTemplate, error)
+	SetData(roleTemplate rancherModel.RoleTemplate) error
+}
+
+// UserClient interacts with a local Rancher user
+type UserClient interface {
+	ResourceClient
+	Data() (rancherModel.User, error)
+	SetData(user rancherModel.User) error
+}
+
+// GlobalRoleBindingClient interacts with a Rancher global role binding resource
+type GlobalRoleBindingClient interface {
+	ResourceClient
+	Data() (rancherModel.GlobalRoleBinding, error)
+	SetData(globalRoleBinding rancherModel.GlobalRoleBinding) error
+}
+
+// GlobalDNSProviderClient interacts with a Rancher global DNS provider resource
+type GlobalDNSProviderClient interface {
+	ResourceClient
+	Data() (rancherModel.GlobalDNSProvider, error)
+	SetData(provider rancherModel.GlobalDNSProvider) error
+}
+
+// GlobalDNSEntryClient interacts with a Rancher global DNS resource
+type GlobalDNSEntryClient interface {
+	ResourceClient
+	Data() (rancherModel.GlobalDNSEntry, error)
+	SetData(entry rancherModel.GlobalDNSEntry) error
+}
+
+// MultiClusterAppClient interacts with a Rancher multi cluster app resource
+type MultiClusterAppClient interface {
+	ResourceClient
+	Data() (rancherModel.MultiClusterApp, error)
+	SetData(multiClusterApp rancherModel.MultiClusterApp) error
+}
+
 // NamespaceClient interacts with a Rancher namespace resource
 type NamespaceClient interface {
 	ResourceClient
 	HasProject() (bool, error)
+	Owned() (bool, error)
 	Data() (projectModel.Namespace, error)
 	SetData(namespace projectModel.Namespace) error
 }
@@ -138,6 +255,22 @@ type PersistentVolumeClient interface {
 	SetData(persistentVolume projectModel.PersistentVolume) error
 }
 
+// ProjectRoleTemplateBindingClient interacts with the Rancher project role template bindings of one member
+type ProjectRoleTemplateBindingClient interface {
+	ResourceClient
+	Owned() (bool, error)
+	Data() (projectModel.Member, error)
+	SetData(member projectModel.Member) error
+}
+
+// ClusterRoleTemplateBindingClient interacts with the Rancher cluster role template bindings of one member
+type ClusterRoleTemplateBindingClient interface {
+	ResourceClient
+	Owned() (bool, error)
+	Data() (projectModel.Member, error)
+	SetData(member projectModel.Member) error
+}
+
 // CertificateClient interacts with a Rancher certificate resource
 type CertificateClient interface {
 	NamespacedResourceClient
@@ -148,6 +281,7 @@ type CertificateClient interface {
 // ConfigMapClient interacts with a Rancher config map or secret resource
 type ConfigMapClient interface {
 	NamespacedResourceClient
+	Owned() (bool, error)
 	Data() (projectModel.ConfigMap, error)
 	SetData(configMap projectModel.ConfigMap) error
 }
@@ -162,6 +296,7 @@ type DockerCredentialClient interface {
 // AppClient interacts with a Rancher app resource
 type AppClient interface {
 	ResourceClient
+	Owned() (bool, error)
 	Data() (projectModel.App, error)
 	SetData(app projectModel.App) error
 }
@@ -200,3 +335,43 @@ type StatefulSetClient interface {
 	Data() (projectModel.StatefulSet, error)
 	SetData(statefulSet projectModel.StatefulSet) error
 }
+
+// HorizontalPodAutoscalerClient interacts with a Rancher horizontal pod autoscaler resource
+type HorizontalPodAutoscalerClient interface {
+	NamespacedResourceClient
+	Owned() (bool, error)
+	Data() (projectModel.HorizontalPodAutoscaler, error)
+	SetData(horizontalPodAutoscaler projectModel.HorizontalPodAutoscaler) error
+}
+
+// ServiceClient interacts with a Rancher service resource
+type ServiceClient interface {
+	NamespacedResourceClient
+	Owned() (bool, error)
+	Data() (projectModel.Service, error)
+	SetData(service projectModel.Service) error
+}
+
+// NetworkPolicyClient interacts with a K8S network policy through the rancher proxy
+type NetworkPolicyClient interface {
+	NamespacedResourceClient
+	Owned() (bool, error)
+	Data() (projectModel.NetworkPolicy, error)
+	SetData(networkPolicy projectModel.NetworkPolicy) error
+}
+
+// PersistentVolumeClaimClient interacts with a Rancher persistent volume claim resource
+type PersistentVolumeClaimClient interface {
+	NamespacedResourceClient
+	Owned() (bool, error)
+	Data() (projectModel.VolumeClaim, error)
+	SetData(volumeClaim projectModel.VolumeClaim) error
+}
+
+// IngressClient interacts with a Rancher ingress resource
+type IngressClient interface {
+	NamespacedResourceClient
+	Owned() (bool, error)
+	Data() (projectModel.Ingress, error)
+	SetData(ingress projectModel.Ingress) error
+}
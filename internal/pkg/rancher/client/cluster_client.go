@@ -16,6 +16,8 @@ package client
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	clusterModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
@@ -36,27 +38,39 @@ func newClusterClient(
 			name:   name,
 			logger: logger.WithField("cluster_name", name),
 		},
-		config:            config,
+		rancherConfig:     config,
 		rancherClient:     rancherClient,
 		projectClients:    make(map[string]ProjectClient),
 		storageClasses:    make(map[string]StorageClassClient),
 		persistentVolumes: make(map[string]PersistentVolumeClient),
 		namespaces:        make(map[string]namespaceCacheEntry),
 		catalogClients:    make(map[string]CatalogClient),
+		memberClients:     make(map[string]ClusterRoleTemplateBindingClient),
 	}, nil
 }
 
 type clusterClient struct {
 	resourceClient
-	config                RancherConfig
+	rancherConfig         RancherConfig
 	rancherClient         RancherClient
 	_backendClusterClient *backendClusterClient.Client
-	cluster               clusterModel.Cluster
-	projectClients        map[string]ProjectClient
-	storageClasses        map[string]StorageClassClient
-	persistentVolumes     map[string]PersistentVolumeClient
-	namespaces            map[string]namespaceCacheEntry
-	catalogClients        map[string]CatalogClient
+	// _backendKubernetesClient is created on first use by backendKubernetesClient
+	_backendKubernetesClient *kubernetesClient
+	cluster                  clusterModel.Cluster
+	projectClients           map[string]ProjectClient
+	storageClasses           map[string]StorageClassClient
+	persistentVolumes        map[string]PersistentVolumeClient
+	namespaces               map[string]namespaceCacheEntry
+	catalogClients           map[string]CatalogClient
+	memberClients            map[string]ClusterRoleTemplateBindingClient
+	// cacheLock guards projectClients, storageClasses, persistentVolumes, namespaces, catalogClients and memberClients
+	// which are filled concurrently while converging the projects of a cluster
+	cacheLock sync.Mutex
+	// initLock guards the creation of _backendClusterClient and _backendKubernetesClient on first use
+	initLock sync.Mutex
+
+	// registrationCommand is set on create, otherwise it is read from the registration tokens of the cluster
+	registrationCommand string
 }
 
 type namespaceCacheEntry struct {
@@ -69,6 +83,8 @@ func (client *clusterClient) Type() string {
 }
 
 func (client *clusterClient) init() error {
+	client.initLock.Lock()
+	defer client.initLock.Unlock()
 	if client._backendClusterClient != nil {
 		return nil
 	}
@@ -79,11 +95,13 @@ func (client *clusterClient) init() error {
 	if id, err = client.ID(); err != nil {
 		return err
 	}
-	client._backendClusterClient, err = createBackendClusterClient(client.config, id)
+	client._backendClusterClient, err = createBackendClusterClient(client.rancherConfig, id)
 	return err
 }
 
 func (client *clusterClient) ID() (string, error) {
+	client.idLock.Lock()
+	defer client.idLock.Unlock()
 	if client.id != "" {
 		return client.id, nil
 	}
@@ -107,14 +125,392 @@ func (client *clusterClient) ID() (string, error) {
 }
 
 func (client *clusterClient) Exists() (bool, error) {
-	_, err := client.ID()
+	existingCluster, err := client.loadExistingCluster()
+	if err != nil {
+		return false, err
+	}
+	if existingCluster == nil {
+		client.logger.Debug("Cluster not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *clusterClient) Create(dryRun bool) (changed bool, err error) {
+	if client.cluster.RKEConfig == nil {
+		return changed, fmt.Errorf("Cluster %v not found, declare a rke_config to create it", client.name)
+	}
+	backendClient, err := client.backendRancherClient()
+	if err != nil {
+		return
+	}
+
+	client.logger.Info("Create new RKE cluster")
+	newCluster := backendRancherClient.Cluster{
+		Name: client.name,
+		Labels: map[string]string{
+			"cattlectl.io/hash": client.provisioningHash(),
+		},
+		RancherKubernetesEngineConfig: &backendRancherClient.RancherKubernetesEngineConfig{},
+	}
+	applyRKEConfig(*client.cluster.RKEConfig, newCluster.RancherKubernetesEngineConfig)
+
+	if dryRun {
+		client.logger.WithField("object", newCluster).Info("Do Dry-Run Create")
+		return true, nil
+	}
+	createdCluster, err := backendClient.Cluster.Create(&newCluster)
+	if err != nil {
+		return
+	}
+	client.idLock.Lock()
+	client.id = createdCluster.ID
+	client.idLock.Unlock()
+	if err = client.upgradeNodePools(createdCluster.ID, dryRun); err != nil {
+		return
+	}
+	token, err := backendClient.ClusterRegistrationToken.Create(&backendRancherClient.ClusterRegistrationToken{
+		ClusterID: createdCluster.ID,
+	})
+	if err != nil {
+		return
+	}
+	client.registrationCommand = token.NodeCommand
+	client.logger.WithField("command", token.NodeCommand).Info("Register custom nodes with the registration command")
+	// custom nodes are registered after apply, only a cluster with node pools can become ready on its own
+	if len(client.cluster.NodePools) > 0 {
+		err = waitUntilReady(client.rancherConfig, client.logger, client.ready)
+	}
+	return err == nil, err
+}
+
+func (client *clusterClient) Upgrade(dryRun bool) (changed bool, err error) {
+	if client.cluster.RKEConfig == nil {
+		client.logger.Debug("Skip upgrade cluster - no rke_config declared")
+		return
+	}
+	backendClient, err := client.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingCluster, err := client.loadExistingCluster()
+	if err != nil {
+		return
+	}
+	if existingCluster == nil {
+		return changed, fmt.Errorf("Cluster %v not found", client.name)
+	}
+	if existingCluster.RancherKubernetesEngineConfig == nil {
+		return changed, fmt.Errorf("Cluster %v is not a RKE cluster", client.name)
+	}
+	hash := client.provisioningHash()
+	if existingCluster.Labels["cattlectl.io/hash"] == hash {
+		client.logger.Debug("Skip upgrade cluster - no changes")
+		return
+	}
+	client.logger.Info("Upgrade cluster")
+	existingCluster.Labels = withHashLabel(existingCluster.Labels, hash)
+	applyRKEConfig(*client.cluster.RKEConfig, existingCluster.RancherKubernetesEngineConfig)
+
+	if dryRun {
+		client.logger.WithField("object", existingCluster).Info("Do Dry-Run Upgrade")
+	} else if _, err = backendClient.Cluster.Replace(existingCluster); err != nil {
+		return
+	}
+	err = client.upgradeNodePools(existingCluster.ID, dryRun)
 	return err == nil, err
 }
+
+func (client *clusterClient) ready() (bool, error) {
+	existingCluster, err := client.loadExistingCluster()
+	if err != nil {
+		return false, err
+	}
+	if existingCluster == nil {
+		return false, fmt.Errorf("Cluster %v not found", client.name)
+	}
+	return existingCluster.State == "active", nil
+}
+
+// Pending skips the resources inside a cluster until it is active, custom nodes are registered after apply
+func (client *clusterClient) Pending() (bool, error) {
+	existingCluster, err := client.loadExistingCluster()
+	if err != nil {
+		return false, err
+	}
+	state := "not created"
+	if existingCluster != nil {
+		if existingCluster.State == "active" {
+			return false, nil
+		}
+		state = existingCluster.State
+	}
+	client.logger.WithField("state", state).Warn("Skip the resources of the cluster until it is active, apply again once its nodes are registered")
+	return true, nil
+}
+
+// Outputs exposes the command to register custom nodes of a cluster declaring a rke_config
+func (client *clusterClient) Outputs() (map[string]string, error) {
+	if client.cluster.RKEConfig == nil {
+		return nil, nil
+	}
+	if client.registrationCommand == "" {
+		existingCluster, err := client.loadExistingCluster()
+		if err != nil || existingCluster == nil {
+			return nil, err
+		}
+		backendClient, err := client.backendRancherClient()
+		if err != nil {
+			return nil, err
+		}
+		collection, err := backendClient.ClusterRegistrationToken.List(&types.ListOpts{
+			Filters: map[string]interface{}{
+				"clusterId": existingCluster.ID,
+			},
+		})
+		if err != nil {
+			client.logger.WithError(err).Error("Failed to read cluster registration token list")
+			return nil, fmt.Errorf("Failed to read cluster registration token list, %v", err)
+		}
+		for _, token := range collection.Data {
+			if token.NodeCommand != "" {
+				client.registrationCommand = token.NodeCommand
+				break
+			}
+		}
+	}
+	if client.registrationCommand == "" {
+		return nil, nil
+	}
+	return map[string]string{
+		"registration_command": client.registrationCommand,
+	}, nil
+}
+
+func (client *clusterClient) Data() (clusterModel.Cluster, error) {
+	return client.cluster, nil
+}
+
+func (client *clusterClient) SetData(cluster clusterModel.Cluster) error {
+	client.name = cluster.Metadata.Name
+	client.cluster = cluster
+	return nil
+}
+
+// provisioningHash covers only the declared RKE config and node pools, the other parts of the
+// cluster descriptor are handled by their own clients
+func (client *clusterClient) provisioningHash() string {
+	return hashOf(struct {
+		RKEConfig *clusterModel.RKEConfig
+		NodePools []clusterModel.NodePool
+	}{
+		RKEConfig: client.cluster.RKEConfig,
+		NodePools: client.cluster.NodePools,
+	})
+}
+
+// upgradeNodePools creates the declared node pools missing in the cluster and upgrades the existing ones,
+// node pools not declared are kept
+func (client *clusterClient) upgradeNodePools(clusterID string, dryRun bool) error {
+	if len(client.cluster.NodePools) == 0 {
+		return nil
+	}
+	backendClient, err := client.backendRancherClient()
+	if err != nil {
+		return err
+	}
+	collection, err := backendClient.NodePool.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"clusterId": clusterID,
+		},
+	})
+	if err != nil {
+		client.logger.WithError(err).Error("Failed to read node pool list")
+		return fmt.Errorf("Failed to read node pool list, %v", err)
+	}
+	existingNodePools := make(map[string]backendRancherClient.NodePool, len(collection.Data))
+	for _, existingNodePool := range collection.Data {
+		existingNodePools[existingNodePool.HostnamePrefix] = existingNodePool
+	}
+	for _, nodePool := range client.cluster.NodePools {
+		nodeTemplateID, err := client.nodeTemplateID(nodePool.NodeTemplate)
+		if err != nil {
+			return err
+		}
+		logger := client.logger.WithField("node_pool", nodePool.HostnamePrefix)
+		backendNodePool, exists := existingNodePools[nodePool.HostnamePrefix]
+		if !exists {
+			backendNodePool = backendRancherClient.NodePool{ClusterID: clusterID}
+		}
+		applyNodePool(nodePool, nodeTemplateID, &backendNodePool)
+		switch {
+		case dryRun && exists:
+			logger.WithField("object", backendNodePool).Info("Do Dry-Run Upgrade")
+		case dryRun:
+			logger.WithField("object", backendNodePool).Info("Do Dry-Run Create")
+		case exists:
+			logger.Info("Upgrade node pool")
+			_, err = backendClient.NodePool.Replace(&backendNodePool)
+		default:
+			logger.Info("Create new node pool")
+			_, err = backendClient.NodePool.Create(&backendNodePool)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeTemplateID resolves the name of a node template, IDs like cattle-global-nt:nt-abcde are used as they are
+func (client *clusterClient) nodeTemplateID(nodeTemplate string) (string, error) {
+	if strings.Contains(nodeTemplate, ":") {
+		return nodeTemplate, nil
+	}
+	backendClient, err := client.backendRancherClient()
+	if err != nil {
+		return "", err
+	}
+	collection, err := backendClient.NodeTemplate.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": nodeTemplate,
+		},
+	})
+	if err != nil {
+		client.logger.WithError(err).Error("Failed to read node template list")
+		return "", fmt.Errorf("Failed to read node template list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Name == nodeTemplate {
+			return item.ID, nil
+		}
+	}
+	return "", fmt.Errorf("Node template %v not found", nodeTemplate)
+}
+
+func (client *clusterClient) loadExistingCluster() (*backendRancherClient.Cluster, error) {
+	backendClient, err := client.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.Cluster.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": client.name,
+		},
+	})
+	if err != nil {
+		client.logger.WithError(err).Error("Failed to read cluster list")
+		return nil, fmt.Errorf("Failed to read cluster list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
+
+// applyRKEConfig sets the declared options on the backend RKE config, options not declared keep the value chosen by rancher
+func applyRKEConfig(rkeConfig clusterModel.RKEConfig, backendConfig *backendRancherClient.RancherKubernetesEngineConfig) {
+	if rkeConfig.KubernetesVersion != "" {
+		backendConfig.Version = rkeConfig.KubernetesVersion
+	}
+	if rkeConfig.IgnoreDockerVersion != nil {
+		backendConfig.IgnoreDockerVersion = *rkeConfig.IgnoreDockerVersion
+	}
+	if backendConfig.Network == nil {
+		backendConfig.Network = &backendRancherClient.NetworkConfig{}
+	}
+	if rkeConfig.NetworkPlugin != "" {
+		backendConfig.Network.Plugin = rkeConfig.NetworkPlugin
+	}
+	if rkeConfig.NetworkOptions != nil {
+		backendConfig.Network.Options = rkeConfig.NetworkOptions
+	}
+
+	if backendConfig.Services == nil {
+		backendConfig.Services = &backendRancherClient.RKEConfigServices{}
+	}
+	services := backendConfig.Services
+	if services.Etcd == nil {
+		services.Etcd = &backendRancherClient.ETCDService{}
+	}
+	applyServiceOptions(rkeConfig.Services.Etcd, &services.Etcd.ExtraArgs, &services.Etcd.ExtraBinds, &services.Etcd.ExtraEnv)
+	if services.KubeAPI == nil {
+		services.KubeAPI = &backendRancherClient.KubeAPIService{}
+	}
+	kubeAPI := rkeConfig.Services.KubeAPI
+	applyServiceOptions(kubeAPI.RKEServiceOptions, &services.KubeAPI.ExtraArgs, &services.KubeAPI.ExtraBinds, &services.KubeAPI.ExtraEnv)
+	if kubeAPI.ServiceClusterIPRange != "" {
+		services.KubeAPI.ServiceClusterIPRange = kubeAPI.ServiceClusterIPRange
+	}
+	if kubeAPI.ServiceNodePortRange != "" {
+		services.KubeAPI.ServiceNodePortRange = kubeAPI.ServiceNodePortRange
+	}
+	if kubeAPI.PodSecurityPolicy != nil {
+		services.KubeAPI.PodSecurityPolicy = *kubeAPI.PodSecurityPolicy
+	}
+	if services.KubeController == nil {
+		services.KubeController = &backendRancherClient.KubeControllerService{}
+	}
+	applyServiceOptions(rkeConfig.Services.KubeController, &services.KubeController.ExtraArgs, &services.KubeController.ExtraBinds, &services.KubeController.ExtraEnv)
+	if services.Kubelet == nil {
+		services.Kubelet = &backendRancherClient.KubeletService{}
+	}
+	kubelet := rkeConfig.Services.Kubelet
+	applyServiceOptions(kubelet.RKEServiceOptions, &services.Kubelet.ExtraArgs, &services.Kubelet.ExtraBinds, &services.Kubelet.ExtraEnv)
+	if kubelet.ClusterDomain != "" {
+		services.Kubelet.ClusterDomain = kubelet.ClusterDomain
+	}
+	if kubelet.ClusterDNSServer != "" {
+		services.Kubelet.ClusterDNSServer = kubelet.ClusterDNSServer
+	}
+	if kubelet.FailSwapOn != nil {
+		services.Kubelet.FailSwapOn = *kubelet.FailSwapOn
+	}
+	if services.Kubeproxy == nil {
+		services.Kubeproxy = &backendRancherClient.KubeproxyService{}
+	}
+	applyServiceOptions(rkeConfig.Services.Kubeproxy, &services.Kubeproxy.ExtraArgs, &services.Kubeproxy.ExtraBinds, &services.Kubeproxy.ExtraEnv)
+	if services.Scheduler == nil {
+		services.Scheduler = &backendRancherClient.SchedulerService{}
+	}
+	applyServiceOptions(rkeConfig.Services.Scheduler, &services.Scheduler.ExtraArgs, &services.Scheduler.ExtraBinds, &services.Scheduler.ExtraEnv)
+}
+
+func applyServiceOptions(options clusterModel.RKEServiceOptions, extraArgs *map[string]string, extraBinds, extraEnv *[]string) {
+	if options.ExtraArgs != nil {
+		*extraArgs = options.ExtraArgs
+	}
+	if options.ExtraBinds != nil {
+		*extraBinds = options.ExtraBinds
+	}
+	if options.ExtraEnv != nil {
+		*extraEnv = options.ExtraEnv
+	}
+}
+
+// applyNodePool sets the declared fields of the node pool on the backend object, a pool has at least one node
+func applyNodePool(nodePool clusterModel.NodePool, nodeTemplateID string, backendNodePool *backendRancherClient.NodePool) {
+	backendNodePool.HostnamePrefix = nodePool.HostnamePrefix
+	backendNodePool.NodeTemplateID = nodeTemplateID
+	backendNodePool.Quantity = nodePool.Quantity
+	if backendNodePool.Quantity < 1 {
+		backendNodePool.Quantity = 1
+	}
+	backendNodePool.ControlPlane = nodePool.ControlPlane
+	backendNodePool.Etcd = nodePool.Etcd
+	backendNodePool.Worker = nodePool.Worker
+	backendNodePool.NodeLabels = nodePool.NodeLabels
+}
 func (client *clusterClient) Project(name string) (ProjectClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.projectClients[name]; exists {
 		return cache, nil
 	}
-	project, err := newProjectClient(name, client.config, client, client.logger)
+	project, err := newProjectClient(name, client.rancherConfig, client, client.logger)
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +541,8 @@ func (client *clusterClient) Projects() ([]ProjectClient, error) {
 	return result, nil
 }
 func (client *clusterClient) StorageClass(name string) (StorageClassClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.storageClasses[name]; exists {
 		return cache, nil
 	}
@@ -179,6 +577,8 @@ func (client *clusterClient) StorageClasses() ([]StorageClassClient, error) {
 	return result, nil
 }
 func (client *clusterClient) PersistentVolume(name string) (PersistentVolumeClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.persistentVolumes[name]; exists {
 		return cache, nil
 	}
@@ -226,6 +626,8 @@ func (client *clusterClient) Namespace(name, projectName string) (NamespaceClien
 		}
 		logger = logger.WithField("project_name", projectName)
 	}
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.namespaces[name]; exists {
 		if cache.projectName != projectName {
 			return nil, fmt.Errorf("Namespace %s is part of project: %s", name, cache.projectName)
@@ -287,8 +689,25 @@ func (client *clusterClient) backendClusterClient() (*backendClusterClient.Clien
 	}
 	return client._backendClusterClient, nil
 }
+func (client *clusterClient) backendKubernetesClient() (*kubernetesClient, error) {
+	clusterID, err := client.ID()
+	if err != nil {
+		return nil, err
+	}
+	client.initLock.Lock()
+	defer client.initLock.Unlock()
+	if client._backendKubernetesClient == nil {
+		client._backendKubernetesClient, err = createKubernetesClient(client.rancherConfig, clusterID)
+	}
+	return client._backendKubernetesClient, err
+}
+func (client *clusterClient) config() RancherConfig {
+	return client.rancherConfig
+}
 
 func (client *clusterClient) Catalog(catalogName string) (CatalogClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.catalogClients[catalogName]; exists {
 		return cache, nil
 	}
@@ -323,3 +742,51 @@ func (client *clusterClient) Catalogs() ([]CatalogClient, error) {
 	}
 	return result, nil
 }
+
+func (client *clusterClient) ClusterRoleTemplateBinding(principalID string) (ClusterRoleTemplateBindingClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.memberClients[principalID]; exists {
+		return cache, nil
+	}
+	member, err := newClusterRoleTemplateBindingClient(principalID, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.memberClients[principalID] = member
+	return member, nil
+}
+
+func (client *clusterClient) ClusterRoleTemplateBindings() ([]ClusterRoleTemplateBindingClient, error) {
+	backendRancherClient, err := client.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	clusterID, err := client.ID()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := backendRancherClient.ClusterRoleTemplateBinding.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"clusterId": clusterID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ClusterRoleTemplateBindingClient, 0)
+	listed := make(map[string]bool)
+	for _, backendBinding := range collection.Data {
+		principalID := clusterBindingPrincipalID(backendBinding)
+		if principalID == "" || listed[principalID] {
+			continue
+		}
+		listed[principalID] = true
+		member, err := client.ClusterRoleTemplateBinding(principalID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, member)
+	}
+	return result, nil
+}
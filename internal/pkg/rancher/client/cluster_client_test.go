@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	clusterModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/model"
 	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
 	"github.com/rancher/norman/types"
 	backendClusterClient "github.com/rancher/types/client/cluster/v3"
@@ -386,6 +387,323 @@ func Test_clusterClient_Namespaces(t *testing.T) {
 	}
 }
 
+func Test_clusterClient_Create(t *testing.T) {
+	tests := []struct {
+		name               string
+		cluster            clusterModel.Cluster
+		dryRun             bool
+		wantedChanged      bool
+		wantedNodePools    []managementClient.NodePool
+		wantedRegistration string
+		wantErr            bool
+		wantedErr          string
+	}{
+		{
+			name:               "Custom_Nodes",
+			cluster:            rkeCluster(),
+			wantedChanged:      true,
+			wantedNodePools:    []managementClient.NodePool{},
+			wantedRegistration: "sudo docker run rancher/rancher-agent",
+		},
+		{
+			name: "Node_Pool",
+			cluster: func() clusterModel.Cluster {
+				cluster := rkeCluster()
+				cluster.NodePools = []clusterModel.NodePool{
+					{HostnamePrefix: "worker-", NodeTemplate: "small", Worker: true},
+				}
+				return cluster
+			}(),
+			wantedChanged: true,
+			wantedNodePools: []managementClient.NodePool{
+				{ClusterID: simpleClusterID, HostnamePrefix: "worker-", NodeTemplateID: "cattle-global-nt:nt-small", Quantity: 1, Worker: true},
+			},
+			wantedRegistration: "sudo docker run rancher/rancher-agent",
+		},
+		{
+			name:            "Dry_Run",
+			cluster:         rkeCluster(),
+			dryRun:          true,
+			wantedChanged:   true,
+			wantedNodePools: []managementClient.NodePool{},
+		},
+		{
+			name:      "No_RKE_Config",
+			cluster:   clusterModel.Cluster{Metadata: clusterModel.ClusterMetadata{Name: simpleClusterName}},
+			wantErr:   true,
+			wantedErr: "Cluster simple-cluster not found, declare a rke_config to create it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			clusterOperationsStub := stubs.CreateClusterOperationsStub(t)
+			clusterOperationsStub.DoCreate = func(cluster *managementClient.Cluster) (*managementClient.Cluster, error) {
+				assert.Equals(t, simpleClusterName, cluster.Name)
+				assert.Equals(t, "v1.17.4-rancher1-2", cluster.RancherKubernetesEngineConfig.Version)
+				assert.Equals(t, "canal", cluster.RancherKubernetesEngineConfig.Network.Plugin)
+				assert.Equals(t, "10.43.0.0/16", cluster.RancherKubernetesEngineConfig.Services.KubeAPI.ServiceClusterIPRange)
+				cluster.ID = simpleClusterID
+				return cluster, nil
+			}
+			testClients.ManagementClient.Cluster = clusterOperationsStub
+			createdNodePools := make([]managementClient.NodePool, 0)
+			nodePoolOperationsStub := stubs.CreateNodePoolOperationsStub(t)
+			nodePoolOperationsStub.DoList = func(opts *types.ListOpts) (*managementClient.NodePoolCollection, error) {
+				assert.Equals(t, simpleClusterID, opts.Filters["clusterId"])
+				return &managementClient.NodePoolCollection{}, nil
+			}
+			nodePoolOperationsStub.DoCreate = func(nodePool *managementClient.NodePool) (*managementClient.NodePool, error) {
+				createdNodePools = append(createdNodePools, *nodePool)
+				return nodePool, nil
+			}
+			testClients.ManagementClient.NodePool = nodePoolOperationsStub
+			nodeTemplateOperationsStub := stubs.CreateNodeTemplateOperationsStub(t)
+			nodeTemplateOperationsStub.DoList = func(opts *types.ListOpts) (*managementClient.NodeTemplateCollection, error) {
+				return &managementClient.NodeTemplateCollection{
+					Data: []managementClient.NodeTemplate{
+						{Resource: types.Resource{ID: "cattle-global-nt:nt-small"}, Name: "small"},
+					},
+				}, nil
+			}
+			testClients.ManagementClient.NodeTemplate = nodeTemplateOperationsStub
+			registrationTokenOperationsStub := stubs.CreateClusterRegistrationTokenOperationsStub(t)
+			registrationTokenOperationsStub.DoCreate = func(token *managementClient.ClusterRegistrationToken) (*managementClient.ClusterRegistrationToken, error) {
+				assert.Equals(t, simpleClusterID, token.ClusterID)
+				token.NodeCommand = "sudo docker run rancher/rancher-agent"
+				return token, nil
+			}
+			testClients.ManagementClient.ClusterRegistrationToken = registrationTokenOperationsStub
+			rancherClient := simpleRancherClient()
+			rancherClient._backendRancherClient = testClients.ManagementClient
+			client := simpleClusterClient()
+			client.rancherClient = rancherClient
+			assert.Ok(t, client.SetData(tt.cluster))
+
+			changed, err := client.Create(tt.dryRun)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Equals(t, tt.wantedErr, err.Error())
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wantedChanged, changed)
+				assert.Equals(t, tt.wantedNodePools, createdNodePools)
+				assert.Equals(t, tt.wantedRegistration, client.registrationCommand)
+			}
+		})
+	}
+}
+
+func Test_clusterClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name            string
+		existing        managementClient.Cluster
+		cluster         clusterModel.Cluster
+		wantedChanged   bool
+		wantedVersion   string
+		wantedMTU       int64
+		wantedPSP       bool
+		wantedSwap      bool
+		wantedNodePools []managementClient.NodePool
+		wantErr         bool
+		wantedErr       string
+	}{
+		{
+			name: "Changed",
+			existing: managementClient.Cluster{
+				Resource: types.Resource{ID: simpleClusterID},
+				Name:     simpleClusterName,
+				RancherKubernetesEngineConfig: &managementClient.RancherKubernetesEngineConfig{
+					Version: "v1.16.8-rancher1-2",
+					Network: &managementClient.NetworkConfig{Plugin: "canal", MTU: 1450},
+					Services: &managementClient.RKEConfigServices{
+						KubeAPI: &managementClient.KubeAPIService{PodSecurityPolicy: true},
+						Kubelet: &managementClient.KubeletService{FailSwapOn: true},
+					},
+				},
+			},
+			cluster: func() clusterModel.Cluster {
+				failSwapOn := false
+				cluster := rkeCluster()
+				cluster.RKEConfig.Services.Kubelet.FailSwapOn = &failSwapOn
+				cluster.NodePools = []clusterModel.NodePool{
+					{HostnamePrefix: "worker-", NodeTemplate: "cattle-global-nt:nt-large", Quantity: 3, Worker: true},
+				}
+				return cluster
+			}(),
+			wantedChanged: true,
+			wantedVersion: "v1.17.4-rancher1-2",
+			wantedMTU:     1450,
+			wantedPSP:     true,
+			wantedSwap:    false,
+			wantedNodePools: []managementClient.NodePool{
+				{ClusterID: simpleClusterID, HostnamePrefix: "worker-", NodeTemplateID: "cattle-global-nt:nt-large", Quantity: 3, Worker: true},
+			},
+		},
+		{
+			name: "Unchanged",
+			existing: managementClient.Cluster{
+				Resource: types.Resource{ID: simpleClusterID},
+				Name:     simpleClusterName,
+				Labels: map[string]string{
+					"cattlectl.io/hash": func() string {
+						client := simpleClusterClient()
+						client.SetData(rkeCluster())
+						return client.provisioningHash()
+					}(),
+				},
+				RancherKubernetesEngineConfig: &managementClient.RancherKubernetesEngineConfig{},
+			},
+			cluster:         rkeCluster(),
+			wantedNodePools: []managementClient.NodePool{},
+		},
+		{
+			name: "Imported_Cluster",
+			existing: managementClient.Cluster{
+				Resource: types.Resource{ID: simpleClusterID},
+				Name:     simpleClusterName,
+			},
+			cluster:   rkeCluster(),
+			wantErr:   true,
+			wantedErr: "Cluster simple-cluster is not a RKE cluster",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			replaced := &managementClient.Cluster{}
+			clusterOperationsStub := stubs.CreateClusterOperationsStub(t)
+			clusterOperationsStub.DoList = func(opts *types.ListOpts) (*managementClient.ClusterCollection, error) {
+				return &managementClient.ClusterCollection{Data: []managementClient.Cluster{tt.existing}}, nil
+			}
+			clusterOperationsStub.DoReplace = func(cluster *managementClient.Cluster) (*managementClient.Cluster, error) {
+				replaced = cluster
+				return cluster, nil
+			}
+			testClients.ManagementClient.Cluster = clusterOperationsStub
+			replacedNodePools := make([]managementClient.NodePool, 0)
+			nodePoolOperationsStub := stubs.CreateNodePoolOperationsStub(t)
+			nodePoolOperationsStub.DoList = func(opts *types.ListOpts) (*managementClient.NodePoolCollection, error) {
+				return &managementClient.NodePoolCollection{
+					Data: []managementClient.NodePool{
+						{ClusterID: simpleClusterID, HostnamePrefix: "worker-", NodeTemplateID: "cattle-global-nt:nt-small", Quantity: 1},
+					},
+				}, nil
+			}
+			nodePoolOperationsStub.DoReplace = func(nodePool *managementClient.NodePool) (*managementClient.NodePool, error) {
+				replacedNodePools = append(replacedNodePools, *nodePool)
+				return nodePool, nil
+			}
+			testClients.ManagementClient.NodePool = nodePoolOperationsStub
+			rancherClient := simpleRancherClient()
+			rancherClient._backendRancherClient = testClients.ManagementClient
+			client := simpleClusterClient()
+			client.rancherClient = rancherClient
+			assert.Ok(t, client.SetData(tt.cluster))
+
+			changed, err := client.Upgrade(false)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Equals(t, tt.wantedErr, err.Error())
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wantedChanged, changed)
+				assert.Equals(t, tt.wantedNodePools, replacedNodePools)
+				if tt.wantedChanged {
+					assert.Equals(t, tt.wantedVersion, replaced.RancherKubernetesEngineConfig.Version)
+					assert.Equals(t, tt.wantedMTU, replaced.RancherKubernetesEngineConfig.Network.MTU)
+					assert.Equals(t, tt.wantedPSP, replaced.RancherKubernetesEngineConfig.Services.KubeAPI.PodSecurityPolicy)
+					assert.Equals(t, tt.wantedSwap, replaced.RancherKubernetesEngineConfig.Services.Kubelet.FailSwapOn)
+				}
+			}
+		})
+	}
+}
+
+func Test_clusterClient_Pending(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []managementClient.Cluster
+		wantedPending bool
+	}{
+		{
+			name:          "Active",
+			existing:      []managementClient.Cluster{{Name: simpleClusterName, State: "active"}},
+			wantedPending: false,
+		},
+		{
+			name:          "Waiting_For_Nodes",
+			existing:      []managementClient.Cluster{{Name: simpleClusterName, State: "provisioning"}},
+			wantedPending: true,
+		},
+		{
+			name:          "Not_Created",
+			existing:      []managementClient.Cluster{},
+			wantedPending: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			clusterOperationsStub := stubs.CreateClusterOperationsStub(t)
+			clusterOperationsStub.DoList = func(opts *types.ListOpts) (*managementClient.ClusterCollection, error) {
+				return &managementClient.ClusterCollection{Data: tt.existing}, nil
+			}
+			testClients.ManagementClient.Cluster = clusterOperationsStub
+			rancherClient := simpleRancherClient()
+			rancherClient._backendRancherClient = testClients.ManagementClient
+			client := simpleClusterClient()
+			client.rancherClient = rancherClient
+			assert.Ok(t, client.SetData(rkeCluster()))
+
+			pending, err := client.Pending()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedPending, pending)
+		})
+	}
+}
+
+func Test_clusterClient_Outputs(t *testing.T) {
+	testClients := stubs.CreateBackendStubs(t)
+	clusterOperationsStub := stubs.CreateClusterOperationsStub(t)
+	clusterOperationsStub.DoList = func(opts *types.ListOpts) (*managementClient.ClusterCollection, error) {
+		return &managementClient.ClusterCollection{
+			Data: []managementClient.Cluster{{Resource: types.Resource{ID: simpleClusterID}, Name: simpleClusterName}},
+		}, nil
+	}
+	testClients.ManagementClient.Cluster = clusterOperationsStub
+	registrationTokenOperationsStub := stubs.CreateClusterRegistrationTokenOperationsStub(t)
+	registrationTokenOperationsStub.DoList = func(opts *types.ListOpts) (*managementClient.ClusterRegistrationTokenCollection, error) {
+		assert.Equals(t, simpleClusterID, opts.Filters["clusterId"])
+		return &managementClient.ClusterRegistrationTokenCollection{
+			Data: []managementClient.ClusterRegistrationToken{{NodeCommand: "sudo docker run rancher/rancher-agent"}},
+		}, nil
+	}
+	testClients.ManagementClient.ClusterRegistrationToken = registrationTokenOperationsStub
+	rancherClient := simpleRancherClient()
+	rancherClient._backendRancherClient = testClients.ManagementClient
+	client := simpleClusterClient()
+	client.rancherClient = rancherClient
+	assert.Ok(t, client.SetData(rkeCluster()))
+
+	outputs, err := client.Outputs()
+	assert.Ok(t, err)
+	assert.Equals(t, map[string]string{"registration_command": "sudo docker run rancher/rancher-agent"}, outputs)
+}
+
+func rkeCluster() clusterModel.Cluster {
+	return clusterModel.Cluster{
+		Metadata: clusterModel.ClusterMetadata{Name: simpleClusterName},
+		RKEConfig: &clusterModel.RKEConfig{
+			KubernetesVersion: "v1.17.4-rancher1-2",
+			NetworkPlugin:     "canal",
+			Services: clusterModel.RKEServices{
+				KubeAPI: clusterModel.RKEKubeAPIService{ServiceClusterIPRange: "10.43.0.0/16"},
+			},
+		},
+	}
+}
+
 func simpleClusterClient() *clusterClient {
 	logrus.SetLevel(logrus.TraceLevel)
 	return &clusterClient{
@@ -394,7 +712,7 @@ func simpleClusterClient() *clusterClient {
 			id:     simpleClusterID,
 			logger: logrus.WithFields(logrus.Fields{}),
 		},
-		config: RancherConfig{},
+		rancherConfig: RancherConfig{},
 		projectClients: map[string]ProjectClient{
 			simpleProjectName: simpleProjectClient(),
 		},
@@ -402,6 +720,7 @@ func simpleClusterClient() *clusterClient {
 		persistentVolumes: make(map[string]PersistentVolumeClient),
 		namespaces:        make(map[string]namespaceCacheEntry),
 		catalogClients: make(map[string]CatalogClient),
+		memberClients:  make(map[string]ClusterRoleTemplateBindingClient),
 	}
 }
 
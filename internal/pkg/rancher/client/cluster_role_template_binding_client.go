@@ -0,0 +1,296 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newClusterRoleTemplateBindingClientWithData(
+	member projectModel.Member,
+	clusterClient ClusterClient,
+	logger *logrus.Entry,
+) (ClusterRoleTemplateBindingClient, error) {
+	result, err := newClusterRoleTemplateBindingClient(
+		MemberPrincipalID(member),
+		clusterClient,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(member)
+	return result, err
+}
+
+func newClusterRoleTemplateBindingClient(
+	principalID string,
+	clusterClient ClusterClient,
+	logger *logrus.Entry,
+) (ClusterRoleTemplateBindingClient, error) {
+	return &clusterRoleTemplateBindingClient{
+		resourceClient: resourceClient{
+			name:   principalID,
+			logger: logger.WithField("member_principal_id", principalID),
+		},
+		clusterClient: clusterClient,
+	}, nil
+}
+
+// clusterRoleTemplateBindingClient manages the bindings of all roles of one member
+type clusterRoleTemplateBindingClient struct {
+	resourceClient
+	member        projectModel.Member
+	clusterClient ClusterClient
+}
+
+func (client *clusterRoleTemplateBindingClient) Type() string {
+	return rancherModel.ClusterMember
+}
+
+func (client *clusterRoleTemplateBindingClient) Exists() (bool, error) {
+	bindings, err := client.loadExistingBindings()
+	if err != nil {
+		return false, err
+	}
+	if len(bindings) == 0 {
+		client.logger.Debug("Member not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *clusterRoleTemplateBindingClient) Create(dryRun bool) (changed bool, err error) {
+	client.logger.Info("Create new member")
+	for _, role := range client.member.Roles {
+		if err = client.createBinding(role, dryRun); err != nil {
+			return
+		}
+	}
+	return true, nil
+}
+
+// Upgrade binds the missing roles, with prune it also removes the bindings cattlectl created for roles no longer declared
+func (client *clusterRoleTemplateBindingClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.clusterClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	bindings, err := client.loadExistingBindings()
+	if err != nil {
+		return
+	}
+	removed, added := client.pendingBindings(bindings)
+	for _, binding := range removed {
+		client.logger.WithField("role", binding.RoleTemplateID).Info("Remove role of member")
+		changed = true
+		if dryRun {
+			client.logger.WithField("object", binding).Info("Do Dry-Run Delete")
+		} else if err = backendClient.ClusterRoleTemplateBinding.Delete(&binding); err != nil {
+			return
+		}
+	}
+	for _, role := range added {
+		client.logger.WithField("role", role).Info("Add role to member")
+		changed = true
+		if err = client.createBinding(role, dryRun); err != nil {
+			return
+		}
+	}
+	if !changed {
+		client.logger.Debug("Skip upgrade member - no changes")
+	}
+	return
+}
+
+func (client *clusterRoleTemplateBindingClient) Desired() (interface{}, error) {
+	return projectModel.Member{
+		User:  client.member.User,
+		Group: client.member.Group,
+		Roles: sortedRoles(client.member.Roles),
+	}, nil
+}
+
+func (client *clusterRoleTemplateBindingClient) Existing() (interface{}, error) {
+	bindings, err := client.loadExistingBindings()
+	if err != nil || len(bindings) == 0 {
+		return nil, err
+	}
+	existing := projectModel.Member{
+		User:  client.member.User,
+		Group: client.member.Group,
+	}
+	for _, binding := range bindings {
+		existing.Roles = append(existing.Roles, binding.RoleTemplateID)
+	}
+	existing.Roles = sortedRoles(existing.Roles)
+	return existing, nil
+}
+
+func (client *clusterRoleTemplateBindingClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *clusterRoleTemplateBindingClient) Unchanged() (bool, error) {
+	bindings, err := client.loadExistingBindings()
+	if err != nil || len(bindings) == 0 {
+		return false, err
+	}
+	removed, added := client.pendingBindings(bindings)
+	return len(removed) == 0 && len(added) == 0, nil
+}
+
+// Delete removes the bindings cattlectl created, roles bound by others are kept
+func (client *clusterRoleTemplateBindingClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.clusterClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	bindings, err := client.loadExistingBindings()
+	if err != nil {
+		return
+	}
+	if len(bindings) == 0 {
+		return changed, fmt.Errorf("Member %v not found", client.name)
+	}
+	for _, binding := range bindings {
+		if !isOwned(binding.Labels) {
+			client.logger.WithField("role", binding.RoleTemplateID).Info("Keep role of member - not bound by cattlectl")
+			continue
+		}
+		changed = true
+		if dryRun {
+			client.logger.WithField("object", binding).Info("Do Dry-Run Delete")
+		} else if err = backendClient.ClusterRoleTemplateBinding.Delete(&binding); err != nil {
+			return
+		}
+	}
+	return changed, nil
+}
+
+// Owned is true if any role of the member was bound by cattlectl
+func (client *clusterRoleTemplateBindingClient) Owned() (bool, error) {
+	bindings, err := client.loadExistingBindings()
+	if err != nil {
+		return false, err
+	}
+	for _, binding := range bindings {
+		if isOwned(binding.Labels) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (client *clusterRoleTemplateBindingClient) Data() (projectModel.Member, error) {
+	return client.member, nil
+}
+
+func (client *clusterRoleTemplateBindingClient) SetData(member projectModel.Member) error {
+	client.name = MemberPrincipalID(member)
+	client.member = member
+	return nil
+}
+
+func (client *clusterRoleTemplateBindingClient) createBinding(role string, dryRun bool) (err error) {
+	backendClient, err := client.clusterClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	clusterID, err := client.clusterClient.ID()
+	if err != nil {
+		return
+	}
+	newBinding := backendRancherClient.ClusterRoleTemplateBinding{
+		ClusterID:      clusterID,
+		RoleTemplateID: role,
+		Labels: map[string]string{
+			"cattlectl.io/hash": hashOf(client.member),
+		},
+	}
+	if client.member.Group != "" {
+		newBinding.GroupPrincipalID = client.name
+	} else {
+		newBinding.UserPrincipalID = client.name
+	}
+
+	if dryRun {
+		client.logger.WithField("object", newBinding).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.ClusterRoleTemplateBinding.Create(&newBinding)
+	}
+	return
+}
+
+// pendingBindings are the bindings Upgrade removes and the roles it binds
+func (client *clusterRoleTemplateBindingClient) pendingBindings(bindings []backendRancherClient.ClusterRoleTemplateBinding) (removed []backendRancherClient.ClusterRoleTemplateBinding, added []string) {
+	declared := make(map[string]bool)
+	for _, role := range client.member.Roles {
+		declared[role] = true
+	}
+	bound := make(map[string]bool)
+	for _, binding := range bindings {
+		if declared[binding.RoleTemplateID] {
+			bound[binding.RoleTemplateID] = true
+		} else if client.clusterClient.config().Prune && isOwned(binding.Labels) {
+			removed = append(removed, binding)
+		}
+	}
+	for _, role := range client.member.Roles {
+		if !bound[role] {
+			added = append(added, role)
+		}
+	}
+	return
+}
+
+func (client *clusterRoleTemplateBindingClient) loadExistingBindings() (bindings []backendRancherClient.ClusterRoleTemplateBinding, err error) {
+	backendClient, err := client.clusterClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	clusterID, err := client.clusterClient.ID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.ClusterRoleTemplateBinding.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"clusterId": clusterID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read cluster role template binding list")
+		err = fmt.Errorf("Failed to read cluster role template binding list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if clusterBindingPrincipalID(item) == client.name {
+			bindings = append(bindings, item)
+		}
+	}
+	return
+}
+
+// clusterBindingPrincipalID is the principal of the user or group bound by a cluster binding
+func clusterBindingPrincipalID(binding backendRancherClient.ClusterRoleTemplateBinding) string {
+	return principalIDOf(binding.GroupPrincipalID, binding.UserPrincipalID, binding.UserID)
+}
@@ -0,0 +1,183 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_clusterRoleTemplateBindingClient_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  projectModel.Member
+		dryRun  bool
+		wantNew []backendRancherClient.ClusterRoleTemplateBinding
+	}{
+		{
+			name:   "user",
+			member: projectModel.Member{User: "u-abcde", Roles: []string{"cluster-member", "nodes-view"}},
+			wantNew: []backendRancherClient.ClusterRoleTemplateBinding{
+				{ClusterID: simpleClusterID, RoleTemplateID: "cluster-member", UserPrincipalID: "local://u-abcde"},
+				{ClusterID: simpleClusterID, RoleTemplateID: "nodes-view", UserPrincipalID: "local://u-abcde"},
+			},
+		},
+		{
+			name:   "group",
+			member: projectModel.Member{Group: "github_team://4711", Roles: []string{"projects-view"}},
+			wantNew: []backendRancherClient.ClusterRoleTemplateBinding{
+				{ClusterID: simpleClusterID, RoleTemplateID: "projects-view", GroupPrincipalID: "github_team://4711"},
+			},
+		},
+		{
+			name:    "dry_run",
+			member:  projectModel.Member{Group: "github_team://4711", Roles: []string{"projects-view"}},
+			dryRun:  true,
+			wantNew: []backendRancherClient.ClusterRoleTemplateBinding{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, stub := testClusterRoleTemplateBindingClient(t, tt.member, nil)
+			created := make([]backendRancherClient.ClusterRoleTemplateBinding, 0)
+			stub.DoCreate = func(binding *backendRancherClient.ClusterRoleTemplateBinding) (*backendRancherClient.ClusterRoleTemplateBinding, error) {
+				assert.Equals(t, hashOf(tt.member), binding.Labels["cattlectl.io/hash"])
+				binding.Labels = nil
+				created = append(created, *binding)
+				return binding, nil
+			}
+			changed, err := client.Create(tt.dryRun)
+			assert.Ok(t, err)
+			assert.Assert(t, changed, "Create should report a change")
+			assert.Equals(t, tt.wantNew, created)
+		})
+	}
+}
+
+func Test_clusterRoleTemplateBindingClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name        string
+		roles       []string
+		prune       bool
+		wantChanged bool
+		wantCreated []string
+		wantDeleted []string
+	}{
+		{
+			name:        "unchanged",
+			roles:       []string{"cluster-member", "nodes-view", "projects-view"},
+			prune:       true,
+			wantChanged: false,
+			wantCreated: []string{},
+			wantDeleted: []string{},
+		},
+		{
+			name:        "change_roles",
+			roles:       []string{"cluster-member", "storage-manage"},
+			prune:       true,
+			wantChanged: true,
+			wantCreated: []string{"storage-manage"},
+			wantDeleted: []string{"projects-view"},
+		},
+		{
+			name:        "change_roles_without_prune",
+			roles:       []string{"cluster-member", "storage-manage"},
+			wantChanged: true,
+			wantCreated: []string{"storage-manage"},
+			wantDeleted: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, stub := testClusterRoleTemplateBindingClient(
+				t,
+				projectModel.Member{User: "u-abcde", Roles: tt.roles},
+				[]backendRancherClient.ClusterRoleTemplateBinding{
+					{ClusterID: simpleClusterID, RoleTemplateID: "cluster-member", UserID: "u-abcde"},
+					{ClusterID: simpleClusterID, RoleTemplateID: "nodes-view", UserPrincipalID: "local://u-abcde"},
+					{ClusterID: simpleClusterID, RoleTemplateID: "projects-view", UserPrincipalID: "local://u-abcde", Labels: map[string]string{"cattlectl.io/hash": "some-hash"}},
+					{ClusterID: simpleClusterID, RoleTemplateID: "cluster-owner", UserPrincipalID: "local://u-other"},
+				},
+			)
+			client.clusterClient.(*clusterClient).rancherConfig.Prune = tt.prune
+			created := make([]string, 0)
+			deleted := make([]string, 0)
+			stub.DoCreate = func(binding *backendRancherClient.ClusterRoleTemplateBinding) (*backendRancherClient.ClusterRoleTemplateBinding, error) {
+				created = append(created, binding.RoleTemplateID)
+				return binding, nil
+			}
+			stub.DoDelete = func(binding *backendRancherClient.ClusterRoleTemplateBinding) error {
+				deleted = append(deleted, binding.RoleTemplateID)
+				return nil
+			}
+			changed, err := client.Upgrade(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantChanged, changed)
+			assert.Equals(t, tt.wantCreated, created)
+			assert.Equals(t, tt.wantDeleted, deleted)
+		})
+	}
+}
+
+func Test_clusterRoleTemplateBindingClient_Delete(t *testing.T) {
+	client, stub := testClusterRoleTemplateBindingClient(
+		t,
+		projectModel.Member{Group: "github_team://4711"},
+		[]backendRancherClient.ClusterRoleTemplateBinding{
+			{ClusterID: simpleClusterID, RoleTemplateID: "projects-view", GroupPrincipalID: "github_team://4711", Labels: map[string]string{"cattlectl.io/hash": "some-hash"}},
+			{ClusterID: simpleClusterID, RoleTemplateID: "nodes-view", GroupPrincipalID: "github_team://4711"},
+			{ClusterID: simpleClusterID, RoleTemplateID: "cluster-owner", UserPrincipalID: "local://u-other"},
+		},
+	)
+	deleted := make([]string, 0)
+	stub.DoDelete = func(binding *backendRancherClient.ClusterRoleTemplateBinding) error {
+		deleted = append(deleted, binding.RoleTemplateID)
+		return nil
+	}
+	owned, err := client.Owned()
+	assert.Ok(t, err)
+	assert.Assert(t, owned, "Member should be owned")
+	changed, err := client.Delete(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Delete should report a change")
+	assert.Equals(t, []string{"projects-view"}, deleted)
+}
+
+func testClusterRoleTemplateBindingClient(t *testing.T, member projectModel.Member, existing []backendRancherClient.ClusterRoleTemplateBinding) (*clusterRoleTemplateBindingClient, *stubs.ClusterRoleTemplateBindingOperationsStub) {
+	testClients := stubs.CreateBackendStubs(t)
+	bindingOperationsStub := stubs.CreateClusterRoleTemplateBindingOperationsStub(t)
+	bindingOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.ClusterRoleTemplateBindingCollection, error) {
+		assert.Equals(t, map[string]interface{}{"clusterId": simpleClusterID}, opts.Filters)
+		return &backendRancherClient.ClusterRoleTemplateBindingCollection{Data: existing}, nil
+	}
+	testClients.ManagementClient.ClusterRoleTemplateBinding = bindingOperationsStub
+	rancherClient := simpleRancherClient()
+	rancherClient._backendRancherClient = testClients.ManagementClient
+	clusterClient := simpleClusterClient()
+	clusterClient.rancherClient = rancherClient
+	result, err := newClusterRoleTemplateBindingClientWithData(
+		member,
+		clusterClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	return result.(*clusterRoleTemplateBindingClient), bindingOperationsStub
+}
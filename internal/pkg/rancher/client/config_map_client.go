@@ -62,6 +62,7 @@ func newConfigMapClient(
 
 type configMapClient struct {
 	namespacedResourceClient
+	projectDeclaration
 	configMap projectModel.ConfigMap
 }
 
@@ -111,11 +112,9 @@ func (client *configMapClient) Create(dryRun bool) (changed bool, err error) {
 		return changed, fmt.Errorf("Failed to read namespace ID, %v", err)
 	}
 	client.logger.Info("Create new ConfigMap")
-	labels := make(map[string]string)
-	labels["cattlectl.io/hash"] = hashOf(client.configMap)
 	newConfigMap := &backendProjectClient.ConfigMap{
 		Name:        client.configMap.Name,
-		Labels:      labels,
+		Labels:      client.desiredLabels(nil),
 		Data:        client.configMap.Data,
 		NamespaceId: namespaceID,
 		ProjectID:   projectID,
@@ -153,12 +152,12 @@ func (client *configMapClient) Upgrade(dryRun bool) (changed bool, err error) {
 		return changed, fmt.Errorf("ConfigMap %v not found", client.name)
 	}
 	existingConfigMap := collection.Data[0]
-	if isConfigMapUnchanged(existingConfigMap, client.configMap) {
+	if isConfigMapUnchanged(existingConfigMap, client.configMap) && client.hasProjectLabel(existingConfigMap.Labels) {
 		client.logger.Debug("Skip upgrade configMap - no changes")
 		return
 	}
 	client.logger.Info("Upgrade ConfigMap")
-	existingConfigMap.Labels["cattlectl.io/hash"] = hashOf(client.configMap)
+	existingConfigMap.Labels = client.desiredLabels(existingConfigMap.Labels)
 	existingConfigMap.Data = client.configMap.Data
 
 	if dryRun {
@@ -169,6 +168,63 @@ func (client *configMapClient) Upgrade(dryRun bool) (changed bool, err error) {
 	return err == nil, err
 }
 
+func (client *configMapClient) Desired() (interface{}, error) {
+	return backendProjectClient.ConfigMap{
+		Name:   client.configMap.Name,
+		Labels: client.desiredLabels(nil),
+		Data:   client.configMap.Data,
+	}, nil
+}
+
+func (client *configMapClient) Existing() (interface{}, error) {
+	existingConfigMap, err := client.loadExistingConfigMap()
+	if err != nil || existingConfigMap == nil {
+		return nil, err
+	}
+	return *existingConfigMap, nil
+}
+
+func (client *configMapClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *configMapClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingConfigMap()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isConfigMapUnchanged(*existing, client.configMap) && client.hasProjectLabel(existing.Labels), nil
+}
+
+func (client *configMapClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingConfigMap, err := client.loadExistingConfigMap()
+	if err != nil {
+		return
+	}
+	if existingConfigMap == nil {
+		return changed, fmt.Errorf("ConfigMap %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingConfigMap).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.ConfigMap.Delete(existingConfigMap)
+	}
+	return err == nil, err
+}
+
+func (client *configMapClient) Owned() (bool, error) {
+	existingConfigMap, err := client.loadExistingConfigMap()
+	if err != nil || existingConfigMap == nil {
+		return false, err
+	}
+	return isDeclaredByProject(existingConfigMap.Labels), nil
+}
+
 func (client *configMapClient) Data() (projectModel.ConfigMap, error) {
 	return client.configMap, nil
 }
@@ -179,6 +235,41 @@ func (client *configMapClient) SetData(configMap projectModel.ConfigMap) error {
 	return nil
 }
 
+func (client *configMapClient) loadExistingConfigMap() (existingConfigMap *backendProjectClient.ConfigMap, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.ConfigMap.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read configMap list")
+		err = fmt.Errorf("Failed to read configMap list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			existingConfigMap = &item
+			return
+		}
+	}
+	return
+}
+
+// desiredLabels adds the labels of cattlectl to the given labels
+func (client *configMapClient) desiredLabels(labels map[string]string) map[string]string {
+	return client.withProjectLabel(withHashLabel(labels, hashOf(client.configMap)))
+}
+
 func isConfigMapUnchanged(existingConfigMap backendProjectClient.ConfigMap, configMap projectModel.ConfigMap) bool {
 	hash, hashExists := existingConfigMap.Labels["cattlectl.io/hash"]
 	if !hashExists {
@@ -109,6 +109,104 @@ func Test_configMapClient_Create(t *testing.T) {
 	}
 }
 
+func Test_configMapClient_Delete(t *testing.T) {
+	tests := []struct {
+		name        string
+		dryRun      bool
+		wantDeleted bool
+	}{
+		{
+			name:        "Delete",
+			dryRun:      false,
+			wantDeleted: true,
+		},
+		{
+			name:        "Delete_Dry_Run",
+			dryRun:      true,
+			wantDeleted: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted *backendProjectClient.ConfigMap
+			client := ownedConfigMapClient(t, map[string]string{"cattlectl.io/hash": "some-hash"}, &deleted)
+			changed, err := client.Delete(tt.dryRun)
+			assert.Ok(t, err)
+			assert.Assert(t, changed, "Delete should report a change")
+			assert.Equals(t, tt.wantDeleted, deleted != nil)
+		})
+	}
+}
+
+func Test_configMapClient_Owned(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		wanted bool
+	}{
+		{
+			name:   "Owned",
+			labels: map[string]string{"cattlectl.io/hash": "some-hash", "cattlectl.io/project": "test-project-name"},
+			wanted: true,
+		},
+		{
+			name:   "Not_Declared_By_Project",
+			labels: map[string]string{"cattlectl.io/hash": "some-hash"},
+			wanted: false,
+		},
+		{
+			name:   "Not_Owned",
+			labels: map[string]string{},
+			wanted: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted *backendProjectClient.ConfigMap
+			got, err := ownedConfigMapClient(t, tt.labels, &deleted).Owned()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wanted, got)
+		})
+	}
+}
+
+func ownedConfigMapClient(t *testing.T, labels map[string]string, deleted **backendProjectClient.ConfigMap) *configMapClient {
+	testClients := stubs.CreateBackendStubs(t)
+	configMapOperationsStub := stubs.CreateConfigMapOperationsStub(t)
+	configMapOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.ConfigMapCollection, error) {
+		return &backendProjectClient.ConfigMapCollection{
+			Data: []backendProjectClient.ConfigMap{
+				backendProjectClient.ConfigMap{
+					Name:        "existing-configMap",
+					NamespaceId: "test-namespace-id",
+					Labels:      labels,
+				},
+			},
+		}, nil
+	}
+	configMapOperationsStub.DoDelete = func(configMap *backendProjectClient.ConfigMap) error {
+		*deleted = configMap
+		return nil
+	}
+	testClients.ProjectClient.ConfigMap = configMapOperationsStub
+	result, err := newConfigMapClient(
+		"existing-configMap",
+		"test-namespace",
+		&projectClient{
+			resourceClient: resourceClient{
+				name: "test-project-name",
+				id:   "test-project-id",
+			},
+			_backendProjectClient: testClients.ProjectClient,
+		},
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	configMapClientResult := result.(*configMapClient)
+	configMapClientResult.namespaceID = "test-namespace-id"
+	return configMapClientResult
+}
+
 func existingConfigMapClient(t *testing.T, expectedListOpts *types.ListOpts) *configMapClient {
 	const (
 		projectID     = "test-project-id"
@@ -20,6 +20,7 @@ import (
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
 	"github.com/sirupsen/logrus"
 )
 
@@ -111,6 +112,7 @@ func (client *cronJobClient) Create(dryRun bool) (changed bool, err error) {
 		return
 	}
 	pattern.NamespaceId = namespaceID
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.cronJob))
 
 	if dryRun {
 		client.logger.WithField("object", pattern).Info("Do Dry-Run Create")
@@ -121,8 +123,65 @@ func (client *cronJobClient) Create(dryRun bool) (changed bool, err error) {
 }
 
 func (client *cronJobClient) Upgrade(dryRun bool) (changed bool, err error) {
-	client.logger.Warn("Skip change existing cronjob")
-	return
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingCronJob, err := client.loadExistingCronJob()
+	if err != nil {
+		return
+	}
+	if existingCronJob == nil {
+		return changed, fmt.Errorf("CronJob %v not found", client.name)
+	}
+	if isCronJobUnchanged(*existingCronJob, client.cronJob) {
+		client.logger.Debug("Skip upgrade cronjob - no changes")
+		return
+	}
+	client.logger.Info("Upgrade cronjob")
+	pattern, err := projectModel.ConvertCronJobToProjectAPI(client.cronJob)
+	if err != nil {
+		return
+	}
+	pattern.Resource = existingCronJob.Resource
+	pattern.NamespaceId = existingCronJob.NamespaceId
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.cronJob))
+
+	if dryRun {
+		client.logger.WithField("object", pattern).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.CronJob.Replace(&pattern)
+	}
+	return err == nil, err
+}
+
+func (client *cronJobClient) Desired() (interface{}, error) {
+	pattern, err := projectModel.ConvertCronJobToProjectAPI(client.cronJob)
+	if err != nil {
+		return nil, err
+	}
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.cronJob))
+	return pattern, nil
+}
+
+func (client *cronJobClient) Existing() (interface{}, error) {
+	existingCronJob, err := client.loadExistingCronJob()
+	if err != nil || existingCronJob == nil {
+		return nil, err
+	}
+	return *existingCronJob, nil
+}
+
+func (client *cronJobClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *cronJobClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingCronJob()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isCronJobUnchanged(*existing, client.cronJob), nil
 }
 
 func (client *cronJobClient) Data() (projectModel.CronJob, error) {
@@ -134,3 +193,41 @@ func (client *cronJobClient) SetData(cronJob projectModel.CronJob) error {
 	client.cronJob = cronJob
 	return nil
 }
+
+func (client *cronJobClient) loadExistingCronJob() (existingCronJob *backendProjectClient.CronJob, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.CronJob.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read cronJob list")
+		err = fmt.Errorf("Failed to read cronJob list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name && item.NamespaceId == namespaceID {
+			existingCronJob = &item
+			return
+		}
+	}
+	return
+}
+
+func isCronJobUnchanged(existingCronJob backendProjectClient.CronJob, cronJob projectModel.CronJob) bool {
+	hash, hashExists := existingCronJob.WorkloadLabels["cattlectl.io/hash"]
+	if !hashExists {
+		return false
+	}
+	return hash == hashOf(cronJob)
+}
@@ -109,6 +109,55 @@ func Test_cronJobClient_Create(t *testing.T) {
 	}
 }
 
+func Test_cronJobClient_Upgrade(t *testing.T) {
+	cronJob := projectModel.CronJob{}
+	cronJob.Name = "existing-cronJob"
+	tests := []struct {
+		name          string
+		existingHash  string
+		dryRun        bool
+		wantedChanged bool
+		wantedReplace bool
+	}{
+		{
+			name:          "Unchanged",
+			existingHash:  hashOf(cronJob),
+			wantedChanged: false,
+			wantedReplace: false,
+		},
+		{
+			name:          "Changed",
+			existingHash:  "outdated-hash",
+			wantedChanged: true,
+			wantedReplace: true,
+		},
+		{
+			name:          "Without_Hash",
+			existingHash:  "",
+			wantedChanged: true,
+			wantedReplace: true,
+		},
+		{
+			name:          "Changed_Dry_Run",
+			existingHash:  "outdated-hash",
+			dryRun:        true,
+			wantedChanged: true,
+			wantedReplace: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replaced := false
+			client := upgradeCronJobClient(t, tt.existingHash, &replaced)
+			assert.Ok(t, client.SetData(cronJob))
+			changed, err := client.Upgrade(tt.dryRun)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedChanged, changed)
+			assert.Equals(t, tt.wantedReplace, replaced)
+		})
+	}
+}
+
 func existingCronJobClient(t *testing.T, expectedListOpts *types.ListOpts) *cronJobClient {
 	const (
 		projectID   = "test-project-id"
@@ -214,3 +263,45 @@ func notExistingCronJobClient(t *testing.T, expectedListOpts *types.ListOpts) *c
 	cronJobClientResult.namespaceID = "test-namespace-id"
 	return cronJobClientResult
 }
+
+func upgradeCronJobClient(t *testing.T, existingHash string, replaced *bool) *cronJobClient {
+	testClients := stubs.CreateBackendStubs(t)
+	existingCronJob := backendProjectClient.CronJob{
+		Resource: types.Resource{
+			ID: "test-namespace-id:existing-cronJob",
+		},
+		Name:           "existing-cronJob",
+		NamespaceId:    "test-namespace-id",
+		WorkloadLabels: map[string]string{},
+	}
+	if existingHash != "" {
+		existingCronJob.WorkloadLabels["cattlectl.io/hash"] = existingHash
+	}
+
+	cronJobOperationsStub := stubs.CreateCronJobOperationsStub(t)
+	cronJobOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.CronJobCollection, error) {
+		return &backendProjectClient.CronJobCollection{
+			Data: []backendProjectClient.CronJob{existingCronJob},
+		}, nil
+	}
+	cronJobOperationsStub.DoReplace = func(cronJob *backendProjectClient.CronJob) (*backendProjectClient.CronJob, error) {
+		assert.Equals(t, existingCronJob.ID, cronJob.ID)
+		assert.Equals(t, existingCronJob.NamespaceId, cronJob.NamespaceId)
+		assert.Assert(t, cronJob.WorkloadLabels["cattlectl.io/hash"] != existingHash, "Expected new hash label")
+		*replaced = true
+		return cronJob, nil
+	}
+	testClients.ProjectClient.CronJob = cronJobOperationsStub
+	projectClient := simpleProjectClient()
+	projectClient._backendProjectClient = testClients.ProjectClient
+	result, err := newCronJobClient(
+		"existing-cronJob",
+		"test-namespace",
+		projectClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	cronJobClientResult := result.(*cronJobClient)
+	cronJobClientResult.namespaceID = "test-namespace-id"
+	return cronJobClientResult
+}
@@ -20,6 +20,7 @@ import (
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
 	"github.com/sirupsen/logrus"
 )
 
@@ -111,18 +112,76 @@ func (client *daemonSetClient) Create(dryRun bool) (changed bool, err error) {
 		return
 	}
 	pattern.NamespaceId = namespaceID
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.daemonSet))
 
 	if dryRun {
 		client.logger.WithField("object", pattern).Info("Do Dry-Run Create")
-	} else {
-		_, err = backendClient.DaemonSet.Create(&pattern)
+	} else if _, err = backendClient.DaemonSet.Create(&pattern); err == nil {
+		err = waitUntilReady(client.project.config(), client.logger, client.ready)
 	}
 	return err == nil, err
 }
 
 func (client *daemonSetClient) Upgrade(dryRun bool) (changed bool, err error) {
-	client.logger.Warn("Skip change existing daemonset")
-	return
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingDaemonSet, err := client.loadExistingDaemonSet()
+	if err != nil {
+		return
+	}
+	if existingDaemonSet == nil {
+		return changed, fmt.Errorf("DaemonSet %v not found", client.name)
+	}
+	if isDaemonSetUnchanged(*existingDaemonSet, client.daemonSet) {
+		client.logger.Debug("Skip upgrade daemonset - no changes")
+		return
+	}
+	client.logger.Info("Upgrade daemonset")
+	pattern, err := projectModel.ConvertDaemonSetToProjectAPI(client.daemonSet)
+	if err != nil {
+		return
+	}
+	pattern.Resource = existingDaemonSet.Resource
+	pattern.NamespaceId = existingDaemonSet.NamespaceId
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.daemonSet))
+
+	if dryRun {
+		client.logger.WithField("object", pattern).Info("Do Dry-Run Upgrade")
+	} else if _, err = backendClient.DaemonSet.Replace(&pattern); err == nil {
+		err = waitUntilReady(client.project.config(), client.logger, client.ready)
+	}
+	return err == nil, err
+}
+
+func (client *daemonSetClient) Desired() (interface{}, error) {
+	pattern, err := projectModel.ConvertDaemonSetToProjectAPI(client.daemonSet)
+	if err != nil {
+		return nil, err
+	}
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.daemonSet))
+	return pattern, nil
+}
+
+func (client *daemonSetClient) Existing() (interface{}, error) {
+	existingDaemonSet, err := client.loadExistingDaemonSet()
+	if err != nil || existingDaemonSet == nil {
+		return nil, err
+	}
+	return *existingDaemonSet, nil
+}
+
+func (client *daemonSetClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *daemonSetClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingDaemonSet()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isDaemonSetUnchanged(*existing, client.daemonSet), nil
 }
 
 func (client *daemonSetClient) Data() (projectModel.DaemonSet, error) {
@@ -134,3 +193,64 @@ func (client *daemonSetClient) SetData(daemonSet projectModel.DaemonSet) error {
 	client.daemonSet = daemonSet
 	return nil
 }
+
+// ready is true if the daemon set is ready on all scheduled nodes
+func (client *daemonSetClient) ready() (bool, error) {
+	existingDaemonSet, err := client.loadExistingDaemonSet()
+	if err != nil {
+		return false, err
+	}
+	if existingDaemonSet == nil {
+		return false, fmt.Errorf("DaemonSet %v not found", client.name)
+	}
+	if err = transitioningError(existingDaemonSet.State, existingDaemonSet.Transitioning, existingDaemonSet.TransitioningMessage); err != nil {
+		return false, err
+	}
+	status := existingDaemonSet.DaemonSetStatus
+	if status == nil {
+		return false, nil
+	}
+	// right after a change the status may still describe the previous spec
+	if observed, err := generationObserved(client.project, workloadPath(client.namespace, "daemonsets", client.name), status.ObservedGeneration); err != nil || !observed {
+		return false, err
+	}
+	return status.NumberReady >= status.DesiredNumberScheduled && status.NumberUnavailable == 0, nil
+}
+
+func (client *daemonSetClient) loadExistingDaemonSet() (existingDaemonSet *backendProjectClient.DaemonSet, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.DaemonSet.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read daemonSet list")
+		err = fmt.Errorf("Failed to read daemonSet list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name && item.NamespaceId == namespaceID {
+			existingDaemonSet = &item
+			return
+		}
+	}
+	return
+}
+
+func isDaemonSetUnchanged(existingDaemonSet backendProjectClient.DaemonSet, daemonSet projectModel.DaemonSet) bool {
+	hash, hashExists := existingDaemonSet.WorkloadLabels["cattlectl.io/hash"]
+	if !hashExists {
+		return false
+	}
+	return hash == hashOf(daemonSet)
+}
@@ -109,6 +109,55 @@ func Test_daemonSetClient_Create(t *testing.T) {
 	}
 }
 
+func Test_daemonSetClient_Upgrade(t *testing.T) {
+	daemonSet := projectModel.DaemonSet{}
+	daemonSet.Name = "existing-daemonSet"
+	tests := []struct {
+		name          string
+		existingHash  string
+		dryRun        bool
+		wantedChanged bool
+		wantedReplace bool
+	}{
+		{
+			name:          "Unchanged",
+			existingHash:  hashOf(daemonSet),
+			wantedChanged: false,
+			wantedReplace: false,
+		},
+		{
+			name:          "Changed",
+			existingHash:  "outdated-hash",
+			wantedChanged: true,
+			wantedReplace: true,
+		},
+		{
+			name:          "Without_Hash",
+			existingHash:  "",
+			wantedChanged: true,
+			wantedReplace: true,
+		},
+		{
+			name:          "Changed_Dry_Run",
+			existingHash:  "outdated-hash",
+			dryRun:        true,
+			wantedChanged: true,
+			wantedReplace: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replaced := false
+			client := upgradeDaemonSetClient(t, tt.existingHash, &replaced)
+			assert.Ok(t, client.SetData(daemonSet))
+			changed, err := client.Upgrade(tt.dryRun)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedChanged, changed)
+			assert.Equals(t, tt.wantedReplace, replaced)
+		})
+	}
+}
+
 func existingDaemonSetClient(t *testing.T, expectedListOpts *types.ListOpts) *daemonSetClient {
 	const (
 		projectID     = "test-project-id"
@@ -214,3 +263,45 @@ func notExistingDaemonSetClient(t *testing.T, expectedListOpts *types.ListOpts)
 	daemonSetClientResult.namespaceID = "test-namespace-id"
 	return daemonSetClientResult
 }
+
+func upgradeDaemonSetClient(t *testing.T, existingHash string, replaced *bool) *daemonSetClient {
+	testClients := stubs.CreateBackendStubs(t)
+	existingDaemonSet := backendProjectClient.DaemonSet{
+		Resource: types.Resource{
+			ID: "test-namespace-id:existing-daemonSet",
+		},
+		Name:           "existing-daemonSet",
+		NamespaceId:    "test-namespace-id",
+		WorkloadLabels: map[string]string{},
+	}
+	if existingHash != "" {
+		existingDaemonSet.WorkloadLabels["cattlectl.io/hash"] = existingHash
+	}
+
+	daemonSetOperationsStub := stubs.CreateDaemonSetOperationsStub(t)
+	daemonSetOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.DaemonSetCollection, error) {
+		return &backendProjectClient.DaemonSetCollection{
+			Data: []backendProjectClient.DaemonSet{existingDaemonSet},
+		}, nil
+	}
+	daemonSetOperationsStub.DoReplace = func(daemonSet *backendProjectClient.DaemonSet) (*backendProjectClient.DaemonSet, error) {
+		assert.Equals(t, existingDaemonSet.ID, daemonSet.ID)
+		assert.Equals(t, existingDaemonSet.NamespaceId, daemonSet.NamespaceId)
+		assert.Assert(t, daemonSet.WorkloadLabels["cattlectl.io/hash"] != existingHash, "Expected new hash label")
+		*replaced = true
+		return daemonSet, nil
+	}
+	testClients.ProjectClient.DaemonSet = daemonSetOperationsStub
+	projectClient := simpleProjectClient()
+	projectClient._backendProjectClient = testClients.ProjectClient
+	result, err := newDaemonSetClient(
+		"existing-daemonSet",
+		"test-namespace",
+		projectClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	daemonSetClientResult := result.(*daemonSetClient)
+	daemonSetClientResult.namespaceID = "test-namespace-id"
+	return daemonSetClientResult
+}
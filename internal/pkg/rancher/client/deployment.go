@@ -20,6 +20,7 @@ import (
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
 	"github.com/sirupsen/logrus"
 )
 
@@ -111,18 +112,83 @@ func (client *deploymentClient) Create(dryRun bool) (changed bool, err error) {
 		return
 	}
 	pattern.NamespaceId = namespaceID
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.deployment))
 
 	if dryRun {
 		client.logger.WithField("object", pattern).Info("Do Dry-Run Create")
-	} else {
-		_, err = backendClient.Deployment.Create(&pattern)
+	} else if _, err = backendClient.Deployment.Create(&pattern); err == nil {
+		err = waitUntilReady(client.project.config(), client.logger, client.ready)
 	}
 	return err == nil, err
 }
 
 func (client *deploymentClient) Upgrade(dryRun bool) (changed bool, err error) {
-	client.logger.Warn("Skip change existing deployment")
-	return
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingDeployment, err := client.loadExistingDeployment()
+	if err != nil {
+		return
+	}
+	if existingDeployment == nil {
+		return changed, fmt.Errorf("Deployment %v not found", client.name)
+	}
+	if isDeploymentUnchanged(*existingDeployment, client.deployment) {
+		client.logger.Debug("Skip upgrade deployment - no changes")
+		return
+	}
+	client.logger.Info("Upgrade deployment")
+	pattern, err := projectModel.ConvertDeploymentToProjectAPI(client.deployment)
+	if err != nil {
+		return
+	}
+	pattern.Resource = existingDeployment.Resource
+	pattern.NamespaceId = existingDeployment.NamespaceId
+	if client.deployment.Autoscaling != nil {
+		// the replicas are managed by the horizontal pod autoscaler
+		pattern.Scale = existingDeployment.Scale
+	}
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.deployment))
+
+	if dryRun {
+		client.logger.WithField("object", pattern).Info("Do Dry-Run Upgrade")
+	} else if _, err = backendClient.Deployment.Replace(&pattern); err == nil {
+		err = waitUntilReady(client.project.config(), client.logger, client.ready)
+	}
+	return err == nil, err
+}
+
+func (client *deploymentClient) Desired() (interface{}, error) {
+	pattern, err := projectModel.ConvertDeploymentToProjectAPI(client.deployment)
+	if err != nil {
+		return nil, err
+	}
+	if client.deployment.Autoscaling != nil {
+		pattern.Scale = nil
+	}
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.deployment))
+	return pattern, nil
+}
+
+func (client *deploymentClient) Existing() (interface{}, error) {
+	existingDeployment, err := client.loadExistingDeployment()
+	if err != nil || existingDeployment == nil {
+		return nil, err
+	}
+	return *existingDeployment, nil
+}
+
+func (client *deploymentClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *deploymentClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingDeployment()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isDeploymentUnchanged(*existing, client.deployment), nil
 }
 
 func (client *deploymentClient) Data() (projectModel.Deployment, error) {
@@ -134,3 +200,65 @@ func (client *deploymentClient) SetData(deployment projectModel.Deployment) erro
 	client.deployment = deployment
 	return nil
 }
+
+// ready is true if all replicas of the deployment are updated and available
+func (client *deploymentClient) ready() (bool, error) {
+	existingDeployment, err := client.loadExistingDeployment()
+	if err != nil {
+		return false, err
+	}
+	if existingDeployment == nil {
+		return false, fmt.Errorf("Deployment %v not found", client.name)
+	}
+	if err = transitioningError(existingDeployment.State, existingDeployment.Transitioning, existingDeployment.TransitioningMessage); err != nil {
+		return false, err
+	}
+	status := existingDeployment.DeploymentStatus
+	if status == nil {
+		return false, nil
+	}
+	// right after a change the status may still describe the previous spec
+	if observed, err := generationObserved(client.project, workloadPath(client.namespace, "deployments", client.name), status.ObservedGeneration); err != nil || !observed {
+		return false, err
+	}
+	desired := desiredReplicas(existingDeployment.Scale)
+	return status.UpdatedReplicas >= desired && status.AvailableReplicas >= desired && status.UnavailableReplicas == 0, nil
+}
+
+func (client *deploymentClient) loadExistingDeployment() (existingDeployment *backendProjectClient.Deployment, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.Deployment.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read deployment list")
+		err = fmt.Errorf("Failed to read deployment list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name && item.NamespaceId == namespaceID {
+			existingDeployment = &item
+			return
+		}
+	}
+	return
+}
+
+func isDeploymentUnchanged(existingDeployment backendProjectClient.Deployment, deployment projectModel.Deployment) bool {
+	hash, hashExists := existingDeployment.WorkloadLabels["cattlectl.io/hash"]
+	if !hashExists {
+		return false
+	}
+	return hash == hashOf(deployment)
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/assert"
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
@@ -109,6 +110,163 @@ func Test_deploymentClient_Create(t *testing.T) {
 	}
 }
 
+func Test_deploymentClient_Upgrade(t *testing.T) {
+	deployment := projectModel.Deployment{}
+	deployment.Name = "existing-deployment"
+	tests := []struct {
+		name          string
+		existingHash  string
+		dryRun        bool
+		wantedChanged bool
+		wantedReplace bool
+	}{
+		{
+			name:          "Unchanged",
+			existingHash:  hashOf(deployment),
+			wantedChanged: false,
+			wantedReplace: false,
+		},
+		{
+			name:          "Changed",
+			existingHash:  "outdated-hash",
+			wantedChanged: true,
+			wantedReplace: true,
+		},
+		{
+			name:          "Without_Hash",
+			existingHash:  "",
+			wantedChanged: true,
+			wantedReplace: true,
+		},
+		{
+			name:          "Changed_Dry_Run",
+			existingHash:  "outdated-hash",
+			dryRun:        true,
+			wantedChanged: true,
+			wantedReplace: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replaced := false
+			client := upgradeDeploymentClient(t, tt.existingHash, &replaced)
+			assert.Ok(t, client.SetData(deployment))
+			changed, err := client.Upgrade(tt.dryRun)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedChanged, changed)
+			assert.Equals(t, tt.wantedReplace, replaced)
+		})
+	}
+}
+
+func Test_deploymentClient_Upgrade_Autoscaled(t *testing.T) {
+	existingScale := int64(5)
+	declaredScale := int64(2)
+	deployment := projectModel.Deployment{
+		Scale:       &declaredScale,
+		Autoscaling: &projectModel.Autoscaling{MaxReplicas: 10},
+	}
+	deployment.Name = "existing-deployment"
+	deployment.Containers = []projectModel.Container{{Name: "web", Image: "nginx:1.17"}}
+
+	testClients := stubs.CreateBackendStubs(t)
+	deploymentOperationsStub := stubs.CreateDeploymentOperationsStub(t)
+	deploymentOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.DeploymentCollection, error) {
+		return &backendProjectClient.DeploymentCollection{
+			Data: []backendProjectClient.Deployment{
+				{
+					Name:           "existing-deployment",
+					NamespaceId:    "test-namespace-id",
+					Scale:          &existingScale,
+					WorkloadLabels: map[string]string{"cattlectl.io/hash": "outdated-hash"},
+				},
+			},
+		}, nil
+	}
+	var replaced *backendProjectClient.Deployment
+	deploymentOperationsStub.DoReplace = func(deployment *backendProjectClient.Deployment) (*backendProjectClient.Deployment, error) {
+		replaced = deployment
+		return deployment, nil
+	}
+	testClients.ProjectClient.Deployment = deploymentOperationsStub
+	projectClient := simpleProjectClient()
+	projectClient._backendProjectClient = testClients.ProjectClient
+	result, err := newDeploymentClientWithData(
+		deployment,
+		"test-namespace",
+		projectClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	client := result.(*deploymentClient)
+	client.namespaceID = "test-namespace-id"
+
+	changed, err := client.Upgrade(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Upgrade should report a change")
+	assert.Equals(t, existingScale, *replaced.Scale)
+	assert.Equals(t, "nginx:1.17", replaced.Containers[0].Image)
+
+	desired, err := client.Desired()
+	assert.Ok(t, err)
+	assert.Assert(t, desired.(backendProjectClient.Deployment).Scale == nil, "Desired scale should be left to the autoscaler")
+}
+
+func Test_deploymentClient_Create_Wait(t *testing.T) {
+	scale := int64(2)
+	tests := []struct {
+		name      string
+		states    []backendProjectClient.Deployment
+		wantedErr string
+	}{
+		{
+			name: "Ready",
+			states: []backendProjectClient.Deployment{
+				{Scale: &scale, State: "updating", Transitioning: "yes"},
+				{Scale: &scale, State: "updating", Transitioning: "yes", DeploymentStatus: &backendProjectClient.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 1, UnavailableReplicas: 1}},
+				{Scale: &scale, State: "active", DeploymentStatus: &backendProjectClient.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: 2}},
+			},
+		},
+		{
+			name: "Stale_Status",
+			states: []backendProjectClient.Deployment{
+				{Scale: &scale, State: "active", DeploymentStatus: &backendProjectClient.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 2, AvailableReplicas: 2}},
+			},
+			wantedErr: "Not ready after 10ms",
+		},
+		{
+			name: "Failed",
+			states: []backendProjectClient.Deployment{
+				{Scale: &scale, State: "updating", Transitioning: "error", TransitioningMessage: "ImagePullBackOff"},
+			},
+			wantedErr: "Resource is updating, ImagePullBackOff",
+		},
+		{
+			name: "Timeout",
+			states: []backendProjectClient.Deployment{
+				{Scale: &scale, State: "updating", Transitioning: "yes"},
+			},
+			wantedErr: "Not ready after 10ms",
+		},
+	}
+	defer func(interval time.Duration) { readyPollInterval = interval }(readyPollInterval)
+	readyPollInterval = time.Millisecond
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newWorkloadServer(t, workloadPath("test-namespace", "deployments", "existing-deployment"), 2)
+			defer server.Close()
+			client := waitingDeploymentClient(t, server.URL, tt.states)
+			changed, err := client.Create(false)
+			if tt.wantedErr != "" {
+				assert.NotOk(t, err, tt.wantedErr)
+			} else {
+				assert.Ok(t, err)
+				assert.Assert(t, changed, "Expected created deployment")
+			}
+		})
+	}
+}
+
 func existingDeploymentClient(t *testing.T, expectedListOpts *types.ListOpts) *deploymentClient {
 	const (
 		projectID      = "test-project-id"
@@ -214,3 +372,84 @@ func notExistingDeploymentClient(t *testing.T, expectedListOpts *types.ListOpts)
 	deploymentClientResult.namespaceID = "test-namespace-id"
 	return deploymentClientResult
 }
+
+func upgradeDeploymentClient(t *testing.T, existingHash string, replaced *bool) *deploymentClient {
+	testClients := stubs.CreateBackendStubs(t)
+	existingDeployment := backendProjectClient.Deployment{
+		Resource: types.Resource{
+			ID: "test-namespace-id:existing-deployment",
+		},
+		Name:           "existing-deployment",
+		NamespaceId:    "test-namespace-id",
+		WorkloadLabels: map[string]string{},
+	}
+	if existingHash != "" {
+		existingDeployment.WorkloadLabels["cattlectl.io/hash"] = existingHash
+	}
+
+	deploymentOperationsStub := stubs.CreateDeploymentOperationsStub(t)
+	deploymentOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.DeploymentCollection, error) {
+		return &backendProjectClient.DeploymentCollection{
+			Data: []backendProjectClient.Deployment{existingDeployment},
+		}, nil
+	}
+	deploymentOperationsStub.DoReplace = func(deployment *backendProjectClient.Deployment) (*backendProjectClient.Deployment, error) {
+		assert.Equals(t, existingDeployment.ID, deployment.ID)
+		assert.Equals(t, existingDeployment.NamespaceId, deployment.NamespaceId)
+		assert.Assert(t, deployment.WorkloadLabels["cattlectl.io/hash"] != existingHash, "Expected new hash label")
+		*replaced = true
+		return deployment, nil
+	}
+	testClients.ProjectClient.Deployment = deploymentOperationsStub
+	projectClient := simpleProjectClient()
+	projectClient._backendProjectClient = testClients.ProjectClient
+	result, err := newDeploymentClient(
+		"existing-deployment",
+		"test-namespace",
+		projectClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	deploymentClientResult := result.(*deploymentClient)
+	deploymentClientResult.namespaceID = "test-namespace-id"
+	return deploymentClientResult
+}
+
+// waitingDeploymentClient creates a deployment, which is listed in the given states one after another
+func waitingDeploymentClient(t *testing.T, kubernetesURL string, states []backendProjectClient.Deployment) *deploymentClient {
+	testClients := stubs.CreateBackendStubs(t)
+	deploymentOperationsStub := stubs.CreateDeploymentOperationsStub(t)
+	created := false
+	deploymentOperationsStub.DoCreate = func(deployment *backendProjectClient.Deployment) (*backendProjectClient.Deployment, error) {
+		created = true
+		return deployment, nil
+	}
+	polled := 0
+	deploymentOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.DeploymentCollection, error) {
+		assert.Assert(t, created, "Expected list after create")
+		state := states[len(states)-1]
+		if polled < len(states) {
+			state = states[polled]
+		}
+		polled++
+		state.Name = "existing-deployment"
+		state.NamespaceId = "test-namespace-id"
+		return &backendProjectClient.DeploymentCollection{
+			Data: []backendProjectClient.Deployment{state},
+		}, nil
+	}
+	testClients.ProjectClient.Deployment = deploymentOperationsStub
+	projectClient := kubernetesProjectClient(t, kubernetesURL)
+	projectClient._backendProjectClient = testClients.ProjectClient
+	projectClient.rancherConfig.WaitTimeout = 10 * time.Millisecond
+	result, err := newDeploymentClient(
+		"existing-deployment",
+		"test-namespace",
+		projectClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	deploymentClientResult := result.(*deploymentClient)
+	deploymentClientResult.namespaceID = "test-namespace-id"
+	return deploymentClientResult
+}
@@ -0,0 +1,265 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newGlobalDNSEntryClientWithData(
+	entry rancherModel.GlobalDNSEntry,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (GlobalDNSEntryClient, error) {
+	result, err := newGlobalDNSEntryClient(
+		entry.Name,
+		rancherClient,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(entry)
+	return result, err
+}
+
+func newGlobalDNSEntryClient(
+	name string,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (GlobalDNSEntryClient, error) {
+	return &globalDNSEntryClient{
+		resourceClient: resourceClient{
+			name:   name,
+			logger: logger.WithField("global_dns_entry_name", name),
+		},
+		rancherClient: rancherClient,
+	}, nil
+}
+
+// globalDNSEntryClient manages a global DNS entry, the projects of an existing entry are
+// changed by the actions addProjects and removeProjects
+type globalDNSEntryClient struct {
+	resourceClient
+	entry         rancherModel.GlobalDNSEntry
+	rancherClient RancherClient
+}
+
+func (client *globalDNSEntryClient) Type() string {
+	return rancherModel.RancherGlobalDNSEntry
+}
+
+func (client *globalDNSEntryClient) Exists() (bool, error) {
+	existingEntry, err := client.loadExistingEntry()
+	if err != nil {
+		return false, err
+	}
+	if existingEntry == nil {
+		client.logger.Debug("Global DNS entry not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *globalDNSEntryClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	projectIDs, err := client.projectIDs()
+	if err != nil {
+		return
+	}
+
+	client.logger.Info("Create new global DNS entry")
+	newEntry := backendRancherClient.GlobalDNS{
+		Name: client.entry.Name,
+		Labels: map[string]string{
+			"cattlectl.io/hash": hashOf(client.entry),
+		},
+		ProjectIDs: projectIDs,
+	}
+	if err = client.applyEntry(&newEntry); err != nil {
+		return
+	}
+
+	if dryRun {
+		client.logger.WithField("object", newEntry).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.GlobalDNS.Create(&newEntry)
+	}
+	return err == nil, err
+}
+
+// Upgrade adds and removes projects first, then the other fields are replaced
+func (client *globalDNSEntryClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingEntry, err := client.loadExistingEntry()
+	if err != nil {
+		return
+	}
+	if existingEntry == nil {
+		return changed, fmt.Errorf("Global DNS entry %v not found", client.name)
+	}
+	if existingEntry.Labels["cattlectl.io/hash"] == hashOf(client.entry) {
+		client.logger.Debug("Skip upgrade global DNS entry - no changes")
+		return
+	}
+	projectIDs, err := client.projectIDs()
+	if err != nil {
+		return
+	}
+	addedProjectIDs, removedProjectIDs := diffProjectIDs(existingEntry.ProjectIDs, projectIDs)
+
+	client.logger.Info("Upgrade GlobalDNS")
+	if len(removedProjectIDs) > 0 {
+		client.logger.WithField("projects", removedProjectIDs).Info("Remove projects from global DNS entry")
+		if !dryRun {
+			if err = backendClient.GlobalDNS.ActionRemoveProjects(existingEntry, &backendRancherClient.UpdateGlobalDNSTargetsInput{
+				ProjectIDs: removedProjectIDs,
+			}); err != nil {
+				return
+			}
+		}
+	}
+	if len(addedProjectIDs) > 0 {
+		client.logger.WithField("projects", addedProjectIDs).Info("Add projects to global DNS entry")
+		if !dryRun {
+			if err = backendClient.GlobalDNS.ActionAddProjects(existingEntry, &backendRancherClient.UpdateGlobalDNSTargetsInput{
+				ProjectIDs: addedProjectIDs,
+			}); err != nil {
+				return
+			}
+		}
+	}
+	if !dryRun && len(addedProjectIDs)+len(removedProjectIDs) > 0 {
+		// the actions changed the entry, the replace needs the current revision
+		if existingEntry, err = backendClient.GlobalDNS.ByID(existingEntry.ID); err != nil {
+			return
+		}
+	}
+	existingEntry.Labels = withHashLabel(existingEntry.Labels, hashOf(client.entry))
+	if err = client.applyEntry(existingEntry); err != nil {
+		return
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingEntry).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.GlobalDNS.Replace(existingEntry)
+	}
+	return err == nil, err
+}
+
+func (client *globalDNSEntryClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingEntry, err := client.loadExistingEntry()
+	if err != nil {
+		return
+	}
+	if existingEntry == nil {
+		return changed, fmt.Errorf("Global DNS entry %v not found", client.name)
+	}
+	if dryRun {
+		client.logger.WithField("object", existingEntry).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.GlobalDNS.Delete(existingEntry)
+	}
+	return err == nil, err
+}
+
+func (client *globalDNSEntryClient) Data() (rancherModel.GlobalDNSEntry, error) {
+	return client.entry, nil
+}
+
+func (client *globalDNSEntryClient) SetData(entry rancherModel.GlobalDNSEntry) error {
+	client.name = entry.Name
+	client.entry = entry
+	return nil
+}
+
+// applyEntry sets the declared fields besides the projects on the backend object,
+// the names of the provider and the multi cluster app are resolved to their IDs
+func (client *globalDNSEntryClient) applyEntry(backendEntry *backendRancherClient.GlobalDNS) error {
+	providerClient, err := client.rancherClient.GlobalDNSProvider(client.entry.Provider)
+	if err != nil {
+		return err
+	}
+	providerID, err := providerClient.ID()
+	if err != nil {
+		return err
+	}
+	multiClusterAppID := ""
+	if client.entry.MultiClusterApp != "" {
+		multiClusterAppClient, err := client.rancherClient.MultiClusterApp(client.entry.MultiClusterApp)
+		if err != nil {
+			return err
+		}
+		if multiClusterAppID, err = multiClusterAppClient.ID(); err != nil {
+			return err
+		}
+	}
+	backendEntry.FQDN = client.entry.FQDN
+	backendEntry.ProviderID = providerID
+	backendEntry.TTL = client.entry.TTL
+	backendEntry.MultiClusterAppID = multiClusterAppID
+	return nil
+}
+
+func (client *globalDNSEntryClient) projectIDs() (projectIDs []string, err error) {
+	for _, project := range client.entry.Projects {
+		clusterName, projectName := rancherModel.SplitTarget(project)
+		projectID, err := projectIDOf(client.rancherClient, clusterName, projectName)
+		if err != nil {
+			return nil, err
+		}
+		projectIDs = append(projectIDs, projectID)
+	}
+	return
+}
+
+func (client *globalDNSEntryClient) loadExistingEntry() (*backendRancherClient.GlobalDNS, error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.GlobalDNS.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": client.name,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read global DNS entry list")
+		return nil, fmt.Errorf("Failed to read global DNS entry list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,187 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_globalDNSEntryClient_Create(t *testing.T) {
+	tests := []struct {
+		name                    string
+		entry                   rancherModel.GlobalDNSEntry
+		wantedProjectIDs        []string
+		wantedMultiClusterAppID string
+		wantErr                 bool
+		wantedErr               string
+	}{
+		{
+			name: "Projects",
+			entry: rancherModel.GlobalDNSEntry{
+				Name:     "shop",
+				FQDN:     "shop.example.com",
+				Provider: "route53",
+				Projects: []string{"production:web", "staging:web"},
+			},
+			wantedProjectIDs: []string{"c-prod:p-web", "c-stage:p-web"},
+		},
+		{
+			name: "Multi_Cluster_App",
+			entry: rancherModel.GlobalDNSEntry{
+				Name:            "shop",
+				FQDN:            "shop.example.com",
+				Provider:        "route53",
+				MultiClusterApp: "wordpress",
+			},
+			wantedMultiClusterAppID: "cattle-global-data:mcapp-wordpress",
+		},
+		{
+			name: "Unknown_Provider",
+			entry: rancherModel.GlobalDNSEntry{
+				Name:     "shop",
+				FQDN:     "shop.example.com",
+				Provider: "cloudflare",
+				Projects: []string{"production:web"},
+			},
+			wantErr:   true,
+			wantedErr: "Global DNS provider cloudflare not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			testClients.ManagementClient.GlobalDNSProvider = testGlobalDNSProviderOperationsStub(t)
+			multiClusterAppOperationsStub := stubs.CreateMultiClusterAppOperationsStub(t)
+			multiClusterAppOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.MultiClusterAppCollection, error) {
+				return &backendRancherClient.MultiClusterAppCollection{
+					Data: []backendRancherClient.MultiClusterApp{
+						{Resource: types.Resource{ID: "cattle-global-data:mcapp-wordpress"}, Name: "wordpress"},
+					},
+				}, nil
+			}
+			testClients.ManagementClient.MultiClusterApp = multiClusterAppOperationsStub
+			var created *backendRancherClient.GlobalDNS
+			globalDNSOperationsStub := stubs.CreateGlobalDNSOperationsStub(t)
+			globalDNSOperationsStub.DoCreate = func(entry *backendRancherClient.GlobalDNS) (*backendRancherClient.GlobalDNS, error) {
+				created = entry
+				return entry, nil
+			}
+			testClients.ManagementClient.GlobalDNS = globalDNSOperationsStub
+			client, err := newGlobalDNSEntryClientWithData(
+				tt.entry,
+				newTestTargetRancherClient(testClients.ManagementClient),
+				logrus.New().WithFields(logrus.Fields{}),
+			)
+			assert.Ok(t, err)
+
+			changed, err := client.Create(false)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Equals(t, tt.wantedErr, err.Error())
+				return
+			}
+			assert.Ok(t, err)
+			assert.Assert(t, changed, "Global DNS entry must be created")
+			assert.Equals(t, "shop.example.com", created.FQDN)
+			assert.Equals(t, "cattle-global-data:gd-route53", created.ProviderID)
+			assert.Equals(t, tt.wantedProjectIDs, created.ProjectIDs)
+			assert.Equals(t, tt.wantedMultiClusterAppID, created.MultiClusterAppID)
+		})
+	}
+}
+
+func Test_globalDNSEntryClient_Upgrade(t *testing.T) {
+	testClients := stubs.CreateBackendStubs(t)
+	testClients.ManagementClient.GlobalDNSProvider = testGlobalDNSProviderOperationsStub(t)
+	existing := backendRancherClient.GlobalDNS{
+		Resource:   types.Resource{ID: "cattle-global-data:gd-shop"},
+		Name:       "shop",
+		FQDN:       "shop.example.com",
+		ProjectIDs: []string{"c-prod:p-web", "c-test:p-web"},
+		Labels:     map[string]string{"cattlectl.io/hash": "old"},
+	}
+	var (
+		addedProjectIDs   []string
+		removedProjectIDs []string
+		replaced          *backendRancherClient.GlobalDNS
+	)
+	globalDNSOperationsStub := stubs.CreateGlobalDNSOperationsStub(t)
+	globalDNSOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.GlobalDNSCollection, error) {
+		return &backendRancherClient.GlobalDNSCollection{Data: []backendRancherClient.GlobalDNS{existing}}, nil
+	}
+	globalDNSOperationsStub.DoActionAddProjects = func(entry *backendRancherClient.GlobalDNS, input *backendRancherClient.UpdateGlobalDNSTargetsInput) error {
+		addedProjectIDs = input.ProjectIDs
+		return nil
+	}
+	globalDNSOperationsStub.DoActionRemoveProjects = func(entry *backendRancherClient.GlobalDNS, input *backendRancherClient.UpdateGlobalDNSTargetsInput) error {
+		removedProjectIDs = input.ProjectIDs
+		return nil
+	}
+	globalDNSOperationsStub.DoByID = func(id string) (*backendRancherClient.GlobalDNS, error) {
+		assert.Equals(t, "cattle-global-data:gd-shop", id)
+		reloaded := existing
+		reloaded.ProjectIDs = []string{"c-prod:p-web", "c-stage:p-web"}
+		return &reloaded, nil
+	}
+	globalDNSOperationsStub.DoReplace = func(entry *backendRancherClient.GlobalDNS) (*backendRancherClient.GlobalDNS, error) {
+		replaced = entry
+		return entry, nil
+	}
+	testClients.ManagementClient.GlobalDNS = globalDNSOperationsStub
+	entry := rancherModel.GlobalDNSEntry{
+		Name:     "shop",
+		FQDN:     "shop.example.com",
+		Provider: "route53",
+		TTL:      60,
+		Projects: []string{"production:web", "staging:web"},
+	}
+	client, err := newGlobalDNSEntryClientWithData(
+		entry,
+		newTestTargetRancherClient(testClients.ManagementClient),
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+
+	changed, err := client.Upgrade(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Global DNS entry must be upgraded")
+	assert.Equals(t, []string{"c-stage:p-web"}, addedProjectIDs)
+	assert.Equals(t, []string{"c-test:p-web"}, removedProjectIDs)
+	assert.Equals(t, []string{"c-prod:p-web", "c-stage:p-web"}, replaced.ProjectIDs)
+	assert.Equals(t, int64(60), replaced.TTL)
+	assert.Equals(t, hashOf(entry), replaced.Labels["cattlectl.io/hash"])
+}
+
+func testGlobalDNSProviderOperationsStub(tb testing.TB) *stubs.GlobalDNSProviderOperationsStub {
+	providerOperationsStub := stubs.CreateGlobalDNSProviderOperationsStub(tb)
+	providerOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.GlobalDNSProviderCollection, error) {
+		if opts.Filters["name"] != "route53" {
+			return &backendRancherClient.GlobalDNSProviderCollection{}, nil
+		}
+		return &backendRancherClient.GlobalDNSProviderCollection{
+			Data: []backendRancherClient.GlobalDNSProvider{
+				{Resource: types.Resource{ID: "cattle-global-data:gd-route53"}, Name: "route53"},
+			},
+		}, nil
+	}
+	return providerOperationsStub
+}
@@ -0,0 +1,250 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newGlobalDNSProviderClientWithData(
+	provider rancherModel.GlobalDNSProvider,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (GlobalDNSProviderClient, error) {
+	result, err := newGlobalDNSProviderClient(
+		provider.Name,
+		rancherClient,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(provider)
+	return result, err
+}
+
+func newGlobalDNSProviderClient(
+	name string,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (GlobalDNSProviderClient, error) {
+	return &globalDNSProviderClient{
+		resourceClient: resourceClient{
+			name:   name,
+			logger: logger.WithField("global_dns_provider_name", name),
+		},
+		rancherClient: rancherClient,
+	}, nil
+}
+
+type globalDNSProviderClient struct {
+	resourceClient
+	provider      rancherModel.GlobalDNSProvider
+	rancherClient RancherClient
+}
+
+func (client *globalDNSProviderClient) Type() string {
+	return rancherModel.RancherGlobalDNSProvider
+}
+
+func (client *globalDNSProviderClient) ID() (string, error) {
+	client.idLock.Lock()
+	defer client.idLock.Unlock()
+	if client.id != "" {
+		return client.id, nil
+	}
+	existingProvider, err := client.loadExistingProvider()
+	if err != nil {
+		return "", err
+	}
+	if existingProvider == nil {
+		return "", fmt.Errorf("Global DNS provider %v not found", client.name)
+	}
+	client.id = existingProvider.ID
+	return client.id, nil
+}
+
+func (client *globalDNSProviderClient) Exists() (bool, error) {
+	existingProvider, err := client.loadExistingProvider()
+	if err != nil {
+		return false, err
+	}
+	if existingProvider == nil {
+		client.logger.Debug("Global DNS provider not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *globalDNSProviderClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+
+	client.logger.Info("Create new global DNS provider")
+	newProvider := backendRancherClient.GlobalDNSProvider{
+		Name: client.provider.Name,
+		Labels: map[string]string{
+			"cattlectl.io/hash": client.providerHash(),
+		},
+	}
+	client.applyProvider(&newProvider)
+
+	if dryRun {
+		client.logger.WithField("object", newProvider).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.GlobalDNSProvider.Create(&newProvider)
+	}
+	return err == nil, err
+}
+
+func (client *globalDNSProviderClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingProvider, err := client.loadExistingProvider()
+	if err != nil {
+		return
+	}
+	if existingProvider == nil {
+		return changed, fmt.Errorf("Global DNS provider %v not found", client.name)
+	}
+	if existingProvider.Labels["cattlectl.io/hash"] == client.providerHash() {
+		client.logger.Debug("Skip upgrade global DNS provider - no changes")
+		return
+	}
+	client.logger.Info("Upgrade GlobalDNSProvider")
+	existingProvider.Labels = withHashLabel(existingProvider.Labels, client.providerHash())
+	client.applyProvider(existingProvider)
+
+	if dryRun {
+		client.logger.WithField("object", existingProvider).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.GlobalDNSProvider.Replace(existingProvider)
+	}
+	return err == nil, err
+}
+
+func (client *globalDNSProviderClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingProvider, err := client.loadExistingProvider()
+	if err != nil {
+		return
+	}
+	if existingProvider == nil {
+		return changed, fmt.Errorf("Global DNS provider %v not found", client.name)
+	}
+	if dryRun {
+		client.logger.WithField("object", existingProvider).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.GlobalDNSProvider.Delete(existingProvider)
+	}
+	return err == nil, err
+}
+
+func (client *globalDNSProviderClient) Data() (rancherModel.GlobalDNSProvider, error) {
+	return client.provider, nil
+}
+
+func (client *globalDNSProviderClient) SetData(provider rancherModel.GlobalDNSProvider) error {
+	client.name = provider.Name
+	client.provider = provider
+	return nil
+}
+
+// applyProvider sets the root domain and the declared provider config on the backend object, the other configs are removed
+func (client *globalDNSProviderClient) applyProvider(backendProvider *backendRancherClient.GlobalDNSProvider) {
+	backendProvider.RootDomain = client.provider.RootDomain
+	backendProvider.Route53ProviderConfig = nil
+	backendProvider.CloudflareProviderConfig = nil
+	backendProvider.AlidnsProviderConfig = nil
+	if route53 := client.provider.Route53; route53 != nil {
+		backendProvider.Route53ProviderConfig = &backendRancherClient.Route53ProviderConfig{
+			AccessKey:       route53.AccessKey,
+			SecretKey:       route53.SecretKey,
+			Region:          route53.Region,
+			ZoneType:        route53.ZoneType,
+			RoleArn:         route53.RoleArn,
+			CredentialsPath: route53.CredentialsPath,
+		}
+	}
+	if cloudflare := client.provider.Cloudflare; cloudflare != nil {
+		backendProvider.CloudflareProviderConfig = &backendRancherClient.CloudflareProviderConfig{
+			APIEmail:     cloudflare.APIEmail,
+			APIKey:       cloudflare.APIKey,
+			ProxySetting: cloudflare.ProxySetting,
+		}
+	}
+	if alidns := client.provider.Alidns; alidns != nil {
+		backendProvider.AlidnsProviderConfig = &backendRancherClient.AlidnsProviderConfig{
+			AccessKey: alidns.AccessKey,
+			SecretKey: alidns.SecretKey,
+		}
+	}
+}
+
+// providerHash is the hash of the provider without its credentials, which must not leak into the labels
+func (client *globalDNSProviderClient) providerHash() string {
+	provider := client.provider
+	if provider.Route53 != nil {
+		route53 := *provider.Route53
+		route53.AccessKey, route53.SecretKey = "", ""
+		provider.Route53 = &route53
+	}
+	if provider.Cloudflare != nil {
+		cloudflare := *provider.Cloudflare
+		cloudflare.APIKey = ""
+		provider.Cloudflare = &cloudflare
+	}
+	if provider.Alidns != nil {
+		alidns := *provider.Alidns
+		alidns.AccessKey, alidns.SecretKey = "", ""
+		provider.Alidns = &alidns
+	}
+	return hashOf(provider)
+}
+
+func (client *globalDNSProviderClient) loadExistingProvider() (*backendRancherClient.GlobalDNSProvider, error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.GlobalDNSProvider.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": client.name,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read global DNS provider list")
+		return nil, fmt.Errorf("Failed to read global DNS provider list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
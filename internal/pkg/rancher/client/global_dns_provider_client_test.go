@@ -0,0 +1,152 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+var simpleGlobalDNSProvider = rancherModel.GlobalDNSProvider{
+	Name:       "route53",
+	RootDomain: "example.com",
+	Route53: &rancherModel.Route53ProviderConfig{
+		AccessKey: "access",
+		SecretKey: "secret",
+		Region:    "eu-central-1",
+	},
+}
+
+// simpleGlobalDNSProviderHash is the hash of simpleGlobalDNSProvider without its credentials
+var simpleGlobalDNSProviderHash = hashOf(rancherModel.GlobalDNSProvider{
+	Name:       "route53",
+	RootDomain: "example.com",
+	Route53: &rancherModel.Route53ProviderConfig{
+		Region: "eu-central-1",
+	},
+})
+
+func Test_globalDNSProviderClient_Create(t *testing.T) {
+	testClients := stubs.CreateBackendStubs(t)
+	var created *backendRancherClient.GlobalDNSProvider
+	providerOperationsStub := stubs.CreateGlobalDNSProviderOperationsStub(t)
+	providerOperationsStub.DoCreate = func(provider *backendRancherClient.GlobalDNSProvider) (*backendRancherClient.GlobalDNSProvider, error) {
+		created = provider
+		return provider, nil
+	}
+	testClients.ManagementClient.GlobalDNSProvider = providerOperationsStub
+	rancherClient := simpleRancherClient()
+	rancherClient._backendRancherClient = testClients.ManagementClient
+	client, err := newGlobalDNSProviderClientWithData(simpleGlobalDNSProvider, rancherClient, logrus.New().WithFields(logrus.Fields{}))
+	assert.Ok(t, err)
+
+	changed, err := client.Create(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Global DNS provider must be created")
+	assert.Equals(t, "route53", created.Name)
+	assert.Equals(t, "example.com", created.RootDomain)
+	assert.Equals(t, &backendRancherClient.Route53ProviderConfig{AccessKey: "access", SecretKey: "secret", Region: "eu-central-1"}, created.Route53ProviderConfig)
+	assert.Assert(t, created.CloudflareProviderConfig == nil, "Only the declared provider config must be set")
+	assert.Equals(t, simpleGlobalDNSProviderHash, created.Labels["cattlectl.io/hash"])
+}
+
+func Test_globalDNSProviderClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name             string
+		existing         backendRancherClient.GlobalDNSProvider
+		wantedChanged    bool
+		wantedRootDomain string
+	}{
+		{
+			name: "Changed",
+			existing: backendRancherClient.GlobalDNSProvider{
+				Name:       "route53",
+				RootDomain: "old.example.com",
+				Labels:     map[string]string{"cattlectl.io/hash": "old"},
+			},
+			wantedChanged:    true,
+			wantedRootDomain: "example.com",
+		},
+		{
+			name: "Unchanged",
+			existing: backendRancherClient.GlobalDNSProvider{
+				Name:       "route53",
+				RootDomain: "example.com",
+				Labels:     map[string]string{"cattlectl.io/hash": simpleGlobalDNSProviderHash},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			replacedRootDomain := ""
+			providerOperationsStub := stubs.CreateGlobalDNSProviderOperationsStub(t)
+			providerOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.GlobalDNSProviderCollection, error) {
+				assert.Equals(t, "route53", opts.Filters["name"])
+				return &backendRancherClient.GlobalDNSProviderCollection{
+					Data: []backendRancherClient.GlobalDNSProvider{tt.existing},
+				}, nil
+			}
+			providerOperationsStub.DoReplace = func(provider *backendRancherClient.GlobalDNSProvider) (*backendRancherClient.GlobalDNSProvider, error) {
+				replacedRootDomain = provider.RootDomain
+				return provider, nil
+			}
+			testClients.ManagementClient.GlobalDNSProvider = providerOperationsStub
+			rancherClient := simpleRancherClient()
+			rancherClient._backendRancherClient = testClients.ManagementClient
+			client, err := newGlobalDNSProviderClientWithData(simpleGlobalDNSProvider, rancherClient, logrus.New().WithFields(logrus.Fields{}))
+			assert.Ok(t, err)
+
+			changed, err := client.Upgrade(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedChanged, changed)
+			assert.Equals(t, tt.wantedRootDomain, replacedRootDomain)
+		})
+	}
+}
+
+func Test_globalDNSProviderClient_providerHash(t *testing.T) {
+	providers := []rancherModel.GlobalDNSProvider{
+		{Name: "route53", Route53: &rancherModel.Route53ProviderConfig{AccessKey: "access", SecretKey: "secret"}},
+		{Name: "cloudflare", Cloudflare: &rancherModel.CloudflareProviderConfig{APIEmail: "dns@example.com", APIKey: "secret"}},
+		{Name: "alidns", Alidns: &rancherModel.AlidnsProviderConfig{AccessKey: "access", SecretKey: "secret"}},
+	}
+	for _, provider := range providers {
+		t.Run(provider.Name, func(t *testing.T) {
+			client, err := newGlobalDNSProviderClientWithData(provider, simpleRancherClient(), logrus.New().WithFields(logrus.Fields{}))
+			assert.Ok(t, err)
+			hash := client.(*globalDNSProviderClient).providerHash()
+			assert.Assert(t, hash != hashOf(provider), "The declared credentials must be kept")
+
+			rotated := provider
+			switch {
+			case provider.Route53 != nil:
+				rotated.Route53 = &rancherModel.Route53ProviderConfig{AccessKey: "other-access", SecretKey: "other-secret"}
+			case provider.Cloudflare != nil:
+				rotated.Cloudflare = &rancherModel.CloudflareProviderConfig{APIEmail: "dns@example.com", APIKey: "other-secret"}
+			case provider.Alidns != nil:
+				rotated.Alidns = &rancherModel.AlidnsProviderConfig{AccessKey: "other-access", SecretKey: "other-secret"}
+			}
+			client.SetData(rotated)
+			assert.Equals(t, hash, client.(*globalDNSProviderClient).providerHash())
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newGlobalRoleBindingClientWithData(
+	globalRoleBinding rancherModel.GlobalRoleBinding,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (GlobalRoleBindingClient, error) {
+	result, err := newGlobalRoleBindingClient(
+		globalRoleBindingName(globalRoleBinding),
+		rancherClient,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(globalRoleBinding)
+	return result, err
+}
+
+func newGlobalRoleBindingClient(
+	name string,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (GlobalRoleBindingClient, error) {
+	return &globalRoleBindingClient{
+		resourceClient: resourceClient{
+			name:   name,
+			logger: logger.WithField("global_role_binding_name", name),
+		},
+		rancherClient: rancherClient,
+	}, nil
+}
+
+// globalRoleBindingName identifies a binding by its user and global role e.g. "john/admin"
+func globalRoleBindingName(globalRoleBinding rancherModel.GlobalRoleBinding) string {
+	return globalRoleBinding.User + "/" + globalRoleBinding.GlobalRole
+}
+
+// globalRoleBindingClient binds one global role to one user, a binding is never upgraded
+// as it has no fields besides the user and the role
+type globalRoleBindingClient struct {
+	resourceClient
+	globalRoleBinding rancherModel.GlobalRoleBinding
+	rancherClient     RancherClient
+}
+
+func (client *globalRoleBindingClient) Type() string {
+	return rancherModel.RancherGlobalRoleBinding
+}
+
+func (client *globalRoleBindingClient) Exists() (bool, error) {
+	existingBinding, err := client.loadExistingBinding()
+	if err != nil {
+		return false, err
+	}
+	if existingBinding == nil {
+		client.logger.Debug("Global role binding not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *globalRoleBindingClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	userID, err := client.userID()
+	if err != nil {
+		return
+	}
+
+	client.logger.Info("Create new global role binding")
+	newBinding := backendRancherClient.GlobalRoleBinding{
+		GlobalRoleID: client.globalRoleBinding.GlobalRole,
+		UserID:       userID,
+		Labels: map[string]string{
+			"cattlectl.io/hash": hashOf(client.globalRoleBinding),
+		},
+	}
+
+	if dryRun {
+		client.logger.WithField("object", newBinding).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.GlobalRoleBinding.Create(&newBinding)
+	}
+	return err == nil, err
+}
+
+func (client *globalRoleBindingClient) Upgrade(dryRun bool) (changed bool, err error) {
+	client.logger.Debug("Skip upgrade global role binding - no changes")
+	return
+}
+
+func (client *globalRoleBindingClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingBinding, err := client.loadExistingBinding()
+	if err != nil {
+		return
+	}
+	if existingBinding == nil {
+		return changed, fmt.Errorf("Global role binding %v not found", client.name)
+	}
+	if dryRun {
+		client.logger.WithField("object", existingBinding).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.GlobalRoleBinding.Delete(existingBinding)
+	}
+	return err == nil, err
+}
+
+func (client *globalRoleBindingClient) Data() (rancherModel.GlobalRoleBinding, error) {
+	return client.globalRoleBinding, nil
+}
+
+func (client *globalRoleBindingClient) SetData(globalRoleBinding rancherModel.GlobalRoleBinding) error {
+	client.name = globalRoleBindingName(globalRoleBinding)
+	client.globalRoleBinding = globalRoleBinding
+	return nil
+}
+
+// userID resolves the declared user by its username, any user not found is expected to be a user ID e.g. u-abcde
+func (client *globalRoleBindingClient) userID() (string, error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return "", err
+	}
+	collection, err := backendClient.User.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"username": client.globalRoleBinding.User,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read user list")
+		return "", fmt.Errorf("Failed to read user list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Username == client.globalRoleBinding.User {
+			return item.ID, nil
+		}
+	}
+	return client.globalRoleBinding.User, nil
+}
+
+func (client *globalRoleBindingClient) loadExistingBinding() (*backendRancherClient.GlobalRoleBinding, error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	userID, err := client.userID()
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.GlobalRoleBinding.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"globalRoleId": client.globalRoleBinding.GlobalRole,
+			"userId":       userID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read global role binding list")
+		return nil, fmt.Errorf("Failed to read global role binding list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.GlobalRoleID == client.globalRoleBinding.GlobalRole && item.UserID == userID {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+)
+
+func Test_globalRoleBindingClient_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         string
+		users        []backendRancherClient.User
+		wantedUserID string
+	}{
+		{
+			name:         "Username",
+			user:         "deployer",
+			users:        []backendRancherClient.User{{Resource: types.Resource{ID: "u-abcde"}, Username: "deployer"}},
+			wantedUserID: "u-abcde",
+		},
+		{
+			name:         "User_ID",
+			user:         "u-fghij",
+			users:        []backendRancherClient.User{},
+			wantedUserID: "u-fghij",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			var created *backendRancherClient.GlobalRoleBinding
+			userOperationsStub := stubs.CreateUserOperationsStub(t)
+			userOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.UserCollection, error) {
+				return &backendRancherClient.UserCollection{Data: tt.users}, nil
+			}
+			globalRoleBindingOperationsStub := stubs.CreateGlobalRoleBindingOperationsStub(t)
+			globalRoleBindingOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.GlobalRoleBindingCollection, error) {
+				assert.Equals(t, tt.wantedUserID, opts.Filters["userId"])
+				return &backendRancherClient.GlobalRoleBindingCollection{}, nil
+			}
+			globalRoleBindingOperationsStub.DoCreate = func(binding *backendRancherClient.GlobalRoleBinding) (*backendRancherClient.GlobalRoleBinding, error) {
+				created = binding
+				return binding, nil
+			}
+			testClients.ManagementClient.User = userOperationsStub
+			testClients.ManagementClient.GlobalRoleBinding = globalRoleBindingOperationsStub
+			rancherClient := simpleRancherClient()
+			rancherClient._backendRancherClient = testClients.ManagementClient
+			client, err := rancherClient.GlobalRoleBinding(rancherModel.GlobalRoleBinding{User: tt.user, GlobalRole: "admin"})
+			assert.Ok(t, err)
+
+			name, err := client.Name()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.user+"/admin", name)
+			exists, err := client.Exists()
+			assert.Ok(t, err)
+			assert.Assert(t, !exists, "Global role binding must not exist")
+			_, err = client.Create(false)
+			assert.Ok(t, err)
+			assert.Equals(t, "admin", created.GlobalRoleID)
+			assert.Equals(t, tt.wantedUserID, created.UserID)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newHorizontalPodAutoscalerClientWithData(
+	horizontalPodAutoscaler projectModel.HorizontalPodAutoscaler,
+	namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (HorizontalPodAutoscalerClient, error) {
+	result, err := newHorizontalPodAutoscalerClient(
+		horizontalPodAutoscaler.Name,
+		namespace,
+		project,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(horizontalPodAutoscaler)
+	return result, err
+}
+
+func newHorizontalPodAutoscalerClient(
+	name, namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (HorizontalPodAutoscalerClient, error) {
+	return &horizontalPodAutoscalerClient{
+		namespacedResourceClient: namespacedResourceClient{
+			resourceClient: resourceClient{
+				name:   name,
+				logger: logger.WithField("horizontal_pod_autoscaler_name", name).WithField("namespace", namespace),
+			},
+			namespace: namespace,
+			project:   project,
+		},
+	}, nil
+}
+
+type horizontalPodAutoscalerClient struct {
+	namespacedResourceClient
+	horizontalPodAutoscaler projectModel.HorizontalPodAutoscaler
+}
+
+func (client *horizontalPodAutoscalerClient) Type() string {
+	return rancherModel.HorizontalPodAutoscaler
+}
+
+func (client *horizontalPodAutoscalerClient) Exists() (bool, error) {
+	existingHorizontalPodAutoscaler, err := client.loadExistingHorizontalPodAutoscaler()
+	if err != nil {
+		return false, err
+	}
+	if existingHorizontalPodAutoscaler == nil {
+		client.logger.Debug("HorizontalPodAutoscaler not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *horizontalPodAutoscalerClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	projectID, err := client.project.ID()
+	if err != nil {
+		return
+	}
+	client.logger.Info("Create new HorizontalPodAutoscaler")
+	newHorizontalPodAutoscaler, err := client.desiredHorizontalPodAutoscaler()
+	if err != nil {
+		return
+	}
+	newHorizontalPodAutoscaler.NamespaceId = namespaceID
+	newHorizontalPodAutoscaler.ProjectID = projectID
+
+	if dryRun {
+		client.logger.WithField("object", newHorizontalPodAutoscaler).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.HorizontalPodAutoscaler.Create(&newHorizontalPodAutoscaler)
+	}
+	return err == nil, err
+}
+
+func (client *horizontalPodAutoscalerClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingHorizontalPodAutoscaler, err := client.loadExistingHorizontalPodAutoscaler()
+	if err != nil {
+		return
+	}
+	if existingHorizontalPodAutoscaler == nil {
+		return changed, fmt.Errorf("HorizontalPodAutoscaler %v not found", client.name)
+	}
+	if isHorizontalPodAutoscalerUnchanged(*existingHorizontalPodAutoscaler, client.horizontalPodAutoscaler) {
+		client.logger.Debug("Skip upgrade horizontal pod autoscaler - no changes")
+		return
+	}
+	client.logger.Info("Upgrade HorizontalPodAutoscaler")
+	desiredHorizontalPodAutoscaler, err := client.desiredHorizontalPodAutoscaler()
+	if err != nil {
+		return
+	}
+	existingHorizontalPodAutoscaler.Labels = withHashLabel(existingHorizontalPodAutoscaler.Labels, hashOf(client.horizontalPodAutoscaler))
+	existingHorizontalPodAutoscaler.WorkloadId = desiredHorizontalPodAutoscaler.WorkloadId
+	existingHorizontalPodAutoscaler.MinReplicas = desiredHorizontalPodAutoscaler.MinReplicas
+	existingHorizontalPodAutoscaler.MaxReplicas = desiredHorizontalPodAutoscaler.MaxReplicas
+	existingHorizontalPodAutoscaler.Metrics = desiredHorizontalPodAutoscaler.Metrics
+
+	if dryRun {
+		client.logger.WithField("object", existingHorizontalPodAutoscaler).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.HorizontalPodAutoscaler.Replace(existingHorizontalPodAutoscaler)
+	}
+	return err == nil, err
+}
+
+func (client *horizontalPodAutoscalerClient) Desired() (interface{}, error) {
+	return client.desiredHorizontalPodAutoscaler()
+}
+
+func (client *horizontalPodAutoscalerClient) Existing() (interface{}, error) {
+	existingHorizontalPodAutoscaler, err := client.loadExistingHorizontalPodAutoscaler()
+	if err != nil || existingHorizontalPodAutoscaler == nil {
+		return nil, err
+	}
+	return *existingHorizontalPodAutoscaler, nil
+}
+
+func (client *horizontalPodAutoscalerClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *horizontalPodAutoscalerClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingHorizontalPodAutoscaler()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isHorizontalPodAutoscalerUnchanged(*existing, client.horizontalPodAutoscaler), nil
+}
+
+func (client *horizontalPodAutoscalerClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingHorizontalPodAutoscaler, err := client.loadExistingHorizontalPodAutoscaler()
+	if err != nil {
+		return
+	}
+	if existingHorizontalPodAutoscaler == nil {
+		return changed, fmt.Errorf("HorizontalPodAutoscaler %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingHorizontalPodAutoscaler).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.HorizontalPodAutoscaler.Delete(existingHorizontalPodAutoscaler)
+	}
+	return err == nil, err
+}
+
+func (client *horizontalPodAutoscalerClient) Owned() (bool, error) {
+	existingHorizontalPodAutoscaler, err := client.loadExistingHorizontalPodAutoscaler()
+	if err != nil || existingHorizontalPodAutoscaler == nil {
+		return false, err
+	}
+	return isOwned(existingHorizontalPodAutoscaler.Labels), nil
+}
+
+func (client *horizontalPodAutoscalerClient) Data() (projectModel.HorizontalPodAutoscaler, error) {
+	return client.horizontalPodAutoscaler, nil
+}
+
+func (client *horizontalPodAutoscalerClient) SetData(horizontalPodAutoscaler projectModel.HorizontalPodAutoscaler) error {
+	client.name = horizontalPodAutoscaler.Name
+	client.horizontalPodAutoscaler = horizontalPodAutoscaler
+	return nil
+}
+
+func (client *horizontalPodAutoscalerClient) desiredHorizontalPodAutoscaler() (backendProjectClient.HorizontalPodAutoscaler, error) {
+	horizontalPodAutoscaler, err := projectModel.ConvertHorizontalPodAutoscalerToProjectAPI(client.horizontalPodAutoscaler)
+	if err != nil {
+		return horizontalPodAutoscaler, err
+	}
+	horizontalPodAutoscaler.Labels = withHashLabel(horizontalPodAutoscaler.Labels, hashOf(client.horizontalPodAutoscaler))
+	return horizontalPodAutoscaler, nil
+}
+
+func (client *horizontalPodAutoscalerClient) loadExistingHorizontalPodAutoscaler() (existingHorizontalPodAutoscaler *backendProjectClient.HorizontalPodAutoscaler, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.HorizontalPodAutoscaler.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read horizontal pod autoscaler list")
+		err = fmt.Errorf("Failed to read horizontal pod autoscaler list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name && item.NamespaceId == namespaceID {
+			existingHorizontalPodAutoscaler = &item
+			return
+		}
+	}
+	return
+}
+
+func isHorizontalPodAutoscalerUnchanged(existingHorizontalPodAutoscaler backendProjectClient.HorizontalPodAutoscaler, horizontalPodAutoscaler projectModel.HorizontalPodAutoscaler) bool {
+	hash, hashExists := existingHorizontalPodAutoscaler.Labels["cattlectl.io/hash"]
+	if !hashExists {
+		return false
+	}
+	return hash == hashOf(horizontalPodAutoscaler)
+}
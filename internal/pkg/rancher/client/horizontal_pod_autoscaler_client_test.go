@@ -0,0 +1,193 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_horizontalPodAutoscalerClient_Exists(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []backendProjectClient.HorizontalPodAutoscaler
+		wanted   bool
+	}{
+		{
+			name: "Existing",
+			existing: []backendProjectClient.HorizontalPodAutoscaler{
+				{Name: "test-autoscaler", NamespaceId: "test-namespace-id"},
+			},
+			wanted: true,
+		},
+		{
+			name: "Other_Namespace",
+			existing: []backendProjectClient.HorizontalPodAutoscaler{
+				{Name: "test-autoscaler", NamespaceId: "other-namespace-id"},
+			},
+			wanted: false,
+		},
+		{
+			name:     "Not_Existing",
+			existing: []backendProjectClient.HorizontalPodAutoscaler{},
+			wanted:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := testHorizontalPodAutoscalerClient(t, tt.existing)
+			got, err := client.Exists()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wanted, got)
+		})
+	}
+}
+
+func Test_horizontalPodAutoscalerClient_Create(t *testing.T) {
+	client, stub := testHorizontalPodAutoscalerClient(t, []backendProjectClient.HorizontalPodAutoscaler{})
+	var created *backendProjectClient.HorizontalPodAutoscaler
+	stub.DoCreate = func(horizontalPodAutoscaler *backendProjectClient.HorizontalPodAutoscaler) (*backendProjectClient.HorizontalPodAutoscaler, error) {
+		created = horizontalPodAutoscaler
+		return horizontalPodAutoscaler, nil
+	}
+	changed, err := client.Create(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Create should report a change")
+	assert.Equals(t, "test-autoscaler", created.Name)
+	assert.Equals(t, "test-namespace-id", created.NamespaceId)
+	assert.Equals(t, "test-project-id", created.ProjectID)
+	assert.Equals(t, "deployment:test-namespace:test-autoscaler", created.WorkloadId)
+	assert.Equals(t, int64(2), *created.MinReplicas)
+	assert.Equals(t, int64(10), created.MaxReplicas)
+	assert.Equals(t, 1, len(created.Metrics))
+	assert.Equals(t, "cpu", created.Metrics[0].Name)
+	assert.Equals(t, int64(80), *created.Metrics[0].Target.Utilization)
+	assert.Equals(t, hashOf(client.horizontalPodAutoscaler), created.Labels["cattlectl.io/hash"])
+}
+
+func Test_horizontalPodAutoscalerClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name        string
+		hash        string
+		wantChanged bool
+	}{
+		{
+			name:        "Changed",
+			hash:        "outdated-hash",
+			wantChanged: true,
+		},
+		{
+			name:        "Unchanged",
+			wantChanged: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := backendProjectClient.HorizontalPodAutoscaler{
+				Name:            "test-autoscaler",
+				NamespaceId:     "test-namespace-id",
+				MaxReplicas:     4,
+				CurrentReplicas: 3,
+				Labels:          map[string]string{"cattlectl.io/hash": tt.hash},
+			}
+			client, stub := testHorizontalPodAutoscalerClient(t, []backendProjectClient.HorizontalPodAutoscaler{existing})
+			if tt.hash == "" {
+				existing.Labels["cattlectl.io/hash"] = hashOf(client.horizontalPodAutoscaler)
+			}
+			var replaced *backendProjectClient.HorizontalPodAutoscaler
+			stub.DoReplace = func(horizontalPodAutoscaler *backendProjectClient.HorizontalPodAutoscaler) (*backendProjectClient.HorizontalPodAutoscaler, error) {
+				replaced = horizontalPodAutoscaler
+				return horizontalPodAutoscaler, nil
+			}
+			changed, err := client.Upgrade(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantChanged, changed)
+			if tt.wantChanged {
+				assert.Equals(t, int64(10), replaced.MaxReplicas)
+				assert.Equals(t, int64(3), replaced.CurrentReplicas)
+				assert.Equals(t, hashOf(client.horizontalPodAutoscaler), replaced.Labels["cattlectl.io/hash"])
+			}
+		})
+	}
+}
+
+func Test_horizontalPodAutoscalerClient_Delete(t *testing.T) {
+	client, stub := testHorizontalPodAutoscalerClient(t, []backendProjectClient.HorizontalPodAutoscaler{
+		{Name: "test-autoscaler", NamespaceId: "test-namespace-id", Labels: map[string]string{"cattlectl.io/hash": "some-hash"}},
+	})
+	var deleted *backendProjectClient.HorizontalPodAutoscaler
+	stub.DoDelete = func(horizontalPodAutoscaler *backendProjectClient.HorizontalPodAutoscaler) error {
+		deleted = horizontalPodAutoscaler
+		return nil
+	}
+	owned, err := client.Owned()
+	assert.Ok(t, err)
+	assert.Assert(t, owned, "HorizontalPodAutoscaler should be owned")
+	changed, err := client.Delete(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Delete should report a change")
+	assert.Equals(t, "test-autoscaler", deleted.Name)
+}
+
+func testHorizontalPodAutoscalerClient(t *testing.T, existing []backendProjectClient.HorizontalPodAutoscaler) (*horizontalPodAutoscalerClient, *stubs.HorizontalPodAutoscalerOperationsStub) {
+	testClients := stubs.CreateBackendStubs(t)
+	horizontalPodAutoscalerOperationsStub := stubs.CreateHorizontalPodAutoscalerOperationsStub(t)
+	horizontalPodAutoscalerOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.HorizontalPodAutoscalerCollection, error) {
+		assert.Equals(t, map[string]interface{}{
+			"name":        "test-autoscaler",
+			"namespaceId": "test-namespace-id",
+		}, opts.Filters)
+		return &backendProjectClient.HorizontalPodAutoscalerCollection{Data: existing}, nil
+	}
+	testClients.ProjectClient.HorizontalPodAutoscaler = horizontalPodAutoscalerOperationsStub
+	minReplicas := int64(2)
+	utilization := int64(80)
+	result, err := newHorizontalPodAutoscalerClientWithData(
+		projectModel.HorizontalPodAutoscaler{
+			Name:       "test-autoscaler",
+			WorkloadID: "deployment:test-namespace:test-autoscaler",
+			Autoscaling: projectModel.Autoscaling{
+				MinReplicas: &minReplicas,
+				MaxReplicas: 10,
+				Metrics: []projectModel.AutoscalingMetric{
+					{
+						Type:   "Resource",
+						Name:   "cpu",
+						Target: &projectModel.MetricTarget{Type: "Utilization", Utilization: &utilization},
+					},
+				},
+			},
+		},
+		"test-namespace",
+		&projectClient{
+			resourceClient: resourceClient{
+				name: "test-project-name",
+				id:   "test-project-id",
+			},
+			_backendProjectClient: testClients.ProjectClient,
+		},
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	horizontalPodAutoscalerClientResult := result.(*horizontalPodAutoscalerClient)
+	horizontalPodAutoscalerClientResult.namespaceID = "test-namespace-id"
+	return horizontalPodAutoscalerClientResult, horizontalPodAutoscalerOperationsStub
+}
@@ -0,0 +1,253 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newIngressClientWithData(
+	ingress projectModel.Ingress,
+	namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (IngressClient, error) {
+	result, err := newIngressClient(
+		ingress.Name,
+		namespace,
+		project,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(ingress)
+	return result, err
+}
+
+func newIngressClient(
+	name, namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (IngressClient, error) {
+	return &ingressClient{
+		namespacedResourceClient: namespacedResourceClient{
+			resourceClient: resourceClient{
+				name:   name,
+				logger: logger.WithField("ingress_name", name).WithField("namespace", namespace),
+			},
+			namespace: namespace,
+			project:   project,
+		},
+	}, nil
+}
+
+type ingressClient struct {
+	namespacedResourceClient
+	projectDeclaration
+	ingress projectModel.Ingress
+}
+
+func (client *ingressClient) Type() string {
+	return rancherModel.IngressKind
+}
+
+func (client *ingressClient) Exists() (bool, error) {
+	existingIngress, err := client.loadExistingIngress()
+	if err != nil {
+		return false, err
+	}
+	if existingIngress == nil {
+		client.logger.Debug("Ingress not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *ingressClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	projectID, err := client.project.ID()
+	if err != nil {
+		return
+	}
+	client.logger.Info("Create new Ingress")
+	newIngress, err := client.desiredIngress()
+	if err != nil {
+		return
+	}
+	newIngress.ProjectID = projectID
+
+	if dryRun {
+		client.logger.WithField("object", newIngress).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.Ingress.Create(&newIngress)
+	}
+	return err == nil, err
+}
+
+func (client *ingressClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingIngress, err := client.loadExistingIngress()
+	if err != nil {
+		return
+	}
+	if existingIngress == nil {
+		return changed, fmt.Errorf("Ingress %v not found", client.name)
+	}
+	if isIngressUnchanged(*existingIngress, client.ingress) && client.hasProjectLabel(existingIngress.Labels) {
+		client.logger.Debug("Skip upgrade ingress - no changes")
+		return
+	}
+	client.logger.Info("Upgrade Ingress")
+	desiredIngress, err := client.desiredIngress()
+	if err != nil {
+		return
+	}
+	existingIngress.Labels = desiredIngress.Labels
+	existingIngress.Annotations = desiredIngress.Annotations
+	existingIngress.DefaultBackend = desiredIngress.DefaultBackend
+	existingIngress.Rules = desiredIngress.Rules
+	existingIngress.TLS = desiredIngress.TLS
+
+	if dryRun {
+		client.logger.WithField("object", existingIngress).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.Ingress.Replace(existingIngress)
+	}
+	return err == nil, err
+}
+
+func (client *ingressClient) Desired() (interface{}, error) {
+	return client.desiredIngress()
+}
+
+func (client *ingressClient) Existing() (interface{}, error) {
+	existingIngress, err := client.loadExistingIngress()
+	if err != nil || existingIngress == nil {
+		return nil, err
+	}
+	return *existingIngress, nil
+}
+
+func (client *ingressClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *ingressClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingIngress()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isIngressUnchanged(*existing, client.ingress) && client.hasProjectLabel(existing.Labels), nil
+}
+
+func (client *ingressClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingIngress, err := client.loadExistingIngress()
+	if err != nil {
+		return
+	}
+	if existingIngress == nil {
+		return changed, fmt.Errorf("Ingress %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingIngress).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.Ingress.Delete(existingIngress)
+	}
+	return err == nil, err
+}
+
+func (client *ingressClient) Owned() (bool, error) {
+	existingIngress, err := client.loadExistingIngress()
+	if err != nil || existingIngress == nil {
+		return false, err
+	}
+	return isDeclaredByProject(existingIngress.Labels), nil
+}
+
+func (client *ingressClient) Data() (projectModel.Ingress, error) {
+	return client.ingress, nil
+}
+
+func (client *ingressClient) SetData(ingress projectModel.Ingress) error {
+	client.name = ingress.Name
+	client.ingress = ingress
+	return nil
+}
+
+func (client *ingressClient) desiredIngress() (backendProjectClient.Ingress, error) {
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return backendProjectClient.Ingress{}, err
+	}
+	ingress := projectModel.ConvertIngressToProjectAPI(client.ingress, namespaceID)
+	ingress.Labels = client.withProjectLabel(withHashLabel(ingress.Labels, hashOf(client.ingress)))
+	return ingress, nil
+}
+
+func (client *ingressClient) loadExistingIngress() (existingIngress *backendProjectClient.Ingress, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.Ingress.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read ingress list")
+		err = fmt.Errorf("Failed to read ingress list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name && item.NamespaceId == namespaceID {
+			existingIngress = &item
+			return
+		}
+	}
+	return
+}
+
+func isIngressUnchanged(existingIngress backendProjectClient.Ingress, ingress projectModel.Ingress) bool {
+	hash, hashExists := existingIngress.Labels["cattlectl.io/hash"]
+	if !hashExists {
+		return false
+	}
+	return hash == hashOf(ingress)
+}
@@ -0,0 +1,150 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_ingressClient_Exists(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []backendProjectClient.Ingress
+		wanted   bool
+	}{
+		{
+			name: "Existing",
+			existing: []backendProjectClient.Ingress{
+				{Name: "test-ingress", NamespaceId: "test-namespace-id"},
+			},
+			wanted: true,
+		},
+		{
+			name:     "Not_Existing",
+			existing: []backendProjectClient.Ingress{},
+			wanted:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := testIngressClient(t, tt.existing)
+			got, err := client.Exists()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wanted, got)
+		})
+	}
+}
+
+func Test_ingressClient_Create(t *testing.T) {
+	client, stub := testIngressClient(t, []backendProjectClient.Ingress{})
+	var created *backendProjectClient.Ingress
+	stub.DoCreate = func(ingress *backendProjectClient.Ingress) (*backendProjectClient.Ingress, error) {
+		created = ingress
+		return ingress, nil
+	}
+	changed, err := client.Create(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Create should report a change")
+	assert.Equals(t, "test-namespace-id", created.NamespaceId)
+	assert.Equals(t, "test-project-id", created.ProjectID)
+	assert.Equals(t, []backendProjectClient.IngressRule{
+		{
+			Host: "www.example.com",
+			Paths: []backendProjectClient.HTTPIngressPath{
+				{Path: "/", ServiceID: "test-namespace-id:web", TargetPort: intstr.FromInt(80)},
+			},
+		},
+	}, created.Rules)
+	assert.Equals(t, []backendProjectClient.IngressTLS{
+		{CertificateID: "test-namespace-id:web-tls", Hosts: []string{"www.example.com"}},
+	}, created.TLS)
+	assert.Equals(t, hashOf(client.ingress), created.Labels["cattlectl.io/hash"])
+}
+
+func Test_ingressClient_Upgrade(t *testing.T) {
+	client, stub := testIngressClient(t, []backendProjectClient.Ingress{
+		{
+			Name:        "test-ingress",
+			NamespaceId: "test-namespace-id",
+			Labels:      map[string]string{"cattlectl.io/hash": "outdated-hash"},
+		},
+	})
+	var replaced *backendProjectClient.Ingress
+	stub.DoReplace = func(ingress *backendProjectClient.Ingress) (*backendProjectClient.Ingress, error) {
+		replaced = ingress
+		return ingress, nil
+	}
+	changed, err := client.Upgrade(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Upgrade should report a change")
+	assert.Equals(t, "www.example.com", replaced.Rules[0].Host)
+	assert.Equals(t, hashOf(client.ingress), replaced.Labels["cattlectl.io/hash"])
+}
+
+func testIngressClient(t *testing.T, existing []backendProjectClient.Ingress) (*ingressClient, *stubs.IngressOperationsStub) {
+	testClients := stubs.CreateBackendStubs(t)
+	ingressOperationsStub := stubs.CreateIngressOperationsStub(t)
+	ingressOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.IngressCollection, error) {
+		assert.Equals(t, map[string]interface{}{
+			"name":        "test-ingress",
+			"namespaceId": "test-namespace-id",
+		}, opts.Filters)
+		return &backendProjectClient.IngressCollection{Data: existing}, nil
+	}
+	testClients.ProjectClient.Ingress = ingressOperationsStub
+	result, err := newIngressClientWithData(
+		projectModel.Ingress{
+			Name: "test-ingress",
+			Rules: []projectModel.IngressRule{
+				{
+					Host: "www.example.com",
+					Paths: []projectModel.IngressPath{
+						{
+							Path: "/",
+							IngressBackend: projectModel.IngressBackend{
+								Service:    "web",
+								TargetPort: projectModel.IntOrString(intstr.FromInt(80)),
+							},
+						},
+					},
+				},
+			},
+			TLS: []projectModel.IngressTLS{
+				{Certificate: "web-tls", Hosts: []string{"www.example.com"}},
+			},
+		},
+		"test-namespace",
+		&projectClient{
+			resourceClient: resourceClient{
+				name: "test-project-name",
+				id:   "test-project-id",
+			},
+			_backendProjectClient: testClients.ProjectClient,
+		},
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	ingressClientResult := result.(*ingressClient)
+	ingressClientResult.namespaceID = "test-namespace-id"
+	return ingressClientResult, ingressOperationsStub
+}
@@ -17,6 +17,7 @@ package client
 import (
 	"fmt"
 
+	"github.com/bitgrip/cattlectl/internal/pkg/config"
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 	"github.com/rancher/norman/types"
@@ -112,18 +113,60 @@ func (client *jobClient) Create(dryRun bool) (changed bool, err error) {
 		return
 	}
 	pattern.NamespaceId = namespaceID
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.job))
 
 	if dryRun {
 		client.logger.WithField("object", pattern).Info("Do Dry-Run Create")
-	} else {
-		_, err = backendClient.Job.Create(&pattern)
+	} else if _, err = backendClient.Job.Create(&pattern); err == nil {
+		err = waitUntilReady(client.project.config(), client.logger, client.ready)
 	}
 	return err == nil, err
 }
 
 func (client *jobClient) Upgrade(dryRun bool) (changed bool, err error) {
-	client.logger.Warn("Skip change existing job")
-	return
+	if client.job.UpgradeStrategy != projectModel.JobUpgradeStrategyRecreate {
+		client.logger.Warn("Skip change existing job")
+		return
+	}
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingJob, err := client.loadExistingJob()
+	if err != nil {
+		return
+	}
+	if existingJob == nil {
+		return changed, fmt.Errorf("Job %v not found", client.name)
+	}
+	if isJobUnchanged(*existingJob, client.job) {
+		client.logger.Debug("Skip recreate job - no changes")
+		return
+	}
+	client.logger.Info("Recreate job")
+	if dryRun {
+		client.logger.WithField("object", existingJob).Info("Do Dry-Run Delete")
+	} else if err = backendClient.Job.Delete(existingJob); err != nil {
+		client.logger.WithError(err).Error("Failed to delete job")
+		return changed, fmt.Errorf("Failed to delete job, %v", err)
+	} else if err = client.waitUntilDeleted(); err != nil {
+		return true, err
+	}
+	return client.Create(dryRun)
+}
+
+// waitUntilDeleted waits until the deleted job is gone, the deletion is asynchronous
+// and a job with the same name can not be created before.
+// Without --wait the default timeout is used.
+func (client *jobClient) waitUntilDeleted() error {
+	waitConfig := client.project.config()
+	if waitConfig.WaitTimeout <= 0 {
+		waitConfig.WaitTimeout = config.DefaultTimeout
+	}
+	return waitUntilReady(waitConfig, client.logger, func() (bool, error) {
+		existingJob, err := client.loadExistingJob()
+		return existingJob == nil, err
+	})
 }
 
 func (client *jobClient) Delete(dryRun bool) (changed bool, err error) {
@@ -142,16 +185,78 @@ func (client *jobClient) Delete(dryRun bool) (changed bool, err error) {
 	return err == nil, err
 }
 
+func (client *jobClient) Desired() (interface{}, error) {
+	pattern, err := projectModel.ConvertJobToProjectAPI(client.job)
+	if err != nil {
+		return nil, err
+	}
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.job))
+	return pattern, nil
+}
+
+func (client *jobClient) Existing() (interface{}, error) {
+	existingJob, err := client.loadExistingJob()
+	if err != nil || existingJob == nil {
+		return nil, err
+	}
+	return *existingJob, nil
+}
+
+func (client *jobClient) SkipsUpgrade() bool {
+	return client.job.UpgradeStrategy != projectModel.JobUpgradeStrategyRecreate
+}
+
+func (client *jobClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingJob()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isJobUnchanged(*existing, client.job), nil
+}
+
 func (client *jobClient) Data() (projectModel.Job, error) {
 	return client.job, nil
 }
 
 func (client *jobClient) SetData(job projectModel.Job) error {
+	switch job.UpgradeStrategy {
+	case "", projectModel.JobUpgradeStrategySkip, projectModel.JobUpgradeStrategyRecreate:
+	default:
+		return fmt.Errorf("Unknown job upgrade strategy %s", job.UpgradeStrategy)
+	}
 	client.name = job.Name
 	client.job = job
 	return nil
 }
 
+// ready is true if the job completed, a failed job fails with the message of its condition
+func (client *jobClient) ready() (bool, error) {
+	existingJob, err := client.loadExistingJob()
+	if err != nil {
+		return false, err
+	}
+	if existingJob == nil {
+		return false, fmt.Errorf("Job %v not found", client.name)
+	}
+	if err = transitioningError(existingJob.State, existingJob.Transitioning, existingJob.TransitioningMessage); err != nil {
+		return false, err
+	}
+	status := existingJob.JobStatus
+	if status == nil {
+		return false, nil
+	}
+	for _, condition := range status.Conditions {
+		if condition.Type == "Failed" && condition.Status == "True" {
+			return false, fmt.Errorf("Job failed, %s", condition.Message)
+		}
+	}
+	completions := int64(1)
+	if existingJob.JobConfig != nil && existingJob.JobConfig.Completions != nil {
+		completions = *existingJob.JobConfig.Completions
+	}
+	return status.Succeeded >= completions, nil
+}
+
 func (client *jobClient) loadExistingJob() (existingJob *backendProjectClient.Job, err error) {
 	backendClient, err := client.project.backendProjectClient()
 	if err != nil {
@@ -181,3 +286,11 @@ func (client *jobClient) loadExistingJob() (existingJob *backendProjectClient.Jo
 	existingJob = &collection.Data[0]
 	return
 }
+
+func isJobUnchanged(existingJob backendProjectClient.Job, job projectModel.Job) bool {
+	hash, hashExists := existingJob.WorkloadLabels["cattlectl.io/hash"]
+	if !hashExists {
+		return false
+	}
+	return hash == hashOf(job)
+}
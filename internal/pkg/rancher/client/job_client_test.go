@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/assert"
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
@@ -109,6 +110,149 @@ func Test_jobClient_Create(t *testing.T) {
 	}
 }
 
+func Test_jobClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name          string
+		strategy      string
+		existingHash  string
+		dryRun        bool
+		deletePolls   int
+		wantedChanged bool
+		wantedDelete  bool
+		wantedCreate  bool
+	}{
+		{
+			name:          "Skip_By_Default",
+			strategy:      "",
+			existingHash:  "outdated-hash",
+			wantedChanged: false,
+		},
+		{
+			name:          "Recreate_Unchanged",
+			strategy:      projectModel.JobUpgradeStrategyRecreate,
+			existingHash:  "current",
+			wantedChanged: false,
+		},
+		{
+			name:          "Recreate_Changed",
+			strategy:      projectModel.JobUpgradeStrategyRecreate,
+			existingHash:  "outdated-hash",
+			wantedChanged: true,
+			wantedDelete:  true,
+			wantedCreate:  true,
+		},
+		{
+			name:          "Recreate_Changed_Delayed_Delete",
+			strategy:      projectModel.JobUpgradeStrategyRecreate,
+			existingHash:  "outdated-hash",
+			deletePolls:   2,
+			wantedChanged: true,
+			wantedDelete:  true,
+			wantedCreate:  true,
+		},
+		{
+			name:          "Recreate_Changed_Dry_Run",
+			strategy:      projectModel.JobUpgradeStrategyRecreate,
+			existingHash:  "outdated-hash",
+			dryRun:        true,
+			wantedChanged: true,
+		},
+	}
+	defer func(interval time.Duration) { readyPollInterval = interval }(readyPollInterval)
+	readyPollInterval = time.Millisecond
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := projectModel.Job{UpgradeStrategy: tt.strategy}
+			job.Name = "existing-job"
+			existingHash := tt.existingHash
+			if existingHash == "current" {
+				existingHash = hashOf(job)
+			}
+			deleted, created := false, false
+			client := upgradeJobClient(t, existingHash, tt.deletePolls, &deleted, &created)
+			assert.Ok(t, client.SetData(job))
+			changed, err := client.Upgrade(tt.dryRun)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedChanged, changed)
+			assert.Equals(t, tt.wantedDelete, deleted)
+			assert.Equals(t, tt.wantedCreate, created)
+		})
+	}
+}
+
+func Test_jobClient_SetData(t *testing.T) {
+	client := &jobClient{}
+	job := projectModel.Job{UpgradeStrategy: "unknown"}
+	assert.NotOk(t, client.SetData(job), "Unknown job upgrade strategy unknown")
+}
+
+func Test_jobClient_ready(t *testing.T) {
+	completions := int64(2)
+	tests := []struct {
+		name      string
+		job       backendProjectClient.Job
+		wanted    bool
+		wantedErr string
+	}{
+		{
+			name:   "Running",
+			job:    backendProjectClient.Job{JobStatus: &backendProjectClient.JobStatus{Active: 1}},
+			wanted: false,
+		},
+		{
+			name:   "Completed",
+			job:    backendProjectClient.Job{JobStatus: &backendProjectClient.JobStatus{Succeeded: 1}},
+			wanted: true,
+		},
+		{
+			name: "Partly_Completed",
+			job: backendProjectClient.Job{
+				JobConfig: &backendProjectClient.JobConfig{Completions: &completions},
+				JobStatus: &backendProjectClient.JobStatus{Succeeded: 1},
+			},
+			wanted: false,
+		},
+		{
+			name: "Failed",
+			job: backendProjectClient.Job{JobStatus: &backendProjectClient.JobStatus{
+				Failed: 6,
+				Conditions: []backendProjectClient.JobCondition{
+					{Type: "Failed", Status: "True", Message: "Job has reached the specified backoff limit"},
+				},
+			}},
+			wantedErr: "Job failed, Job has reached the specified backoff limit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			jobOperationsStub := stubs.CreateJobOperationsStub(t)
+			jobOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.JobCollection, error) {
+				job := tt.job
+				job.Name = "existing-job"
+				job.NamespaceId = "test-namespace-id"
+				return &backendProjectClient.JobCollection{
+					Data: []backendProjectClient.Job{job},
+				}, nil
+			}
+			testClients.ProjectClient.Job = jobOperationsStub
+			projectClient := simpleProjectClient()
+			projectClient._backendProjectClient = testClients.ProjectClient
+			result, err := newJobClient("existing-job", "test-namespace", projectClient, logrus.New().WithFields(logrus.Fields{}))
+			assert.Ok(t, err)
+			client := result.(*jobClient)
+			client.namespaceID = "test-namespace-id"
+			ready, err := client.ready()
+			if tt.wantedErr != "" {
+				assert.NotOk(t, err, tt.wantedErr)
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wanted, ready)
+			}
+		})
+	}
+}
+
 func existingJobClient(t *testing.T, expectedListOpts *types.ListOpts) *jobClient {
 	const (
 		projectID   = "test-project-id"
@@ -214,3 +358,56 @@ func notExistingJobClient(t *testing.T, expectedListOpts *types.ListOpts) *jobCl
 	jobClientResult.namespaceID = "test-namespace-id"
 	return jobClientResult
 }
+
+// upgradeJobClient lists the existing job until deletePolls lists after its deletion
+func upgradeJobClient(t *testing.T, existingHash string, deletePolls int, deleted, created *bool) *jobClient {
+	testClients := stubs.CreateBackendStubs(t)
+	existingJob := backendProjectClient.Job{
+		Resource: types.Resource{
+			ID: "test-namespace-id:existing-job",
+		},
+		Name:        "existing-job",
+		NamespaceId: "test-namespace-id",
+		WorkloadLabels: map[string]string{
+			"cattlectl.io/hash": existingHash,
+		},
+	}
+
+	jobOperationsStub := stubs.CreateJobOperationsStub(t)
+	jobOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.JobCollection, error) {
+		if *deleted {
+			if deletePolls == 0 {
+				return &backendProjectClient.JobCollection{}, nil
+			}
+			deletePolls--
+		}
+		return &backendProjectClient.JobCollection{
+			Data: []backendProjectClient.Job{existingJob},
+		}, nil
+	}
+	jobOperationsStub.DoDelete = func(job *backendProjectClient.Job) error {
+		assert.Equals(t, existingJob.ID, job.ID)
+		*deleted = true
+		return nil
+	}
+	jobOperationsStub.DoCreate = func(job *backendProjectClient.Job) (*backendProjectClient.Job, error) {
+		assert.Assert(t, *deleted, "Expected delete before create")
+		assert.Equals(t, 0, deletePolls)
+		assert.Equals(t, "test-namespace-id", job.NamespaceId)
+		*created = true
+		return job, nil
+	}
+	testClients.ProjectClient.Job = jobOperationsStub
+	projectClient := simpleProjectClient()
+	projectClient._backendProjectClient = testClients.ProjectClient
+	result, err := newJobClient(
+		"existing-job",
+		"test-namespace",
+		projectClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	jobClientResult := result.(*jobClient)
+	jobClientResult.namespaceID = "test-namespace-id"
+	return jobClientResult
+}
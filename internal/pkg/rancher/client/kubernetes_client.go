@@ -0,0 +1,144 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// kubernetesClient accesses the kubernetes API of a cluster through the rancher proxy.
+// It is used for resources which are not part of the rancher API.
+type kubernetesClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// kubernetesObjectMeta is the metadata of a kubernetes object
+type kubernetesObjectMeta struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+	Generation      int64             `json:"generation,omitempty"`
+}
+
+// kubernetesStatus is returned by the kubernetes API on failures
+type kubernetesStatus struct {
+	Message string `json:"message"`
+}
+
+func createKubernetesClient(config RancherConfig, clusterID string) (*kubernetesClient, error) {
+	logrus.WithFields(logrus.Fields{
+		"rancher.url":        config.RancherURL,
+		"rancher.cluster_id": clusterID,
+	}).Debug("Create Kubernetes Client")
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.Insecure,
+	}
+	if config.CACerts != "" {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM([]byte(config.CACerts)) {
+			return nil, fmt.Errorf("Failed to create kubernetes client, invalid CA certs")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	serverURL := strings.TrimSuffix(strings.TrimSuffix(config.RancherURL, "/"), "/v3")
+	return &kubernetesClient{
+		baseURL: serverURL + "/k8s/clusters/" + clusterID,
+		token:   config.AccessKey + ":" + config.SecretKey,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}, nil
+}
+
+// get reads the object at path into result and reports if it was found
+func (client *kubernetesClient) get(path string, result interface{}) (found bool, err error) {
+	status, err := client.do(http.MethodGet, path, nil, result)
+	if status == http.StatusNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (client *kubernetesClient) create(path string, object interface{}) error {
+	_, err := client.do(http.MethodPost, path, object, nil)
+	return err
+}
+
+func (client *kubernetesClient) replace(path string, object interface{}) error {
+	_, err := client.do(http.MethodPut, path, object, nil)
+	return err
+}
+
+func (client *kubernetesClient) delete(path string) error {
+	_, err := client.do(http.MethodDelete, path, nil, nil)
+	return err
+}
+
+func (client *kubernetesClient) do(method, path string, body, result interface{}) (status int, err error) {
+	var requestBody []byte
+	if body != nil {
+		if requestBody, err = json.Marshal(body); err != nil {
+			return
+		}
+	}
+	request, err := http.NewRequest(method, client.baseURL+path, bytes.NewReader(requestBody))
+	if err != nil {
+		return
+	}
+	request.Header.Set("Authorization", "Bearer "+client.token)
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+	status = response.StatusCode
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return
+	}
+	if status >= http.StatusMultipleChoices {
+		failure := kubernetesStatus{}
+		if json.Unmarshal(responseBody, &failure) != nil || failure.Message == "" {
+			failure.Message = string(responseBody)
+		}
+		return status, fmt.Errorf("%s %s failed with %s, %s", method, path, response.Status, failure.Message)
+	}
+	if result != nil {
+		err = json.Unmarshal(responseBody, result)
+	}
+	return
+}
@@ -0,0 +1,111 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+)
+
+func Test_kubernetesClient_get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equals(t, "Bearer access:secret", request.Header.Get("Authorization"))
+		switch request.URL.Path {
+		case "/k8s/clusters/" + simpleClusterID + "/api/v1/namespaces/found":
+			writer.Write([]byte(`{"metadata":{"name":"found"}}`))
+		case "/k8s/clusters/" + simpleClusterID + "/api/v1/namespaces/forbidden":
+			writer.WriteHeader(http.StatusForbidden)
+			writer.Write([]byte(`{"kind":"Status","message":"access denied"}`))
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := createKubernetesClient(RancherConfig{
+		RancherURL: server.URL + "/v3",
+		AccessKey:  "access",
+		SecretKey:  "secret",
+	}, simpleClusterID)
+	assert.Ok(t, err)
+
+	tests := []struct {
+		name        string
+		path        string
+		wantedFound bool
+		wantedName  string
+		wantErr     bool
+		wantedErr   string
+	}{
+		{
+			name:        "found",
+			path:        "/api/v1/namespaces/found",
+			wantedFound: true,
+			wantedName:  "found",
+		},
+		{
+			name:        "not-found",
+			path:        "/api/v1/namespaces/unknown",
+			wantedFound: false,
+		},
+		{
+			name:      "failure",
+			path:      "/api/v1/namespaces/forbidden",
+			wantErr:   true,
+			wantedErr: "GET /api/v1/namespaces/forbidden failed with 403 Forbidden, access denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := struct {
+				Metadata kubernetesObjectMeta `json:"metadata"`
+			}{}
+			found, err := client.get(tt.path, &result)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wantedFound, found)
+				assert.Equals(t, tt.wantedName, result.Metadata.Name)
+			}
+		})
+	}
+}
+
+// newWorkloadServer serves the metadata of the workload at path with the given generation
+func newWorkloadServer(t *testing.T, path string, generation int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet || request.URL.Path != "/k8s/clusters/"+simpleClusterID+path {
+			t.Errorf("Unexpected request %s %s", request.Method, request.URL.Path)
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(writer, `{"metadata":{"generation":%d}}`, generation)
+	}))
+}
+
+// kubernetesProjectClient is a project client whose kubernetes client uses the server at serverURL
+func kubernetesProjectClient(t *testing.T, serverURL string) *projectClient {
+	kubernetesClient, err := createKubernetesClient(RancherConfig{RancherURL: serverURL}, simpleClusterID)
+	assert.Ok(t, err)
+	clusterClient := simpleClusterClient()
+	clusterClient._backendKubernetesClient = kubernetesClient
+	projectClient := simpleProjectClient()
+	projectClient.clusterClient = clusterClient
+	return projectClient
+}
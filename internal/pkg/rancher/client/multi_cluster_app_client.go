@@ -0,0 +1,401 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+const multiClusterAppTemplateVersionID = "cattle-global-data:%s-%s-%s"
+
+// defaultMultiClusterAppRoles are the roles of the app in the target projects if no roles are declared
+var defaultMultiClusterAppRoles = []string{"project-member"}
+
+func newMultiClusterAppClientWithData(
+	multiClusterApp rancherModel.MultiClusterApp,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (MultiClusterAppClient, error) {
+	result, err := newMultiClusterAppClient(
+		multiClusterApp.Name,
+		rancherClient,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(multiClusterApp)
+	return result, err
+}
+
+func newMultiClusterAppClient(
+	name string,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (MultiClusterAppClient, error) {
+	return &multiClusterAppClient{
+		resourceClient: resourceClient{
+			name:   name,
+			logger: logger.WithField("multi_cluster_app_name", name),
+		},
+		rancherClient: rancherClient,
+	}, nil
+}
+
+// multiClusterAppClient manages a multi cluster app, the targets of an existing app are
+// changed by the actions addProjects and removeProjects
+type multiClusterAppClient struct {
+	resourceClient
+	multiClusterApp rancherModel.MultiClusterApp
+	rancherClient   RancherClient
+}
+
+func (client *multiClusterAppClient) Type() string {
+	return rancherModel.MultiClusterAppKind
+}
+
+func (client *multiClusterAppClient) ID() (string, error) {
+	client.idLock.Lock()
+	defer client.idLock.Unlock()
+	if client.id != "" {
+		return client.id, nil
+	}
+	existingMultiClusterApp, err := client.loadExistingMultiClusterApp()
+	if err != nil {
+		return "", err
+	}
+	if existingMultiClusterApp == nil {
+		return "", fmt.Errorf("Multi cluster app %v not found", client.name)
+	}
+	client.id = existingMultiClusterApp.ID
+	return client.id, nil
+}
+
+func (client *multiClusterAppClient) Exists() (bool, error) {
+	existingMultiClusterApp, err := client.loadExistingMultiClusterApp()
+	if err != nil {
+		return false, err
+	}
+	if existingMultiClusterApp == nil {
+		client.logger.Debug("Multi cluster app not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *multiClusterAppClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	projectIDs, err := client.targetProjectIDs()
+	if err != nil {
+		return
+	}
+
+	client.logger.Info("Create new multi cluster app")
+	newMultiClusterApp := backendRancherClient.MultiClusterApp{
+		Name: client.multiClusterApp.Name,
+		Labels: map[string]string{
+			"cattlectl.io/hash": hashOf(client.multiClusterApp),
+		},
+	}
+	for _, projectID := range projectIDs {
+		newMultiClusterApp.Targets = append(newMultiClusterApp.Targets, backendRancherClient.Target{ProjectID: projectID})
+	}
+	if err = client.applyMultiClusterApp(&newMultiClusterApp); err != nil {
+		return
+	}
+
+	if dryRun {
+		client.logger.WithField("object", newMultiClusterApp).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.MultiClusterApp.Create(&newMultiClusterApp)
+	}
+	return err == nil, err
+}
+
+// Upgrade adds and removes targets first, then the other fields are replaced
+func (client *multiClusterAppClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingMultiClusterApp, err := client.loadExistingMultiClusterApp()
+	if err != nil {
+		return
+	}
+	if existingMultiClusterApp == nil {
+		return changed, fmt.Errorf("Multi cluster app %v not found", client.name)
+	}
+	if existingMultiClusterApp.Labels["cattlectl.io/hash"] == hashOf(client.multiClusterApp) {
+		client.logger.Debug("Skip upgrade multi cluster app - no changes")
+		return
+	}
+	projectIDs, err := client.targetProjectIDs()
+	if err != nil {
+		return
+	}
+	addedProjectIDs, removedProjectIDs := diffTargets(existingMultiClusterApp.Targets, projectIDs)
+	addedAnswers, err := client.projectAnswers(addedProjectIDs)
+	if err != nil {
+		return
+	}
+
+	client.logger.Info("Upgrade MultiClusterApp")
+	if len(addedProjectIDs) > 0 {
+		client.logger.WithField("projects", addedProjectIDs).Info("Add projects to multi cluster app")
+		if !dryRun {
+			if err = backendClient.MultiClusterApp.ActionAddProjects(existingMultiClusterApp, &backendRancherClient.UpdateMultiClusterAppTargetsInput{
+				Projects: addedProjectIDs,
+				Answers:  addedAnswers,
+			}); err != nil {
+				return
+			}
+		}
+	}
+	if len(removedProjectIDs) > 0 {
+		client.logger.WithField("projects", removedProjectIDs).Info("Remove projects from multi cluster app")
+		if !dryRun {
+			if err = backendClient.MultiClusterApp.ActionRemoveProjects(existingMultiClusterApp, &backendRancherClient.UpdateMultiClusterAppTargetsInput{
+				Projects: removedProjectIDs,
+			}); err != nil {
+				return
+			}
+		}
+	}
+	if !dryRun && len(addedProjectIDs)+len(removedProjectIDs) > 0 {
+		// the actions changed the app, the replace needs the current revision
+		if existingMultiClusterApp, err = backendClient.MultiClusterApp.ByID(existingMultiClusterApp.ID); err != nil {
+			return
+		}
+	}
+	existingMultiClusterApp.Labels = withHashLabel(existingMultiClusterApp.Labels, hashOf(client.multiClusterApp))
+	if err = client.applyMultiClusterApp(existingMultiClusterApp); err != nil {
+		return
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingMultiClusterApp).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.MultiClusterApp.Replace(existingMultiClusterApp)
+	}
+	return err == nil, err
+}
+
+func (client *multiClusterAppClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingMultiClusterApp, err := client.loadExistingMultiClusterApp()
+	if err != nil {
+		return
+	}
+	if existingMultiClusterApp == nil {
+		return changed, fmt.Errorf("Multi cluster app %v not found", client.name)
+	}
+	if dryRun {
+		client.logger.WithField("object", existingMultiClusterApp).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.MultiClusterApp.Delete(existingMultiClusterApp)
+	}
+	return err == nil, err
+}
+
+func (client *multiClusterAppClient) Data() (rancherModel.MultiClusterApp, error) {
+	return client.multiClusterApp, nil
+}
+
+func (client *multiClusterAppClient) SetData(multiClusterApp rancherModel.MultiClusterApp) error {
+	client.name = multiClusterApp.Name
+	client.multiClusterApp = multiClusterApp
+	return nil
+}
+
+// applyMultiClusterApp sets the declared fields besides the targets on the backend object
+func (client *multiClusterAppClient) applyMultiClusterApp(backendMultiClusterApp *backendRancherClient.MultiClusterApp) error {
+	answers, err := client.answers()
+	if err != nil {
+		return err
+	}
+	backendMultiClusterApp.TemplateVersionID = fmt.Sprintf(
+		multiClusterAppTemplateVersionID,
+		client.multiClusterApp.Catalog,
+		client.multiClusterApp.Chart,
+		client.multiClusterApp.Version,
+	)
+	backendMultiClusterApp.Answers = answers
+	backendMultiClusterApp.Roles = client.multiClusterApp.Roles
+	if len(backendMultiClusterApp.Roles) == 0 {
+		backendMultiClusterApp.Roles = defaultMultiClusterAppRoles
+	}
+	backendMultiClusterApp.RevisionHistoryLimit = client.multiClusterApp.RevisionHistoryLimit
+	backendMultiClusterApp.UpgradeStrategy = nil
+	if rollingUpdate := client.multiClusterApp.RollingUpdate; rollingUpdate != nil {
+		backendMultiClusterApp.UpgradeStrategy = &backendRancherClient.UpgradeStrategy{
+			RollingUpdate: &backendRancherClient.RollingUpdate{
+				BatchSize: rollingUpdate.BatchSize,
+				Interval:  rollingUpdate.Interval,
+			},
+		}
+	}
+	return nil
+}
+
+// answers are the global answers followed by the answers of the single targets
+func (client *multiClusterAppClient) answers() (answers []backendRancherClient.Answer, err error) {
+	if len(client.multiClusterApp.Answers) > 0 {
+		answers = append(answers, backendRancherClient.Answer{Values: client.multiClusterApp.Answers})
+	}
+	for _, targetAnswer := range client.multiClusterApp.TargetAnswers {
+		answer := backendRancherClient.Answer{Values: targetAnswer.Answers}
+		clusterName, projectName := rancherModel.SplitTarget(targetAnswer.Target)
+		if projectName == "" {
+			answer.ClusterID, err = client.clusterID(clusterName)
+		} else {
+			answer.ProjectID, err = projectIDOf(client.rancherClient, clusterName, projectName)
+		}
+		if err != nil {
+			return
+		}
+		answers = append(answers, answer)
+	}
+	return
+}
+
+// projectAnswers are the answers declared for one of the projects
+func (client *multiClusterAppClient) projectAnswers(projectIDs []string) (projectAnswers []backendRancherClient.Answer, err error) {
+	answers, err := client.answers()
+	if err != nil {
+		return
+	}
+	for _, answer := range answers {
+		for _, projectID := range projectIDs {
+			if answer.ProjectID == projectID {
+				projectAnswers = append(projectAnswers, answer)
+			}
+		}
+	}
+	return
+}
+
+func (client *multiClusterAppClient) targetProjectIDs() (projectIDs []string, err error) {
+	for _, target := range client.multiClusterApp.Targets {
+		clusterName, projectName := rancherModel.SplitTarget(target)
+		projectID, err := projectIDOf(client.rancherClient, clusterName, projectName)
+		if err != nil {
+			return nil, err
+		}
+		projectIDs = append(projectIDs, projectID)
+	}
+	return
+}
+
+func (client *multiClusterAppClient) clusterID(clusterName string) (string, error) {
+	clusterClient, err := client.rancherClient.Cluster(clusterName)
+	if err != nil {
+		return "", err
+	}
+	clusterID, err := clusterClient.ID()
+	if err != nil {
+		return "", err
+	}
+	if clusterID == "" {
+		return "", fmt.Errorf("Cluster %v not found", clusterName)
+	}
+	return clusterID, nil
+}
+
+// projectIDOf resolves the ID of a project given by the names of its cluster and itself
+func projectIDOf(rancherClient RancherClient, clusterName, projectName string) (string, error) {
+	clusterClient, err := rancherClient.Cluster(clusterName)
+	if err != nil {
+		return "", err
+	}
+	projectClient, err := clusterClient.Project(projectName)
+	if err != nil {
+		return "", err
+	}
+	projectID, err := projectClient.ID()
+	if err != nil {
+		return "", err
+	}
+	if projectID == "" {
+		return "", fmt.Errorf("Project %v of cluster %v not found", projectName, clusterName)
+	}
+	return projectID, nil
+}
+
+func (client *multiClusterAppClient) loadExistingMultiClusterApp() (*backendRancherClient.MultiClusterApp, error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.MultiClusterApp.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": client.name,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read multi cluster app list")
+		return nil, fmt.Errorf("Failed to read multi cluster app list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
+
+// diffTargets compares the project IDs of the existing targets with the declared project IDs
+func diffTargets(existingTargets []backendRancherClient.Target, projectIDs []string) (added, removed []string) {
+	existingProjectIDs := make([]string, len(existingTargets))
+	for i, target := range existingTargets {
+		existingProjectIDs[i] = target.ProjectID
+	}
+	return diffProjectIDs(existingProjectIDs, projectIDs)
+}
+
+// diffProjectIDs compares existing project IDs with the declared project IDs
+func diffProjectIDs(existingProjectIDs, projectIDs []string) (added, removed []string) {
+	declared := make(map[string]bool)
+	for _, projectID := range projectIDs {
+		declared[projectID] = true
+	}
+	existing := make(map[string]bool)
+	for _, projectID := range existingProjectIDs {
+		existing[projectID] = true
+		if !declared[projectID] {
+			removed = append(removed, projectID)
+		}
+	}
+	for _, projectID := range projectIDs {
+		if !existing[projectID] {
+			added = append(added, projectID)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return
+}
@@ -0,0 +1,204 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+var simpleMultiClusterApp = rancherModel.MultiClusterApp{
+	Name:    "wordpress",
+	Catalog: "library",
+	Chart:   "wordpress",
+	Version: "7.3.8",
+	Targets: []string{"production:web", "staging:web"},
+	Answers: map[string]string{"wordpressUsername": "admin"},
+	TargetAnswers: []rancherModel.MultiClusterAppAnswer{
+		{Target: "staging", Answers: map[string]string{"replicaCount": "1"}},
+		{Target: "production:web", Answers: map[string]string{"replicaCount": "3"}},
+	},
+	RollingUpdate: &rancherModel.RollingUpdate{BatchSize: 1, Interval: 30},
+}
+
+func Test_multiClusterAppClient_Create(t *testing.T) {
+	testClients := stubs.CreateBackendStubs(t)
+	var created *backendRancherClient.MultiClusterApp
+	multiClusterAppOperationsStub := stubs.CreateMultiClusterAppOperationsStub(t)
+	multiClusterAppOperationsStub.DoCreate = func(multiClusterApp *backendRancherClient.MultiClusterApp) (*backendRancherClient.MultiClusterApp, error) {
+		created = multiClusterApp
+		return multiClusterApp, nil
+	}
+	testClients.ManagementClient.MultiClusterApp = multiClusterAppOperationsStub
+	client, err := newMultiClusterAppClientWithData(
+		simpleMultiClusterApp,
+		newTestTargetRancherClient(testClients.ManagementClient),
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+
+	changed, err := client.Create(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Multi cluster app must be created")
+	assert.Equals(t, &backendRancherClient.MultiClusterApp{
+		Name:              "wordpress",
+		TemplateVersionID: "cattle-global-data:library-wordpress-7.3.8",
+		Targets: []backendRancherClient.Target{
+			{ProjectID: "c-prod:p-web"},
+			{ProjectID: "c-stage:p-web"},
+		},
+		Answers: []backendRancherClient.Answer{
+			{Values: map[string]string{"wordpressUsername": "admin"}},
+			{ClusterID: "c-stage", Values: map[string]string{"replicaCount": "1"}},
+			{ProjectID: "c-prod:p-web", Values: map[string]string{"replicaCount": "3"}},
+		},
+		Roles: []string{"project-member"},
+		UpgradeStrategy: &backendRancherClient.UpgradeStrategy{
+			RollingUpdate: &backendRancherClient.RollingUpdate{BatchSize: 1, Interval: 30},
+		},
+		Labels: map[string]string{"cattlectl.io/hash": hashOf(simpleMultiClusterApp)},
+	}, created)
+}
+
+func Test_multiClusterAppClient_Create_UnknownProject(t *testing.T) {
+	testClients := stubs.CreateBackendStubs(t)
+	multiClusterApp := simpleMultiClusterApp
+	multiClusterApp.Targets = []string{"production:unknown"}
+	client, err := newMultiClusterAppClientWithData(
+		multiClusterApp,
+		newTestTargetRancherClient(testClients.ManagementClient),
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+
+	_, err = client.Create(false)
+	assert.NotOk(t, err, "Project unknown of cluster production not found")
+	assert.Equals(t, "Project unknown of cluster production not found", err.Error())
+}
+
+func Test_multiClusterAppClient_Upgrade(t *testing.T) {
+	testClients := stubs.CreateBackendStubs(t)
+	existing := backendRancherClient.MultiClusterApp{
+		Resource: types.Resource{ID: "mca-wordpress"},
+		Name:     "wordpress",
+		Targets: []backendRancherClient.Target{
+			{ProjectID: "c-stage:p-web"},
+			{ProjectID: "c-test:p-web"},
+		},
+	}
+	var (
+		added    *backendRancherClient.UpdateMultiClusterAppTargetsInput
+		removed  *backendRancherClient.UpdateMultiClusterAppTargetsInput
+		replaced *backendRancherClient.MultiClusterApp
+	)
+	multiClusterAppOperationsStub := stubs.CreateMultiClusterAppOperationsStub(t)
+	multiClusterAppOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.MultiClusterAppCollection, error) {
+		return &backendRancherClient.MultiClusterAppCollection{Data: []backendRancherClient.MultiClusterApp{existing}}, nil
+	}
+	multiClusterAppOperationsStub.DoActionAddProjects = func(multiClusterApp *backendRancherClient.MultiClusterApp, input *backendRancherClient.UpdateMultiClusterAppTargetsInput) error {
+		added = input
+		return nil
+	}
+	multiClusterAppOperationsStub.DoActionRemoveProjects = func(multiClusterApp *backendRancherClient.MultiClusterApp, input *backendRancherClient.UpdateMultiClusterAppTargetsInput) error {
+		removed = input
+		return nil
+	}
+	multiClusterAppOperationsStub.DoByID = func(id string) (*backendRancherClient.MultiClusterApp, error) {
+		assert.Equals(t, "mca-wordpress", id)
+		reloaded := existing
+		return &reloaded, nil
+	}
+	multiClusterAppOperationsStub.DoReplace = func(multiClusterApp *backendRancherClient.MultiClusterApp) (*backendRancherClient.MultiClusterApp, error) {
+		replaced = multiClusterApp
+		return multiClusterApp, nil
+	}
+	testClients.ManagementClient.MultiClusterApp = multiClusterAppOperationsStub
+	client, err := newMultiClusterAppClientWithData(
+		simpleMultiClusterApp,
+		newTestTargetRancherClient(testClients.ManagementClient),
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+
+	changed, err := client.Upgrade(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Multi cluster app must be upgraded")
+	assert.Equals(t, &backendRancherClient.UpdateMultiClusterAppTargetsInput{
+		Projects: []string{"c-prod:p-web"},
+		Answers: []backendRancherClient.Answer{
+			{ProjectID: "c-prod:p-web", Values: map[string]string{"replicaCount": "3"}},
+		},
+	}, added)
+	assert.Equals(t, &backendRancherClient.UpdateMultiClusterAppTargetsInput{
+		Projects: []string{"c-test:p-web"},
+	}, removed)
+	assert.Equals(t, "cattle-global-data:library-wordpress-7.3.8", replaced.TemplateVersionID)
+	assert.Equals(t, hashOf(simpleMultiClusterApp), replaced.Labels["cattlectl.io/hash"])
+}
+
+// testTargetRancherClient resolves the clusters production, staging and test each with the project web
+type testTargetRancherClient struct {
+	RancherClient
+	rancherClient *rancherClient
+}
+
+func newTestTargetRancherClient(backendClient *backendRancherClient.Client) RancherClient {
+	rancherClient := simpleRancherClient()
+	rancherClient._backendRancherClient = backendClient
+	return &testTargetRancherClient{
+		RancherClient: rancherClient,
+		rancherClient: rancherClient,
+	}
+}
+
+func (client *testTargetRancherClient) Cluster(clusterName string) (ClusterClient, error) {
+	clusterIDs := map[string]string{"production": "c-prod", "staging": "c-stage", "test": "c-test"}
+	return &testTargetClusterClient{clusterID: clusterIDs[clusterName]}, nil
+}
+
+func (client *testTargetRancherClient) backendRancherClient() (*backendRancherClient.Client, error) {
+	return client.rancherClient.backendRancherClient()
+}
+
+type testTargetClusterClient struct {
+	ClusterClient
+	clusterID string
+}
+
+func (client *testTargetClusterClient) ID() (string, error) {
+	return client.clusterID, nil
+}
+
+func (client *testTargetClusterClient) Project(projectName string) (ProjectClient, error) {
+	if projectName != "web" {
+		return &testTargetProjectClient{}, nil
+	}
+	return &testTargetProjectClient{projectID: client.clusterID + ":p-web"}, nil
+}
+
+type testTargetProjectClient struct {
+	ProjectClient
+	projectID string
+}
+
+func (client *testTargetProjectClient) ID() (string, error) {
+	return client.projectID, nil
+}
@@ -16,6 +16,8 @@ package client
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
@@ -24,6 +26,13 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// managedLabelsAnnotation lists the labels of a namespace declared by its descriptor
+	managedLabelsAnnotation = "cattlectl.io/managed-labels"
+	// managedAnnotationsAnnotation lists the annotations of a namespace declared by its descriptor
+	managedAnnotationsAnnotation = "cattlectl.io/managed-annotations"
+)
+
 func newNamespaceClientWithData(
 	namespace projectModel.Namespace,
 	projectClient ProjectClient,
@@ -61,6 +70,7 @@ func newNamespaceClient(
 
 type namespaceClient struct {
 	resourceClient
+	projectDeclaration
 	namespace     projectModel.Namespace
 	projectClient ProjectClient
 	clusterClient ClusterClient
@@ -71,6 +81,8 @@ func (client *namespaceClient) Type() string {
 }
 
 func (client *namespaceClient) ID() (string, error) {
+	client.idLock.Lock()
+	defer client.idLock.Unlock()
 	if client.id != "" {
 		return client.id, nil
 	}
@@ -125,15 +137,9 @@ func (client *namespaceClient) Create(dryRun bool) (changed bool, err error) {
 	}
 
 	client.logger.Info("Create new namespace")
-	newNamespace := &backendClusterClient.Namespace{
-		Name: client.namespace.Name,
-	}
-	if hasProjct, _ := client.HasProject(); hasProjct {
-		projectID, err := client.projectClient.ID()
-		if err != nil {
-			return changed, err
-		}
-		newNamespace.ProjectID = projectID
+	newNamespace, err := client.desiredNamespace()
+	if err != nil {
+		return
 	}
 
 	if dryRun {
@@ -145,8 +151,93 @@ func (client *namespaceClient) Create(dryRun bool) (changed bool, err error) {
 }
 
 func (client *namespaceClient) Upgrade(dryRun bool) (changed bool, err error) {
-	client.logger.Debug("Skip change existing namespace")
-	return
+	backendClient, err := client.clusterClient.backendClusterClient()
+	if err != nil {
+		return
+	}
+	existingNamespace, err := client.loadExistingNamespace()
+	if err != nil {
+		return
+	}
+	if existingNamespace == nil {
+		return changed, fmt.Errorf("Namespace %v not found", client.name)
+	}
+	desiredNamespace, err := client.desiredNamespace()
+	if err != nil {
+		return
+	}
+
+	if desiredNamespace.ProjectID != "" && existingNamespace.ProjectID != desiredNamespace.ProjectID {
+		client.logger.WithField("from_project_id", existingNamespace.ProjectID).Info("Move namespace")
+		if dryRun {
+			client.logger.WithField("object", existingNamespace).Info("Do Dry-Run Move")
+			existingNamespace.ProjectID = desiredNamespace.ProjectID
+		} else {
+			err = backendClient.Namespace.ActionMove(existingNamespace, &backendClusterClient.NamespaceMove{
+				ProjectID: desiredNamespace.ProjectID,
+			})
+			if err != nil {
+				return
+			}
+			// the move changes the namespace, so continue with the moved one
+			if existingNamespace, err = client.loadExistingNamespace(); err != nil {
+				return
+			}
+			if existingNamespace == nil {
+				return true, fmt.Errorf("Namespace %v not found", client.name)
+			}
+		}
+		changed = true
+	}
+
+	reconciled, err := client.reconcileNamespace(existingNamespace, *desiredNamespace)
+	if err != nil {
+		return
+	}
+	if !reconciled {
+		client.logger.Debug("Skip upgrade namespace - no changes")
+		return
+	}
+
+	client.logger.Info("Upgrade namespace")
+	if dryRun {
+		client.logger.WithField("object", existingNamespace).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.Namespace.Replace(existingNamespace)
+	}
+	return err == nil, err
+}
+
+func (client *namespaceClient) Desired() (interface{}, error) {
+	return client.desiredNamespace()
+}
+
+func (client *namespaceClient) Existing() (interface{}, error) {
+	existingNamespace, err := client.loadExistingNamespace()
+	if err != nil || existingNamespace == nil {
+		return nil, err
+	}
+	return *existingNamespace, nil
+}
+
+func (client *namespaceClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *namespaceClient) Unchanged() (bool, error) {
+	existingNamespace, err := client.loadExistingNamespace()
+	if err != nil || existingNamespace == nil {
+		return false, err
+	}
+	desiredNamespace, err := client.desiredNamespace()
+	if err != nil {
+		return false, err
+	}
+	if desiredNamespace.ProjectID != "" && existingNamespace.ProjectID != desiredNamespace.ProjectID {
+		return false, nil
+	}
+	reconciled, err := client.reconcileNamespace(existingNamespace, *desiredNamespace)
+	return !reconciled, err
 }
 
 func (client *namespaceClient) Delete(dryRun bool) (changed bool, err error) {
@@ -172,6 +263,14 @@ func (client *namespaceClient) HasProject() (bool, error) {
 	return client.projectClient != nil, nil
 }
 
+func (client *namespaceClient) Owned() (bool, error) {
+	existingNamespace, err := client.loadExistingNamespace()
+	if err != nil || existingNamespace == nil {
+		return false, err
+	}
+	return isDeclaredByProject(existingNamespace.Labels), nil
+}
+
 func (client *namespaceClient) Data() (projectModel.Namespace, error) {
 	return client.namespace, nil
 }
@@ -182,6 +281,29 @@ func (client *namespaceClient) SetData(namespace projectModel.Namespace) error {
 	return nil
 }
 
+func (client *namespaceClient) desiredNamespace() (*backendClusterClient.Namespace, error) {
+	result := &backendClusterClient.Namespace{
+		Name:        client.namespace.Name,
+		Labels:      client.withProjectLabel(withHashLabel(client.namespace.Labels, hashOf(client.namespace))),
+		Annotations: withManagedKeys(client.namespace.Labels, client.namespace.Annotations),
+	}
+	if client.namespace.ResourceQuota != nil {
+		limit := &backendClusterClient.ResourceQuotaLimit{}
+		if err := convertByJSON(client.namespace.ResourceQuota, limit); err != nil {
+			return nil, err
+		}
+		result.ResourceQuota = &backendClusterClient.NamespaceResourceQuota{Limit: limit}
+	}
+	if hasProjct, _ := client.HasProject(); hasProjct {
+		projectID, err := client.projectClient.ID()
+		if err != nil {
+			return nil, err
+		}
+		result.ProjectID = projectID
+	}
+	return result, nil
+}
+
 func (client *namespaceClient) loadExistingNamespace() (existingNamespace *backendClusterClient.Namespace, err error) {
 	backendClient, err := client.clusterClient.backendClusterClient()
 	if err != nil {
@@ -204,3 +326,96 @@ func (client *namespaceClient) loadExistingNamespace() (existingNamespace *backe
 	existingNamespace = &collection.Data[0]
 	return
 }
+
+// reconcileNamespace applies the declared labels, annotations and quota to the existing namespace,
+// changed is false if the existing namespace already matches them
+func (client *namespaceClient) reconcileNamespace(existingNamespace *backendClusterClient.Namespace, desiredNamespace backendClusterClient.Namespace) (changed bool, err error) {
+	// only a namespace created by cattlectl carries the hash and project labels
+	desiredLabels := client.namespace.Labels
+	if isOwned(existingNamespace.Labels) {
+		desiredLabels = desiredNamespace.Labels
+	}
+	var labelsChanged, annotationsChanged, quotaChanged bool
+	existingNamespace.Labels, labelsChanged = reconcile(
+		existingNamespace.Labels,
+		desiredLabels,
+		managedKeys(existingNamespace.Annotations, managedLabelsAnnotation),
+	)
+	existingNamespace.Annotations, annotationsChanged = reconcile(
+		existingNamespace.Annotations,
+		desiredNamespace.Annotations,
+		append(managedKeys(existingNamespace.Annotations, managedAnnotationsAnnotation), managedLabelsAnnotation, managedAnnotationsAnnotation),
+	)
+	if desiredNamespace.ResourceQuota != nil {
+		quotaEqual := false
+		if existingNamespace.ResourceQuota != nil {
+			if quotaEqual, err = quotaLimitsEqual(existingNamespace.ResourceQuota.Limit, desiredNamespace.ResourceQuota.Limit); err != nil {
+				return
+			}
+		}
+		if !quotaEqual {
+			existingNamespace.ResourceQuota = desiredNamespace.ResourceQuota
+			quotaChanged = true
+		}
+	}
+	return labelsChanged || annotationsChanged || quotaChanged, nil
+}
+
+// reconcile sets the desired entries in existing and removes the entries managed before which are not desired anymore,
+// entries added by others e.g. rancher are kept
+func reconcile(existing, desired map[string]string, managedBefore []string) (result map[string]string, changed bool) {
+	result = make(map[string]string, len(existing)+len(desired))
+	for key, value := range existing {
+		result[key] = value
+	}
+	for key, value := range desired {
+		if existingValue, found := existing[key]; !found || existingValue != value {
+			result[key] = value
+			changed = true
+		}
+	}
+	for _, key := range managedBefore {
+		_, isDesired := desired[key]
+		if _, found := result[key]; found && !isDesired {
+			delete(result, key)
+			changed = true
+		}
+	}
+	return
+}
+
+// withManagedKeys adds the keys of the declared labels and annotations to the annotations,
+// so keys which are no longer declared can be removed later
+func withManagedKeys(labels, annotations map[string]string) map[string]string {
+	if len(labels) == 0 && len(annotations) == 0 {
+		return annotations
+	}
+	result := make(map[string]string, len(annotations)+2)
+	for key, value := range annotations {
+		result[key] = value
+	}
+	if len(labels) > 0 {
+		result[managedLabelsAnnotation] = joinKeys(labels)
+	}
+	if len(annotations) > 0 {
+		result[managedAnnotationsAnnotation] = joinKeys(annotations)
+	}
+	return result
+}
+
+// managedKeys are the keys listed in the annotation
+func managedKeys(annotations map[string]string, annotation string) []string {
+	if annotations[annotation] == "" {
+		return nil
+	}
+	return strings.Split(annotations[annotation], ",")
+}
+
+func joinKeys(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
@@ -108,6 +108,213 @@ func Test_namespaceClient_Create(t *testing.T) {
 	}
 }
 
+func Test_namespaceClient_Create_withResourceQuota(t *testing.T) {
+	var created *backendClusterClient.Namespace
+	testClients := stubs.CreateBackendStubs(t)
+	namespaceOperationsStub := stubs.CreateNamespaceOperationsStub(t)
+	namespaceOperationsStub.DoCreate = func(namespace *backendClusterClient.Namespace) (*backendClusterClient.Namespace, error) {
+		created = namespace
+		return namespace, nil
+	}
+	testClients.ClusterClient.Namespace = namespaceOperationsStub
+	clusterClient := simpleClusterClient()
+	clusterClient._backendClusterClient = testClients.ClusterClient
+	client, err := newNamespaceClientWithData(
+		projectModel.Namespace{
+			Name:          "quota-namespace",
+			ResourceQuota: &projectModel.ResourceQuota{LimitsMemory: "1Gi", Pods: "5"},
+		},
+		nil,
+		clusterClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+
+	_, err = client.Create(false)
+	assert.Ok(t, err)
+	assert.Equals(t, &backendClusterClient.ResourceQuotaLimit{LimitsMemory: "1Gi", Pods: "5"}, created.ResourceQuota.Limit)
+}
+
+func Test_namespaceClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name              string
+		existing          backendClusterClient.Namespace
+		desired           projectModel.Namespace
+		wantedChanged     bool
+		wantedMove        bool
+		wantedLabels      map[string]string
+		wantedAnnotations map[string]string
+		wantedQuotaPods   string
+	}{
+		{
+			name: "unchanged",
+			existing: backendClusterClient.Namespace{
+				Name:      simpleNamespaceName,
+				ProjectID: simpleProjectID,
+				Labels: map[string]string{
+					"cattlectl.io/hash": hashOf(projectModel.Namespace{Name: simpleNamespaceName}),
+				},
+			},
+			desired:       projectModel.Namespace{Name: simpleNamespaceName},
+			wantedChanged: false,
+		},
+		{
+			name: "add-label",
+			existing: backendClusterClient.Namespace{
+				Name:      simpleNamespaceName,
+				ProjectID: simpleProjectID,
+				Labels: map[string]string{
+					"field.cattle.io/projectId": "p-simple",
+				},
+			},
+			desired: projectModel.Namespace{
+				Name:   simpleNamespaceName,
+				Labels: map[string]string{"istio-injection": "enabled"},
+			},
+			wantedChanged: true,
+			wantedLabels: map[string]string{
+				"field.cattle.io/projectId": "p-simple",
+				"istio-injection":           "enabled",
+			},
+			wantedAnnotations: map[string]string{
+				"cattlectl.io/managed-labels": "istio-injection",
+			},
+		},
+		{
+			name: "remove-undeclared",
+			existing: backendClusterClient.Namespace{
+				Name:      simpleNamespaceName,
+				ProjectID: simpleProjectID,
+				Labels: map[string]string{
+					"cattlectl.io/hash":         "outdated-hash",
+					"field.cattle.io/projectId": "p-simple",
+					"istio-injection":           "enabled",
+					"team":                      "web",
+				},
+				Annotations: map[string]string{
+					"cattlectl.io/managed-labels":                   "istio-injection,team",
+					"cattlectl.io/managed-annotations":              "contact",
+					"contact":                                       "web@example.com",
+					"field.cattle.io/containerDefaultResourceLimit": "{}",
+				},
+			},
+			desired: projectModel.Namespace{
+				Name:   simpleNamespaceName,
+				Labels: map[string]string{"team": "web"},
+			},
+			wantedChanged: true,
+			wantedLabels: map[string]string{
+				"field.cattle.io/projectId": "p-simple",
+				"team":                      "web",
+				"cattlectl.io/hash": hashOf(projectModel.Namespace{
+					Name:   simpleNamespaceName,
+					Labels: map[string]string{"team": "web"},
+				}),
+			},
+			wantedAnnotations: map[string]string{
+				"cattlectl.io/managed-labels":                   "team",
+				"field.cattle.io/containerDefaultResourceLimit": "{}",
+			},
+		},
+		{
+			name: "quota-normalized",
+			existing: backendClusterClient.Namespace{
+				Name:      simpleNamespaceName,
+				ProjectID: simpleProjectID,
+				Labels: map[string]string{
+					"cattlectl.io/hash": hashOf(projectModel.Namespace{Name: simpleNamespaceName, ResourceQuota: &projectModel.ResourceQuota{LimitsCPU: "2"}}),
+				},
+				ResourceQuota: &backendClusterClient.NamespaceResourceQuota{Limit: &backendClusterClient.ResourceQuotaLimit{LimitsCPU: "2000m"}},
+			},
+			desired:       projectModel.Namespace{Name: simpleNamespaceName, ResourceQuota: &projectModel.ResourceQuota{LimitsCPU: "2"}},
+			wantedChanged: false,
+		},
+		{
+			name: "quota-drift",
+			existing: backendClusterClient.Namespace{
+				Name:      simpleNamespaceName,
+				ProjectID: simpleProjectID,
+				Labels: map[string]string{
+					"cattlectl.io/hash": hashOf(projectModel.Namespace{Name: simpleNamespaceName, ResourceQuota: &projectModel.ResourceQuota{Pods: "5"}}),
+				},
+				ResourceQuota: &backendClusterClient.NamespaceResourceQuota{Limit: &backendClusterClient.ResourceQuotaLimit{Pods: "10"}},
+			},
+			desired:         projectModel.Namespace{Name: simpleNamespaceName, ResourceQuota: &projectModel.ResourceQuota{Pods: "5"}},
+			wantedChanged:   true,
+			wantedQuotaPods: "5",
+		},
+		{
+			name: "move",
+			existing: backendClusterClient.Namespace{
+				Name:      simpleNamespaceName,
+				ProjectID: "other-project-id",
+				Labels: map[string]string{
+					"cattlectl.io/hash": hashOf(projectModel.Namespace{Name: simpleNamespaceName}),
+				},
+			},
+			desired:       projectModel.Namespace{Name: simpleNamespaceName},
+			wantedChanged: true,
+			wantedMove:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				replaced    *backendClusterClient.Namespace
+				movedTo     string
+				existing    = tt.existing
+				testClients = stubs.CreateBackendStubs(t)
+			)
+			namespaceOperationsStub := stubs.CreateNamespaceOperationsStub(t)
+			namespaceOperationsStub.DoList = func(opts *types.ListOpts) (*backendClusterClient.NamespaceCollection, error) {
+				return &backendClusterClient.NamespaceCollection{
+					Data: []backendClusterClient.Namespace{existing},
+				}, nil
+			}
+			namespaceOperationsStub.DoActionMove = func(namespace *backendClusterClient.Namespace, input *backendClusterClient.NamespaceMove) error {
+				movedTo = input.ProjectID
+				existing.ProjectID = input.ProjectID
+				return nil
+			}
+			namespaceOperationsStub.DoReplace = func(namespace *backendClusterClient.Namespace) (*backendClusterClient.Namespace, error) {
+				replaced = namespace
+				return namespace, nil
+			}
+			testClients.ClusterClient.Namespace = namespaceOperationsStub
+			clusterClient := simpleClusterClient()
+			clusterClient._backendClusterClient = testClients.ClusterClient
+			client, err := newNamespaceClientWithData(
+				tt.desired,
+				simpleProjectClient(),
+				clusterClient,
+				logrus.New().WithFields(logrus.Fields{}),
+			)
+			assert.Ok(t, err)
+
+			changed, err := client.Upgrade(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedChanged, changed)
+			if tt.wantedMove {
+				assert.Equals(t, simpleProjectID, movedTo)
+			} else {
+				assert.Equals(t, "", movedTo)
+			}
+			if tt.wantedLabels != nil {
+				assert.Equals(t, tt.wantedLabels, replaced.Labels)
+			}
+			if tt.wantedAnnotations != nil {
+				assert.Equals(t, tt.wantedAnnotations, replaced.Annotations)
+			}
+			if tt.wantedQuotaPods != "" {
+				assert.Equals(t, tt.wantedQuotaPods, replaced.ResourceQuota.Limit.Pods)
+			}
+			if !tt.wantedChanged {
+				assert.Assert(t, replaced == nil, "Unchanged namespace should not be replaced")
+			}
+		})
+	}
+}
+
 func existingNamespaceClient(t *testing.T, expectedListOpts *types.ListOpts) *namespaceClient {
 	const (
 		projectID     = "test-project-id"
@@ -0,0 +1,274 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/sirupsen/logrus"
+)
+
+// projectIDLabel is the label rancher assigns to each namespace of a project
+const projectIDLabel = "field.cattle.io/projectId"
+
+// kubernetesNetworkPolicy is the networking.k8s.io/v1 NetworkPolicy object
+type kubernetesNetworkPolicy struct {
+	APIVersion string                     `json:"apiVersion"`
+	Kind       string                     `json:"kind"`
+	Metadata   kubernetesObjectMeta       `json:"metadata"`
+	Spec       projectModel.NetworkPolicy `json:"spec"`
+}
+
+// kubernetesNetworkPolicyList is the list of NetworkPolicy objects of a namespace
+type kubernetesNetworkPolicyList struct {
+	Items []kubernetesNetworkPolicy `json:"items"`
+}
+
+func newNetworkPolicyClientWithData(
+	networkPolicy projectModel.NetworkPolicy,
+	namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (NetworkPolicyClient, error) {
+	result, err := newNetworkPolicyClient(
+		networkPolicy.Name,
+		namespace,
+		project,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(networkPolicy)
+	return result, err
+}
+
+func newNetworkPolicyClient(
+	name, namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (NetworkPolicyClient, error) {
+	return &networkPolicyClient{
+		namespacedResourceClient: namespacedResourceClient{
+			resourceClient: resourceClient{
+				name:   name,
+				logger: logger.WithField("network_policy_name", name).WithField("namespace", namespace),
+			},
+			namespace: namespace,
+			project:   project,
+		},
+	}, nil
+}
+
+type networkPolicyClient struct {
+	namespacedResourceClient
+	projectDeclaration
+	networkPolicy projectModel.NetworkPolicy
+}
+
+func (client *networkPolicyClient) Type() string {
+	return rancherModel.NetworkPolicy
+}
+
+func (client *networkPolicyClient) Exists() (bool, error) {
+	existingNetworkPolicy, err := client.loadExistingNetworkPolicy()
+	if err != nil {
+		return false, err
+	}
+	if existingNetworkPolicy == nil {
+		client.logger.Debug("NetworkPolicy not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *networkPolicyClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendKubernetesClient()
+	if err != nil {
+		return
+	}
+	client.logger.Info("Create new NetworkPolicy")
+	newNetworkPolicy, err := client.desiredNetworkPolicy()
+	if err != nil {
+		return
+	}
+
+	if dryRun {
+		client.logger.WithField("object", newNetworkPolicy).Info("Do Dry-Run Create")
+	} else {
+		err = backendClient.create(networkPoliciesPath(client.namespace), newNetworkPolicy)
+	}
+	return err == nil, err
+}
+
+func (client *networkPolicyClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendKubernetesClient()
+	if err != nil {
+		return
+	}
+	existingNetworkPolicy, err := client.loadExistingNetworkPolicy()
+	if err != nil {
+		return
+	}
+	if existingNetworkPolicy == nil {
+		return changed, fmt.Errorf("NetworkPolicy %v not found", client.name)
+	}
+	if client.isNetworkPolicyUnchanged(*existingNetworkPolicy) {
+		client.logger.Debug("Skip upgrade network policy - no changes")
+		return
+	}
+	client.logger.Info("Upgrade NetworkPolicy")
+	desiredNetworkPolicy, err := client.desiredNetworkPolicy()
+	if err != nil {
+		return
+	}
+	desiredNetworkPolicy.Metadata.ResourceVersion = existingNetworkPolicy.Metadata.ResourceVersion
+
+	if dryRun {
+		client.logger.WithField("object", desiredNetworkPolicy).Info("Do Dry-Run Upgrade")
+	} else {
+		err = backendClient.replace(networkPolicyPath(client.namespace, client.name), desiredNetworkPolicy)
+	}
+	return err == nil, err
+}
+
+func (client *networkPolicyClient) Desired() (interface{}, error) {
+	return client.desiredNetworkPolicy()
+}
+
+func (client *networkPolicyClient) Existing() (interface{}, error) {
+	existingNetworkPolicy, err := client.loadExistingNetworkPolicy()
+	if err != nil || existingNetworkPolicy == nil {
+		return nil, err
+	}
+	return *existingNetworkPolicy, nil
+}
+
+func (client *networkPolicyClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *networkPolicyClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingNetworkPolicy()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return client.isNetworkPolicyUnchanged(*existing), nil
+}
+
+func (client *networkPolicyClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendKubernetesClient()
+	if err != nil {
+		return
+	}
+	existingNetworkPolicy, err := client.loadExistingNetworkPolicy()
+	if err != nil {
+		return
+	}
+	if existingNetworkPolicy == nil {
+		return changed, fmt.Errorf("NetworkPolicy %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingNetworkPolicy).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.delete(networkPolicyPath(client.namespace, client.name))
+	}
+	return err == nil, err
+}
+
+func (client *networkPolicyClient) Owned() (bool, error) {
+	existingNetworkPolicy, err := client.loadExistingNetworkPolicy()
+	if err != nil || existingNetworkPolicy == nil {
+		return false, err
+	}
+	return isDeclaredByProject(existingNetworkPolicy.Metadata.Labels), nil
+}
+
+func (client *networkPolicyClient) Data() (projectModel.NetworkPolicy, error) {
+	return client.networkPolicy, nil
+}
+
+func (client *networkPolicyClient) SetData(networkPolicy projectModel.NetworkPolicy) error {
+	client.name = networkPolicy.Name
+	client.networkPolicy = networkPolicy
+	return nil
+}
+
+func (client *networkPolicyClient) desiredNetworkPolicy() (kubernetesNetworkPolicy, error) {
+	result := kubernetesNetworkPolicy{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "NetworkPolicy",
+		Metadata: kubernetesObjectMeta{
+			Name:        client.name,
+			Namespace:   client.namespace,
+			Labels:      client.withProjectLabel(withHashLabel(client.networkPolicy.Labels, hashOf(client.networkPolicy))),
+			Annotations: client.networkPolicy.Annotations,
+		},
+		Spec: client.networkPolicy,
+	}
+	if client.networkPolicy.ProjectIsolation {
+		projectID, err := client.project.ID()
+		if err != nil {
+			return result, err
+		}
+		// rancher labels the namespaces with the project part of the ID e.g. c-abcde:p-fghij
+		projectLabel := projectID[strings.LastIndex(projectID, ":")+1:]
+		result.Spec.Ingress = append(append([]projectModel.NetworkPolicyIngressRule{}, result.Spec.Ingress...), projectModel.NetworkPolicyIngressRule{
+			From: []projectModel.NetworkPolicyPeer{
+				projectModel.NetworkPolicyPeer{
+					NamespaceSelector: &projectModel.LabelSelector{
+						MatchLabels: map[string]string{projectIDLabel: projectLabel},
+					},
+				},
+			},
+		})
+	}
+	return result, nil
+}
+
+func (client *networkPolicyClient) isNetworkPolicyUnchanged(existingNetworkPolicy kubernetesNetworkPolicy) bool {
+	hash, hashExists := existingNetworkPolicy.Metadata.Labels["cattlectl.io/hash"]
+	return hashExists && hash == hashOf(client.networkPolicy) && client.hasProjectLabel(existingNetworkPolicy.Metadata.Labels)
+}
+
+func (client *networkPolicyClient) loadExistingNetworkPolicy() (*kubernetesNetworkPolicy, error) {
+	backendClient, err := client.project.backendKubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Trace("Load from rancher")
+	existingNetworkPolicy := &kubernetesNetworkPolicy{}
+	found, err := backendClient.get(networkPolicyPath(client.namespace, client.name), existingNetworkPolicy)
+	if err != nil {
+		client.logger.WithError(err).Error("Failed to read network policy")
+		return nil, fmt.Errorf("Failed to read network policy, %v", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return existingNetworkPolicy, nil
+}
+
+func networkPoliciesPath(namespace string) string {
+	return fmt.Sprintf("/apis/networking.k8s.io/v1/namespaces/%s/networkpolicies", namespace)
+}
+
+func networkPolicyPath(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", networkPoliciesPath(namespace), name)
+}
@@ -0,0 +1,212 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	simpleNetworkPolicyName = "simple-network-policy"
+)
+
+func Test_networkPolicyClient_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		networkPolicy projectModel.NetworkPolicy
+		wantedIngress []projectModel.NetworkPolicyIngressRule
+	}{
+		{
+			name: "default-deny",
+			networkPolicy: projectModel.NetworkPolicy{
+				Name:        simpleNetworkPolicyName,
+				PolicyTypes: []string{"Ingress"},
+			},
+			wantedIngress: nil,
+		},
+		{
+			name: "project-isolation",
+			networkPolicy: projectModel.NetworkPolicy{
+				Name:             simpleNetworkPolicyName,
+				ProjectIsolation: true,
+			},
+			wantedIngress: []projectModel.NetworkPolicyIngressRule{
+				projectModel.NetworkPolicyIngressRule{
+					From: []projectModel.NetworkPolicyPeer{
+						projectModel.NetworkPolicyPeer{
+							NamespaceSelector: &projectModel.LabelSelector{
+								MatchLabels: map[string]string{projectIDLabel: "p-simple"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newNetworkPolicyServer(t, nil)
+			defer server.Close()
+			client := networkPolicyClientOf(t, server, tt.networkPolicy)
+
+			changed, err := client.Create(false)
+			assert.Ok(t, err)
+			assert.Assert(t, changed, "Create should report a change")
+			created := server.written
+			assert.Equals(t, http.MethodPost, server.method)
+			assert.Equals(t, "networking.k8s.io/v1", created.APIVersion)
+			assert.Equals(t, simpleNetworkPolicyName, created.Metadata.Name)
+			assert.Equals(t, simpleNamespaceName, created.Metadata.Namespace)
+			assert.Equals(t, hashOf(tt.networkPolicy), created.Metadata.Labels["cattlectl.io/hash"])
+			assert.Equals(t, tt.wantedIngress, created.Spec.Ingress)
+		})
+	}
+}
+
+func Test_networkPolicyClient_Upgrade(t *testing.T) {
+	networkPolicy := projectModel.NetworkPolicy{
+		Name:        simpleNetworkPolicyName,
+		PolicyTypes: []string{"Ingress", "Egress"},
+	}
+	tests := []struct {
+		name          string
+		existingHash  string
+		wantedChanged bool
+	}{
+		{
+			name:          "unchanged",
+			existingHash:  hashOf(networkPolicy),
+			wantedChanged: false,
+		},
+		{
+			name:          "changed",
+			existingHash:  "outdated",
+			wantedChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newNetworkPolicyServer(t, &kubernetesNetworkPolicy{
+				Metadata: kubernetesObjectMeta{
+					Name:            simpleNetworkPolicyName,
+					Namespace:       simpleNamespaceName,
+					Labels:          map[string]string{"cattlectl.io/hash": tt.existingHash},
+					ResourceVersion: "4711",
+				},
+			})
+			defer server.Close()
+			client := networkPolicyClientOf(t, server, networkPolicy)
+
+			changed, err := client.Upgrade(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedChanged, changed)
+			if tt.wantedChanged {
+				assert.Equals(t, http.MethodPut, server.method)
+				assert.Equals(t, "4711", server.written.Metadata.ResourceVersion)
+				assert.Equals(t, networkPolicy.PolicyTypes, server.written.Spec.PolicyTypes)
+			} else {
+				assert.Equals(t, "", server.method)
+			}
+		})
+	}
+}
+
+func Test_networkPolicyClient_Exists(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *kubernetesNetworkPolicy
+		wanted   bool
+	}{
+		{
+			name: "existing",
+			existing: &kubernetesNetworkPolicy{
+				Metadata: kubernetesObjectMeta{Name: simpleNetworkPolicyName},
+			},
+			wanted: true,
+		},
+		{
+			name:     "not-existing",
+			existing: nil,
+			wanted:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newNetworkPolicyServer(t, tt.existing)
+			defer server.Close()
+			client := networkPolicyClientOf(t, server, projectModel.NetworkPolicy{Name: simpleNetworkPolicyName})
+
+			got, err := client.Exists()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wanted, got)
+		})
+	}
+}
+
+// networkPolicyServer serves one network policy and records the last write
+type networkPolicyServer struct {
+	*httptest.Server
+	method  string
+	written kubernetesNetworkPolicy
+}
+
+func newNetworkPolicyServer(t *testing.T, existing *kubernetesNetworkPolicy) *networkPolicyServer {
+	result := &networkPolicyServer{}
+	policiesPath := "/k8s/clusters/" + simpleClusterID + networkPoliciesPath(simpleNamespaceName)
+	result.Server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch {
+		case request.Method == http.MethodGet && request.URL.Path == policiesPath+"/"+simpleNetworkPolicyName:
+			if existing == nil {
+				writer.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(writer).Encode(existing)
+		case request.Method == http.MethodPost && request.URL.Path == policiesPath,
+			request.Method == http.MethodPut && request.URL.Path == policiesPath+"/"+simpleNetworkPolicyName:
+			result.method = request.Method
+			assert.Ok(t, json.NewDecoder(request.Body).Decode(&result.written))
+			json.NewEncoder(writer).Encode(result.written)
+		default:
+			t.Errorf("Unexpected request %s %s", request.Method, request.URL.Path)
+			writer.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	return result
+}
+
+func networkPolicyClientOf(t *testing.T, server *networkPolicyServer, networkPolicy projectModel.NetworkPolicy) NetworkPolicyClient {
+	kubernetesClient, err := createKubernetesClient(RancherConfig{RancherURL: server.URL}, simpleClusterID)
+	assert.Ok(t, err)
+	clusterClient := simpleClusterClient()
+	clusterClient._backendKubernetesClient = kubernetesClient
+	projectClient := simpleProjectClient()
+	projectClient.id = simpleClusterID + ":p-simple"
+	projectClient.clusterClient = clusterClient
+	result, err := newNetworkPolicyClientWithData(
+		networkPolicy,
+		simpleNamespaceName,
+		projectClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	return result
+}
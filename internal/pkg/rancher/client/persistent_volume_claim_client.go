@@ -0,0 +1,266 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"reflect"
+
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newPersistentVolumeClaimClientWithData(
+	volumeClaim projectModel.VolumeClaim,
+	namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (PersistentVolumeClaimClient, error) {
+	result, err := newPersistentVolumeClaimClient(
+		volumeClaim.Name,
+		namespace,
+		project,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(volumeClaim)
+	return result, err
+}
+
+func newPersistentVolumeClaimClient(
+	name, namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (PersistentVolumeClaimClient, error) {
+	return &persistentVolumeClaimClient{
+		namespacedResourceClient: namespacedResourceClient{
+			resourceClient: resourceClient{
+				name:   name,
+				logger: logger.WithField("persistent_volume_claim_name", name).WithField("namespace", namespace),
+			},
+			namespace: namespace,
+			project:   project,
+		},
+	}, nil
+}
+
+type persistentVolumeClaimClient struct {
+	namespacedResourceClient
+	projectDeclaration
+	volumeClaim projectModel.VolumeClaim
+}
+
+func (client *persistentVolumeClaimClient) Type() string {
+	return rancherModel.PersistentVolumeClaim
+}
+
+func (client *persistentVolumeClaimClient) Exists() (bool, error) {
+	existingVolumeClaim, err := client.loadExistingVolumeClaim()
+	if err != nil {
+		return false, err
+	}
+	if existingVolumeClaim == nil {
+		client.logger.Debug("PersistentVolumeClaim not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *persistentVolumeClaimClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	projectID, err := client.project.ID()
+	if err != nil {
+		return
+	}
+	client.logger.Info("Create new PersistentVolumeClaim")
+	newVolumeClaim, err := client.desiredVolumeClaim()
+	if err != nil {
+		return
+	}
+	newVolumeClaim.NamespaceId = namespaceID
+	newVolumeClaim.ProjectID = projectID
+
+	if dryRun {
+		client.logger.WithField("object", newVolumeClaim).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.PersistentVolumeClaim.Create(&newVolumeClaim)
+	}
+	return err == nil, err
+}
+
+// Upgrade updates the labels, annotations and the requested storage of an existing claim.
+// The other fields of a claim are immutable.
+func (client *persistentVolumeClaimClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingVolumeClaim, err := client.loadExistingVolumeClaim()
+	if err != nil {
+		return
+	}
+	if existingVolumeClaim == nil {
+		return changed, fmt.Errorf("PersistentVolumeClaim %v not found", client.name)
+	}
+	if isVolumeClaimUnchanged(*existingVolumeClaim, client.volumeClaim) && client.hasProjectLabel(existingVolumeClaim.Labels) {
+		client.logger.Debug("Skip upgrade persistent volume claim - no changes")
+		return
+	}
+	desiredVolumeClaim, err := client.desiredVolumeClaim()
+	if err != nil {
+		return
+	}
+	if desiredVolumeClaim.StorageClassID != "" && desiredVolumeClaim.StorageClassID != existingVolumeClaim.StorageClassID {
+		return changed, fmt.Errorf("PersistentVolumeClaim %v can not change the storage class from %v to %v", client.name, existingVolumeClaim.StorageClassID, desiredVolumeClaim.StorageClassID)
+	}
+	if len(desiredVolumeClaim.AccessModes) > 0 && !reflect.DeepEqual(desiredVolumeClaim.AccessModes, existingVolumeClaim.AccessModes) {
+		return changed, fmt.Errorf("PersistentVolumeClaim %v can not change the access modes from %v to %v", client.name, existingVolumeClaim.AccessModes, desiredVolumeClaim.AccessModes)
+	}
+	client.logger.Info("Upgrade PersistentVolumeClaim")
+	existingVolumeClaim.Labels = desiredVolumeClaim.Labels
+	existingVolumeClaim.Annotations = desiredVolumeClaim.Annotations
+	if desiredVolumeClaim.Resources != nil {
+		existingVolumeClaim.Resources = desiredVolumeClaim.Resources
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingVolumeClaim).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.PersistentVolumeClaim.Replace(existingVolumeClaim)
+	}
+	return err == nil, err
+}
+
+func (client *persistentVolumeClaimClient) Desired() (interface{}, error) {
+	return client.desiredVolumeClaim()
+}
+
+func (client *persistentVolumeClaimClient) Existing() (interface{}, error) {
+	existingVolumeClaim, err := client.loadExistingVolumeClaim()
+	if err != nil || existingVolumeClaim == nil {
+		return nil, err
+	}
+	return *existingVolumeClaim, nil
+}
+
+func (client *persistentVolumeClaimClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *persistentVolumeClaimClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingVolumeClaim()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isVolumeClaimUnchanged(*existing, client.volumeClaim) && client.hasProjectLabel(existing.Labels), nil
+}
+
+func (client *persistentVolumeClaimClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingVolumeClaim, err := client.loadExistingVolumeClaim()
+	if err != nil {
+		return
+	}
+	if existingVolumeClaim == nil {
+		return changed, fmt.Errorf("PersistentVolumeClaim %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingVolumeClaim).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.PersistentVolumeClaim.Delete(existingVolumeClaim)
+	}
+	return err == nil, err
+}
+
+func (client *persistentVolumeClaimClient) Owned() (bool, error) {
+	existingVolumeClaim, err := client.loadExistingVolumeClaim()
+	if err != nil || existingVolumeClaim == nil {
+		return false, err
+	}
+	return isDeclaredByProject(existingVolumeClaim.Labels), nil
+}
+
+func (client *persistentVolumeClaimClient) Data() (projectModel.VolumeClaim, error) {
+	return client.volumeClaim, nil
+}
+
+func (client *persistentVolumeClaimClient) SetData(volumeClaim projectModel.VolumeClaim) error {
+	client.name = volumeClaim.Name
+	client.volumeClaim = volumeClaim
+	return nil
+}
+
+func (client *persistentVolumeClaimClient) desiredVolumeClaim() (backendProjectClient.PersistentVolumeClaim, error) {
+	volumeClaim, err := projectModel.ConvertVolumeClaimToProjectAPI(client.volumeClaim)
+	if err != nil {
+		return volumeClaim, err
+	}
+	volumeClaim.Labels = client.withProjectLabel(withHashLabel(volumeClaim.Labels, hashOf(client.volumeClaim)))
+	return volumeClaim, nil
+}
+
+func (client *persistentVolumeClaimClient) loadExistingVolumeClaim() (existingVolumeClaim *backendProjectClient.PersistentVolumeClaim, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.PersistentVolumeClaim.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read persistent volume claim list")
+		err = fmt.Errorf("Failed to read persistent volume claim list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name && item.NamespaceId == namespaceID {
+			existingVolumeClaim = &item
+			return
+		}
+	}
+	return
+}
+
+func isVolumeClaimUnchanged(existingVolumeClaim backendProjectClient.PersistentVolumeClaim, volumeClaim projectModel.VolumeClaim) bool {
+	hash, hashExists := existingVolumeClaim.Labels["cattlectl.io/hash"]
+	if !hashExists {
+		return false
+	}
+	return hash == hashOf(volumeClaim)
+}
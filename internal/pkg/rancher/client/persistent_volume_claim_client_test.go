@@ -0,0 +1,200 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_persistentVolumeClaimClient_Exists(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []backendProjectClient.PersistentVolumeClaim
+		wanted   bool
+	}{
+		{
+			name: "Existing",
+			existing: []backendProjectClient.PersistentVolumeClaim{
+				{Name: "test-claim", NamespaceId: "test-namespace-id"},
+			},
+			wanted: true,
+		},
+		{
+			name: "Other_Namespace",
+			existing: []backendProjectClient.PersistentVolumeClaim{
+				{Name: "test-claim", NamespaceId: "other-namespace-id"},
+			},
+			wanted: false,
+		},
+		{
+			name:     "Not_Existing",
+			existing: []backendProjectClient.PersistentVolumeClaim{},
+			wanted:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := testPersistentVolumeClaimClient(t, tt.existing)
+			got, err := client.Exists()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wanted, got)
+		})
+	}
+}
+
+func Test_persistentVolumeClaimClient_Create(t *testing.T) {
+	client, stub := testPersistentVolumeClaimClient(t, []backendProjectClient.PersistentVolumeClaim{})
+	var created *backendProjectClient.PersistentVolumeClaim
+	stub.DoCreate = func(volumeClaim *backendProjectClient.PersistentVolumeClaim) (*backendProjectClient.PersistentVolumeClaim, error) {
+		created = volumeClaim
+		return volumeClaim, nil
+	}
+	changed, err := client.Create(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Create should report a change")
+	assert.Equals(t, "test-claim", created.Name)
+	assert.Equals(t, "test-namespace-id", created.NamespaceId)
+	assert.Equals(t, "test-project-id", created.ProjectID)
+	assert.Equals(t, []string{"ReadWriteOnce"}, created.AccessModes)
+	assert.Equals(t, "local-storage", created.StorageClassID)
+	assert.Equals(t, map[string]string{"storage": "10Gi"}, created.Resources.Requests)
+	assert.Equals(t, hashOf(client.volumeClaim), created.Labels["cattlectl.io/hash"])
+}
+
+func Test_persistentVolumeClaimClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name           string
+		hash           string
+		storageClassID string
+		wantChanged    bool
+		wantErr        bool
+		wantedErr      string
+	}{
+		{
+			name:           "Resize",
+			hash:           "outdated-hash",
+			storageClassID: "local-storage",
+			wantChanged:    true,
+		},
+		{
+			name:           "Unchanged",
+			storageClassID: "local-storage",
+			wantChanged:    false,
+		},
+		{
+			name:           "Other_Storage_Class",
+			hash:           "outdated-hash",
+			storageClassID: "nfs",
+			wantErr:        true,
+			wantedErr:      "PersistentVolumeClaim test-claim can not change the storage class from nfs to local-storage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := backendProjectClient.PersistentVolumeClaim{
+				Name:           "test-claim",
+				NamespaceId:    "test-namespace-id",
+				AccessModes:    []string{"ReadWriteOnce"},
+				StorageClassID: tt.storageClassID,
+				VolumeID:       "pvc-4711",
+				Resources: &backendProjectClient.ResourceRequirements{
+					Requests: map[string]string{"storage": "5Gi"},
+				},
+				Labels: map[string]string{"cattlectl.io/hash": tt.hash},
+			}
+			client, stub := testPersistentVolumeClaimClient(t, []backendProjectClient.PersistentVolumeClaim{existing})
+			if tt.hash == "" {
+				existing.Labels["cattlectl.io/hash"] = hashOf(client.volumeClaim)
+			}
+			var replaced *backendProjectClient.PersistentVolumeClaim
+			stub.DoReplace = func(volumeClaim *backendProjectClient.PersistentVolumeClaim) (*backendProjectClient.PersistentVolumeClaim, error) {
+				replaced = volumeClaim
+				return volumeClaim, nil
+			}
+			changed, err := client.Upgrade(false)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Assert(t, replaced == nil, "PersistentVolumeClaim must not be replaced")
+				return
+			}
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantChanged, changed)
+			if tt.wantChanged {
+				assert.Equals(t, "pvc-4711", replaced.VolumeID)
+				assert.Equals(t, map[string]string{"storage": "10Gi"}, replaced.Resources.Requests)
+				assert.Equals(t, hashOf(client.volumeClaim), replaced.Labels["cattlectl.io/hash"])
+			}
+		})
+	}
+}
+
+func Test_persistentVolumeClaimClient_Delete(t *testing.T) {
+	client, stub := testPersistentVolumeClaimClient(t, []backendProjectClient.PersistentVolumeClaim{
+		{Name: "test-claim", NamespaceId: "test-namespace-id", Labels: map[string]string{"cattlectl.io/hash": "some-hash", "cattlectl.io/project": "test-project-name"}},
+	})
+	var deleted *backendProjectClient.PersistentVolumeClaim
+	stub.DoDelete = func(volumeClaim *backendProjectClient.PersistentVolumeClaim) error {
+		deleted = volumeClaim
+		return nil
+	}
+	owned, err := client.Owned()
+	assert.Ok(t, err)
+	assert.Assert(t, owned, "PersistentVolumeClaim should be owned")
+	changed, err := client.Delete(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Delete should report a change")
+	assert.Equals(t, "test-claim", deleted.Name)
+}
+
+func testPersistentVolumeClaimClient(t *testing.T, existing []backendProjectClient.PersistentVolumeClaim) (*persistentVolumeClaimClient, *stubs.PersistentVolumeClaimOperationsStub) {
+	testClients := stubs.CreateBackendStubs(t)
+	volumeClaimOperationsStub := stubs.CreatePersistentVolumeClaimOperationsStub(t)
+	volumeClaimOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.PersistentVolumeClaimCollection, error) {
+		assert.Equals(t, map[string]interface{}{
+			"name":        "test-claim",
+			"namespaceId": "test-namespace-id",
+		}, opts.Filters)
+		return &backendProjectClient.PersistentVolumeClaimCollection{Data: existing}, nil
+	}
+	testClients.ProjectClient.PersistentVolumeClaim = volumeClaimOperationsStub
+	result, err := newPersistentVolumeClaimClientWithData(
+		projectModel.VolumeClaim{
+			Name:             "test-claim",
+			AccessModes:      []string{"ReadWriteOnce"},
+			StorageClassName: "local-storage",
+			Storage:          "10Gi",
+		},
+		"test-namespace",
+		&projectClient{
+			resourceClient: resourceClient{
+				name: "test-project-name",
+				id:   "test-project-id",
+			},
+			_backendProjectClient: testClients.ProjectClient,
+		},
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	volumeClaimClientResult := result.(*persistentVolumeClaimClient)
+	volumeClaimClientResult.namespaceID = "test-namespace-id"
+	return volumeClaimClientResult, volumeClaimOperationsStub
+}
@@ -16,6 +16,7 @@ package client
 
 import (
 	"fmt"
+	"strings"
 
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
@@ -52,6 +53,7 @@ func newPersistentVolumeClient(
 			logger: logger.WithField("persistentVolume_name", name),
 		},
 		clusterClient: clusterClient,
+		scriptRunner:  newShellScriptRunner(clusterClient.config()),
 	}, nil
 }
 
@@ -59,6 +61,7 @@ type persistentVolumeClient struct {
 	resourceClient
 	persistentVolume projectModel.PersistentVolume
 	clusterClient    ClusterClient
+	scriptRunner     scriptRunner
 }
 
 func (client *persistentVolumeClient) Type() string {
@@ -66,26 +69,15 @@ func (client *persistentVolumeClient) Type() string {
 }
 
 func (client *persistentVolumeClient) Exists() (bool, error) {
-	backendClient, err := client.clusterClient.backendClusterClient()
+	existingPersistentVolume, err := client.loadExistingPersistentVolume()
 	if err != nil {
 		return false, err
 	}
-	collection, err := backendClient.PersistentVolume.List(&types.ListOpts{
-		Filters: map[string]interface{}{
-			"name": client.name,
-		},
-	})
-	if nil != err {
-		client.logger.WithError(err).Error("Failed to read persistentVolume list")
-		return false, fmt.Errorf("Failed to read persistentVolume list, %v", err)
-	}
-	for _, item := range collection.Data {
-		if item.Name == client.name {
-			return true, nil
-		}
+	if existingPersistentVolume == nil {
+		client.logger.Debug("PersistentVolume not found")
+		return false, nil
 	}
-	client.logger.Debug("PersistentVolume not found")
-	return false, nil
+	return true, nil
 }
 
 func (client *persistentVolumeClient) Create(dryRun bool) (changed bool, err error) {
@@ -94,16 +86,120 @@ func (client *persistentVolumeClient) Create(dryRun bool) (changed bool, err err
 		return
 	}
 	client.logger.Info("Create new persistent volume")
-	newPersistentVolume := &backendClusterClient.PersistentVolume{
-		Name:                          client.persistentVolume.Name,
-		StorageClassID:                client.persistentVolume.StorageClassName,
-		AccessModes:                   client.persistentVolume.AccessModes,
-		Capacity:                      map[string]string{"storage": client.persistentVolume.Capacity},
-		PersistentVolumeReclaimPolicy: "Delete",
-		Local: &backendClusterClient.LocalVolumeSource{
-			Path: client.persistentVolume.Path,
-		},
-		NodeAffinity: &backendClusterClient.VolumeNodeAffinity{
+	newPersistentVolume := client.desiredPersistentVolume()
+
+	if dryRun {
+		client.logger.WithField("object", newPersistentVolume).Info("Do Dry-Run Create")
+		if script := client.initScript(); script != "" {
+			client.logger.WithField("script", script).Info("Do Dry-Run Init Script")
+		}
+	} else {
+		if err = client.runInitScript(); err != nil {
+			return
+		}
+		_, err = backendClient.PersistentVolume.Create(newPersistentVolume)
+	}
+	return err == nil, err
+}
+
+func (client *persistentVolumeClient) Upgrade(dryRun bool) (changed bool, err error) {
+	client.logger.Debug("Skip change existing persistent volume")
+	return
+}
+
+func (client *persistentVolumeClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.clusterClient.backendClusterClient()
+	if err != nil {
+		return
+	}
+	existingPersistentVolume, err := client.loadExistingPersistentVolume()
+	if err != nil {
+		return
+	}
+	if existingPersistentVolume == nil {
+		return changed, fmt.Errorf("PersistentVolume %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingPersistentVolume).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.PersistentVolume.Delete(existingPersistentVolume)
+	}
+	return err == nil, err
+}
+
+// initScript is the init script of the persistent volume with ${node}, ${path} and ${name} expanded to shell quoted values
+func (client *persistentVolumeClient) initScript() string {
+	if client.persistentVolume.InitScript == "" {
+		return ""
+	}
+	return strings.NewReplacer(
+		"${node}", shellQuote(client.persistentVolume.Node),
+		"${path}", shellQuote(client.persistentVolume.Path),
+		"${name}", shellQuote(client.persistentVolume.Name),
+	).Replace(client.persistentVolume.InitScript)
+}
+
+func (client *persistentVolumeClient) runInitScript() error {
+	script := client.initScript()
+	if script == "" {
+		return nil
+	}
+	logger := client.logger.WithField("script", script)
+	logger.Info("Run init script")
+	output, err := client.scriptRunner.run(script)
+	logger = logger.WithField("output", output)
+	if err != nil {
+		logger.WithError(err).Error("Failed to run init script")
+		return fmt.Errorf("Failed to run init script of persistent volume %v, %v", client.name, err)
+	}
+	logger.Info("Finished init script")
+	return nil
+}
+
+// desiredPersistentVolume builds the volume source of the type of the persistent volume
+func (client *persistentVolumeClient) desiredPersistentVolume() *backendClusterClient.PersistentVolume {
+	persistentVolume := client.persistentVolume
+	result := &backendClusterClient.PersistentVolume{
+		Name:                          persistentVolume.Name,
+		StorageClassID:                persistentVolume.StorageClassName,
+		AccessModes:                   persistentVolume.AccessModes,
+		Capacity:                      map[string]string{"storage": persistentVolume.Capacity},
+		PersistentVolumeReclaimPolicy: persistentVolume.ReclaimPolicy,
+		MountOptions:                  persistentVolume.MountOptions,
+		VolumeMode:                    persistentVolume.VolumeMode,
+	}
+	if result.PersistentVolumeReclaimPolicy == "" {
+		result.PersistentVolumeReclaimPolicy = "Delete"
+	}
+	switch persistentVolume.Type {
+	case projectModel.PersistentVolumeTypeHostPath:
+		result.HostPath = &backendClusterClient.HostPathVolumeSource{
+			Path: persistentVolume.Path,
+			Kind: persistentVolume.HostPathType,
+		}
+	case projectModel.PersistentVolumeTypeNFS:
+		result.NFS = &backendClusterClient.NFSVolumeSource{
+			Server:   persistentVolume.Server,
+			Path:     persistentVolume.Path,
+			ReadOnly: persistentVolume.ReadOnly,
+		}
+	case projectModel.PersistentVolumeTypeCSI:
+		result.CSI = &backendClusterClient.CSIPersistentVolumeSource{
+			Driver:           persistentVolume.Driver,
+			VolumeHandle:     persistentVolume.VolumeHandle,
+			VolumeAttributes: persistentVolume.VolumeAttributes,
+			FSType:           persistentVolume.FSType,
+			ReadOnly:         persistentVolume.ReadOnly,
+		}
+	default:
+		result.Local = &backendClusterClient.LocalVolumeSource{
+			Path:   persistentVolume.Path,
+			FSType: persistentVolume.FSType,
+		}
+	}
+	if persistentVolume.Node != "" {
+		result.NodeAffinity = &backendClusterClient.VolumeNodeAffinity{
 			Required: &backendClusterClient.NodeSelector{
 				NodeSelectorTerms: []backendClusterClient.NodeSelectorTerm{
 					backendClusterClient.NodeSelectorTerm{
@@ -111,26 +207,15 @@ func (client *persistentVolumeClient) Create(dryRun bool) (changed bool, err err
 							backendClusterClient.NodeSelectorRequirement{
 								Key:      "kubernetes.io/hostname",
 								Operator: "In",
-								Values:   []string{client.persistentVolume.Node},
+								Values:   []string{persistentVolume.Node},
 							},
 						},
 					},
 				},
 			},
-		},
-	}
-
-	if dryRun {
-		client.logger.WithField("object", newPersistentVolume).Info("Do Dry-Run Create")
-	} else {
-		_, err = backendClient.PersistentVolume.Create(newPersistentVolume)
+		}
 	}
-	return err == nil, err
-}
-
-func (client *persistentVolumeClient) Upgrade(dryRun bool) (changed bool, err error) {
-	client.logger.Debug("Skip change existing persistent volume")
-	return
+	return result
 }
 
 func (client *persistentVolumeClient) Data() (projectModel.PersistentVolume, error) {
@@ -142,3 +227,25 @@ func (client *persistentVolumeClient) SetData(persistentVolume projectModel.Pers
 	client.persistentVolume = persistentVolume
 	return nil
 }
+
+func (client *persistentVolumeClient) loadExistingPersistentVolume() (*backendClusterClient.PersistentVolume, error) {
+	backendClient, err := client.clusterClient.backendClusterClient()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := backendClient.PersistentVolume.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": client.name,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read persistentVolume list")
+		return nil, fmt.Errorf("Failed to read persistentVolume list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
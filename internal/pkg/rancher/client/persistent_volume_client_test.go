@@ -103,6 +103,235 @@ func Test_persistentVolumeClient_Create(t *testing.T) {
 	}
 }
 
+func Test_persistentVolumeClient_Create_InitScript(t *testing.T) {
+	tests := []struct {
+		name          string
+		dryRun        bool
+		runErr        error
+		wantedScripts []string
+		wantedCreated bool
+		wantErr       bool
+		wantedErr     string
+	}{
+		{
+			name:          "run-before-create",
+			wantedScripts: []string{"ssh node-1 sudo mkdir -p /var/data/local-volume"},
+			wantedCreated: true,
+		},
+		{
+			name:          "dry-run",
+			dryRun:        true,
+			wantedScripts: nil,
+			wantedCreated: false,
+		},
+		{
+			name:          "failing-script",
+			runErr:        fmt.Errorf("exit status 1"),
+			wantedScripts: []string{"ssh node-1 sudo mkdir -p /var/data/local-volume"},
+			wantedCreated: false,
+			wantErr:       true,
+			wantedErr:     "Failed to run init script of persistent volume local-volume, exit status 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				testClients = stubs.CreateBackendStubs(t)
+				runner      = &testScriptRunner{err: tt.runErr}
+				created     = false
+			)
+			persistentVolumeOperationsStub := stubs.CreatePersistentVolumeOperationsStub(t)
+			persistentVolumeOperationsStub.DoCreate = func(persistentVolume *backendClusterClient.PersistentVolume) (*backendClusterClient.PersistentVolume, error) {
+				assert.Equals(t, len(tt.wantedScripts), len(runner.scripts))
+				created = true
+				return persistentVolume, nil
+			}
+			testClients.ClusterClient.PersistentVolume = persistentVolumeOperationsStub
+			clusterClient := simpleClusterClient()
+			clusterClient._backendClusterClient = testClients.ClusterClient
+			result, err := newPersistentVolumeClientWithData(
+				projectModel.PersistentVolume{
+					Name:       "local-volume",
+					Path:       "/var/data/local-volume",
+					Node:       "node-1",
+					InitScript: "ssh ${node} sudo mkdir -p ${path}",
+				},
+				clusterClient,
+				logrus.New().WithFields(logrus.Fields{}),
+			)
+			assert.Ok(t, err)
+			result.(*persistentVolumeClient).scriptRunner = runner
+
+			_, err = result.Create(tt.dryRun)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+			} else {
+				assert.Ok(t, err)
+			}
+			assert.Equals(t, tt.wantedScripts, runner.scripts)
+			assert.Equals(t, tt.wantedCreated, created)
+		})
+	}
+}
+
+func Test_persistentVolumeClient_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []backendClusterClient.PersistentVolume
+		dryRun        bool
+		wantedDeleted bool
+		wantErr       bool
+		wantedErr     string
+	}{
+		{
+			name:          "Delete",
+			existing:      []backendClusterClient.PersistentVolume{backendClusterClient.PersistentVolume{Name: "existing-persistentVolume"}},
+			wantedDeleted: true,
+		},
+		{
+			name:          "Dry_Run",
+			existing:      []backendClusterClient.PersistentVolume{backendClusterClient.PersistentVolume{Name: "existing-persistentVolume"}},
+			dryRun:        true,
+			wantedDeleted: false,
+		},
+		{
+			name:      "Not_Existing",
+			existing:  []backendClusterClient.PersistentVolume{},
+			wantErr:   true,
+			wantedErr: "PersistentVolume existing-persistentVolume not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			deleted := false
+			persistentVolumeOperationsStub := stubs.CreatePersistentVolumeOperationsStub(t)
+			persistentVolumeOperationsStub.DoList = func(opts *types.ListOpts) (*backendClusterClient.PersistentVolumeCollection, error) {
+				return &backendClusterClient.PersistentVolumeCollection{Data: tt.existing}, nil
+			}
+			persistentVolumeOperationsStub.DoDelete = func(persistentVolume *backendClusterClient.PersistentVolume) error {
+				assert.Equals(t, "existing-persistentVolume", persistentVolume.Name)
+				deleted = true
+				return nil
+			}
+			testClients.ClusterClient.PersistentVolume = persistentVolumeOperationsStub
+			clusterClient := simpleClusterClient()
+			clusterClient._backendClusterClient = testClients.ClusterClient
+			client, err := newPersistentVolumeClient(
+				"existing-persistentVolume",
+				clusterClient,
+				logrus.New().WithFields(logrus.Fields{}),
+			)
+			assert.Ok(t, err)
+
+			_, err = client.Delete(tt.dryRun)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+			} else {
+				assert.Ok(t, err)
+			}
+			assert.Equals(t, tt.wantedDeleted, deleted)
+		})
+	}
+}
+
+func Test_persistentVolumeClient_desiredPersistentVolume(t *testing.T) {
+	tests := []struct {
+		name             string
+		persistentVolume projectModel.PersistentVolume
+		wanted           backendClusterClient.PersistentVolume
+	}{
+		{
+			name: "local",
+			persistentVolume: projectModel.PersistentVolume{
+				Name: "local-volume",
+				Path: "/var/data",
+				Node: "node-1",
+			},
+			wanted: backendClusterClient.PersistentVolume{
+				Name:                          "local-volume",
+				Capacity:                      map[string]string{"storage": ""},
+				PersistentVolumeReclaimPolicy: "Delete",
+				Local:                         &backendClusterClient.LocalVolumeSource{Path: "/var/data"},
+				NodeAffinity:                  nodeAffinityOf("node-1"),
+			},
+		},
+		{
+			name: "hostPath",
+			persistentVolume: projectModel.PersistentVolume{
+				Name:          "host-path-volume",
+				Type:          projectModel.PersistentVolumeTypeHostPath,
+				Path:          "/var/data",
+				HostPathType:  "DirectoryOrCreate",
+				ReclaimPolicy: "Retain",
+			},
+			wanted: backendClusterClient.PersistentVolume{
+				Name:                          "host-path-volume",
+				Capacity:                      map[string]string{"storage": ""},
+				PersistentVolumeReclaimPolicy: "Retain",
+				HostPath:                      &backendClusterClient.HostPathVolumeSource{Path: "/var/data", Kind: "DirectoryOrCreate"},
+			},
+		},
+		{
+			name: "nfs",
+			persistentVolume: projectModel.PersistentVolume{
+				Name:         "nfs-volume",
+				Type:         projectModel.PersistentVolumeTypeNFS,
+				Server:       "nfs.example.com",
+				Path:         "/exports/data",
+				Capacity:     "10Gi",
+				MountOptions: []string{"nfsvers=4.1"},
+			},
+			wanted: backendClusterClient.PersistentVolume{
+				Name:                          "nfs-volume",
+				Capacity:                      map[string]string{"storage": "10Gi"},
+				PersistentVolumeReclaimPolicy: "Delete",
+				MountOptions:                  []string{"nfsvers=4.1"},
+				NFS:                           &backendClusterClient.NFSVolumeSource{Server: "nfs.example.com", Path: "/exports/data"},
+			},
+		},
+		{
+			name: "csi",
+			persistentVolume: projectModel.PersistentVolume{
+				Name:         "csi-volume",
+				Type:         projectModel.PersistentVolumeTypeCSI,
+				Driver:       "ebs.csi.aws.com",
+				VolumeHandle: "vol-4711",
+				FSType:       "ext4",
+				VolumeMode:   "Block",
+			},
+			wanted: backendClusterClient.PersistentVolume{
+				Name:                          "csi-volume",
+				Capacity:                      map[string]string{"storage": ""},
+				PersistentVolumeReclaimPolicy: "Delete",
+				VolumeMode:                    "Block",
+				CSI:                           &backendClusterClient.CSIPersistentVolumeSource{Driver: "ebs.csi.aws.com", VolumeHandle: "vol-4711", FSType: "ext4"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newPersistentVolumeClientWithData(tt.persistentVolume, simpleClusterClient(), logrus.New().WithFields(logrus.Fields{}))
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wanted, *result.(*persistentVolumeClient).desiredPersistentVolume())
+		})
+	}
+}
+
+func nodeAffinityOf(node string) *backendClusterClient.VolumeNodeAffinity {
+	return &backendClusterClient.VolumeNodeAffinity{
+		Required: &backendClusterClient.NodeSelector{
+			NodeSelectorTerms: []backendClusterClient.NodeSelectorTerm{
+				{
+					MatchExpressions: []backendClusterClient.NodeSelectorRequirement{
+						{Key: "kubernetes.io/hostname", Operator: "In", Values: []string{node}},
+					},
+				},
+			},
+		},
+	}
+}
+
 func existingPersistentVolumeClient(t *testing.T, expectedListOpts *types.ListOpts) *persistentVolumeClient {
 	const (
 		projectID            = "test-project-id"
@@ -183,3 +412,14 @@ func notExistingPersistentVolumeClient(t *testing.T, expectedListOpts *types.Lis
 	persistentVolumeClientResult := result.(*persistentVolumeClient)
 	return persistentVolumeClientResult
 }
+
+// testScriptRunner records the scripts instead of running them
+type testScriptRunner struct {
+	scripts []string
+	err     error
+}
+
+func (runner *testScriptRunner) run(script string) (string, error) {
+	runner.scripts = append(runner.scripts, script)
+	return "", runner.err
+}
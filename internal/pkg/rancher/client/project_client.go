@@ -16,6 +16,7 @@ package client
 
 import (
 	"fmt"
+	"sync"
 
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
@@ -51,6 +52,12 @@ func newProjectClient(
 		deploymentClients:       make(map[string]DeploymentClient),
 		daemonSetClients:        make(map[string]DaemonSetClient),
 		statefulSetClients:      make(map[string]StatefulSetClient),
+		autoscalerClients:       make(map[string]HorizontalPodAutoscalerClient),
+		serviceClients:          make(map[string]ServiceClient),
+		ingressClients:          make(map[string]IngressClient),
+		networkPolicyClients:    make(map[string]NetworkPolicyClient),
+		volumeClaimClients:      make(map[string]PersistentVolumeClaimClient),
+		memberClients:           make(map[string]ProjectRoleTemplateBindingClient),
 		catalogClients:          make(map[string]CatalogClient),
 	}, nil
 }
@@ -71,7 +78,18 @@ type projectClient struct {
 	deploymentClients       map[string]DeploymentClient
 	daemonSetClients        map[string]DaemonSetClient
 	statefulSetClients      map[string]StatefulSetClient
+	autoscalerClients       map[string]HorizontalPodAutoscalerClient
+	serviceClients          map[string]ServiceClient
+	ingressClients          map[string]IngressClient
+	networkPolicyClients    map[string]NetworkPolicyClient
+	volumeClaimClients      map[string]PersistentVolumeClaimClient
+	memberClients           map[string]ProjectRoleTemplateBindingClient
 	catalogClients          map[string]CatalogClient
+	// cacheLock guards the clients cached per namespace from certificateClients to volumeClaimClients
+	// as well as appClients, catalogClients and memberClients, which the parallel phases of a project fill concurrently
+	cacheLock sync.Mutex
+	// initLock guards the creation of _backendProjectClient on first use
+	initLock sync.Mutex
 }
 
 func (client *projectClient) Type() string {
@@ -79,6 +97,8 @@ func (client *projectClient) Type() string {
 }
 
 func (client *projectClient) init() error {
+	client.initLock.Lock()
+	defer client.initLock.Unlock()
 	if client._backendProjectClient != nil {
 		return nil
 	}
@@ -98,6 +118,8 @@ func (client *projectClient) init() error {
 }
 
 func (client *projectClient) ID() (string, error) {
+	client.idLock.Lock()
+	defer client.idLock.Unlock()
 	if client.id != "" {
 		return client.id, nil
 	}
@@ -148,6 +170,9 @@ func (client *projectClient) Create(dryRun bool) (changed bool, err error) {
 		ClusterID: clusterID,
 		Name:      client.name,
 	}
+	if _, err = client.applyQuotas(pattern); err != nil {
+		return
+	}
 
 	if dryRun {
 		client.logger.WithField("object", pattern).Info("Do Dry-Run Create")
@@ -164,9 +189,124 @@ func (client *projectClient) Create(dryRun bool) (changed bool, err error) {
 	return err == nil, err
 }
 func (client *projectClient) Upgrade(dryRun bool) (changed bool, err error) {
-	client.logger.Debug("Project exists")
+	backendClient, err := client.clusterClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingProject, err := client.loadExistingProject()
+	if err != nil {
+		return
+	}
+	if existingProject == nil {
+		return false, fmt.Errorf("Project %v not found", client.name)
+	}
+	changed, err = client.applyQuotas(existingProject)
+	if err != nil {
+		return
+	}
+	if !changed {
+		client.logger.Debug("Skip upgrade project - no changes")
+		return
+	}
+
+	client.logger.Info("Upgrade project")
+	if dryRun {
+		client.logger.WithField("object", existingProject).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.Project.Replace(existingProject)
+	}
+	return err == nil, err
+}
+
+func (client *projectClient) Data() (projectModel.Project, error) {
+	return client.project, nil
+}
+
+func (client *projectClient) SetData(project projectModel.Project) error {
+	client.project = project
+	return nil
+}
+
+// applyQuotas sets the declared quotas and limits of the project and reports if one has changed,
+// quantities are compared by value as rancher may normalize them e.g. 2 to 2000m.
+// Quotas which are not declared are left untouched.
+func (client *projectClient) applyQuotas(project *backendRancherClient.Project) (changed bool, err error) {
+	metadata := client.project.Metadata
+	if metadata.ResourceQuota != nil {
+		limit := &backendRancherClient.ResourceQuotaLimit{}
+		if err = convertByJSON(metadata.ResourceQuota, limit); err != nil {
+			return
+		}
+		if project.ResourceQuota == nil {
+			project.ResourceQuota = &backendRancherClient.ProjectResourceQuota{}
+		}
+		var equal bool
+		if equal, err = quotaLimitsEqual(project.ResourceQuota.Limit, limit); err != nil {
+			return
+		}
+		if !equal {
+			project.ResourceQuota.Limit = limit
+			changed = true
+		}
+	}
+	if metadata.NamespaceDefaultResourceQuota != nil {
+		limit := &backendRancherClient.ResourceQuotaLimit{}
+		if err = convertByJSON(metadata.NamespaceDefaultResourceQuota, limit); err != nil {
+			return
+		}
+		if project.NamespaceDefaultResourceQuota == nil {
+			project.NamespaceDefaultResourceQuota = &backendRancherClient.NamespaceResourceQuota{}
+		}
+		var equal bool
+		if equal, err = quotaLimitsEqual(project.NamespaceDefaultResourceQuota.Limit, limit); err != nil {
+			return
+		}
+		if !equal {
+			project.NamespaceDefaultResourceQuota.Limit = limit
+			changed = true
+		}
+	}
+	if metadata.ContainerDefaultResourceLimit != nil {
+		limit := &backendRancherClient.ContainerResourceLimit{}
+		if err = convertByJSON(metadata.ContainerDefaultResourceLimit, limit); err != nil {
+			return
+		}
+		var equal bool
+		if equal, err = quotaLimitsEqual(project.ContainerDefaultResourceLimit, limit); err != nil {
+			return
+		}
+		if !equal {
+			project.ContainerDefaultResourceLimit = limit
+			changed = true
+		}
+	}
 	return
 }
+
+func (client *projectClient) loadExistingProject() (*backendRancherClient.Project, error) {
+	backendClient, err := client.clusterClient.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	clusterID, err := client.ClusterID()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := backendClient.Project.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"clusterId": clusterID,
+			"name":      client.name,
+		},
+	})
+	if err != nil {
+		client.logger.WithError(err).Error("Failed to read project list")
+		return nil, fmt.Errorf("Failed to read project list, %v", err)
+	}
+	if len(collection.Data) < 1 {
+		return nil, nil
+	}
+	return &collection.Data[0], nil
+}
 func (client *projectClient) Namespace(name string) (NamespaceClient, error) {
 	return client.clusterClient.Namespace(name, client.name)
 }
@@ -201,6 +341,8 @@ func (client *projectClient) GlobalCertificates() ([]CertificateClient, error) {
 	return result, nil
 }
 func (client *projectClient) Certificate(name, namespaceName string) (CertificateClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.certificateClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
 		return cache, nil
 	}
@@ -249,6 +391,8 @@ func (client *projectClient) Certificates(namespaceName string) ([]CertificateCl
 	return result, nil
 }
 func (client *projectClient) ConfigMap(name, namespaceName string) (ConfigMapClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.configMapClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
 		return cache, nil
 	}
@@ -321,6 +465,8 @@ func (client *projectClient) GlobalDockerCredentials() ([]DockerCredentialClient
 	return result, nil
 }
 func (client *projectClient) DockerCredential(name, namespaceName string) (DockerCredentialClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.dockerCredentialClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
 		return cache, nil
 	}
@@ -396,6 +542,8 @@ func (client *projectClient) GlobalSecrets() ([]ConfigMapClient, error) {
 	return result, nil
 }
 func (client *projectClient) Secret(name, namespaceName string) (ConfigMapClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.secretClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
 		return cache, nil
 	}
@@ -444,6 +592,8 @@ func (client *projectClient) Secrets(namespaceName string) ([]ConfigMapClient, e
 	return result, nil
 }
 func (client *projectClient) App(name string) (AppClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.appClients[name]; exists {
 		return cache, nil
 	}
@@ -479,6 +629,8 @@ func (client *projectClient) Apps() ([]AppClient, error) {
 	return result, nil
 }
 func (client *projectClient) Job(name, namespaceName string) (JobClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.jobClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
 		return cache, nil
 	}
@@ -524,6 +676,8 @@ func (client *projectClient) Jobs(namespaceName string) ([]JobClient, error) {
 	return result, nil
 }
 func (client *projectClient) CronJob(name, namespaceName string) (CronJobClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.cronJobClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
 		return cache, nil
 	}
@@ -569,6 +723,8 @@ func (client *projectClient) CronJobs(namespaceName string) ([]CronJobClient, er
 	return result, nil
 }
 func (client *projectClient) Deployment(name, namespaceName string) (DeploymentClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.deploymentClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
 		return cache, nil
 	}
@@ -614,6 +770,8 @@ func (client *projectClient) Deployments(namespaceName string) ([]DeploymentClie
 	return result, nil
 }
 func (client *projectClient) DaemonSet(name, namespaceName string) (DaemonSetClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.daemonSetClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
 		return cache, nil
 	}
@@ -659,6 +817,8 @@ func (client *projectClient) DaemonSets(namespaceName string) ([]DaemonSetClient
 	return result, nil
 }
 func (client *projectClient) StatefulSet(name, namespaceName string) (StatefulSetClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.statefulSetClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
 		return cache, nil
 	}
@@ -704,7 +864,230 @@ func (client *projectClient) StatefulSets(namespaceName string) ([]StatefulSetCl
 	return result, nil
 }
 
+func (client *projectClient) HorizontalPodAutoscaler(name, namespaceName string) (HorizontalPodAutoscalerClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.autoscalerClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
+		return cache, nil
+	}
+	autoscaler, err := newHorizontalPodAutoscalerClient(name, namespaceName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.autoscalerClients[fmt.Sprintf("%s::%s", name, namespaceName)] = autoscaler
+	return autoscaler, nil
+}
+func (client *projectClient) HorizontalPodAutoscalers(namespaceName string) ([]HorizontalPodAutoscalerClient, error) {
+	backendProjectClient, err := client.backendProjectClient()
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := client.Namespace(namespaceName)
+	if err != nil {
+		return nil, err
+	}
+	namespaceID, err := namespace.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := backendProjectClient.HorizontalPodAutoscaler.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"projectId":   client.id,
+			"namespaceId": namespaceID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]HorizontalPodAutoscalerClient, len(collection.Data))
+	for i, backendAutoscaler := range collection.Data {
+		autoscaler, err := client.HorizontalPodAutoscaler(backendAutoscaler.Name, namespaceName)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = autoscaler
+	}
+	return result, nil
+}
+func (client *projectClient) Service(name, namespaceName string) (ServiceClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.serviceClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
+		return cache, nil
+	}
+	service, err := newServiceClient(name, namespaceName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.serviceClients[fmt.Sprintf("%s::%s", name, namespaceName)] = service
+	return service, nil
+}
+func (client *projectClient) Services(namespaceName string) ([]ServiceClient, error) {
+	backendProjectClient, err := client.backendProjectClient()
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := client.Namespace(namespaceName)
+	if err != nil {
+		return nil, err
+	}
+	namespaceID, err := namespace.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := backendProjectClient.Service.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"projectId":   client.id,
+			"namespaceId": namespaceID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ServiceClient, len(collection.Data))
+	for i, backendService := range collection.Data {
+		service, err := client.Service(backendService.Name, namespaceName)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = service
+	}
+	return result, nil
+}
+func (client *projectClient) Ingress(name, namespaceName string) (IngressClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.ingressClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
+		return cache, nil
+	}
+	ingress, err := newIngressClient(name, namespaceName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.ingressClients[fmt.Sprintf("%s::%s", name, namespaceName)] = ingress
+	return ingress, nil
+}
+func (client *projectClient) Ingresses(namespaceName string) ([]IngressClient, error) {
+	backendProjectClient, err := client.backendProjectClient()
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := client.Namespace(namespaceName)
+	if err != nil {
+		return nil, err
+	}
+	namespaceID, err := namespace.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := backendProjectClient.Ingress.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"projectId":   client.id,
+			"namespaceId": namespaceID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]IngressClient, len(collection.Data))
+	for i, backendIngress := range collection.Data {
+		ingress, err := client.Ingress(backendIngress.Name, namespaceName)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = ingress
+	}
+	return result, nil
+}
+func (client *projectClient) NetworkPolicy(name, namespaceName string) (NetworkPolicyClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.networkPolicyClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
+		return cache, nil
+	}
+	networkPolicy, err := newNetworkPolicyClient(name, namespaceName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.networkPolicyClients[fmt.Sprintf("%s::%s", name, namespaceName)] = networkPolicy
+	return networkPolicy, nil
+}
+func (client *projectClient) NetworkPolicies(namespaceName string) ([]NetworkPolicyClient, error) {
+	backendKubernetesClient, err := client.backendKubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	collection := kubernetesNetworkPolicyList{}
+	if _, err = backendKubernetesClient.get(networkPoliciesPath(namespaceName), &collection); err != nil {
+		return nil, err
+	}
+	result := make([]NetworkPolicyClient, len(collection.Items))
+	for i, backendNetworkPolicy := range collection.Items {
+		networkPolicy, err := client.NetworkPolicy(backendNetworkPolicy.Metadata.Name, namespaceName)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = networkPolicy
+	}
+	return result, nil
+}
+func (client *projectClient) PersistentVolumeClaim(name, namespaceName string) (PersistentVolumeClaimClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.volumeClaimClients[fmt.Sprintf("%s::%s", name, namespaceName)]; exists {
+		return cache, nil
+	}
+	volumeClaim, err := newPersistentVolumeClaimClient(name, namespaceName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.volumeClaimClients[fmt.Sprintf("%s::%s", name, namespaceName)] = volumeClaim
+	return volumeClaim, nil
+}
+func (client *projectClient) PersistentVolumeClaims(namespaceName string) ([]PersistentVolumeClaimClient, error) {
+	backendProjectClient, err := client.backendProjectClient()
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := client.Namespace(namespaceName)
+	if err != nil {
+		return nil, err
+	}
+	namespaceID, err := namespace.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := backendProjectClient.PersistentVolumeClaim.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"projectId":   client.id,
+			"namespaceId": namespaceID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]PersistentVolumeClaimClient, len(collection.Data))
+	for i, backendVolumeClaim := range collection.Data {
+		volumeClaim, err := client.PersistentVolumeClaim(backendVolumeClaim.Name, namespaceName)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = volumeClaim
+	}
+	return result, nil
+}
 func (client *projectClient) Catalog(catalogName string) (CatalogClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.catalogClients[catalogName]; exists {
 		return cache, nil
 	}
@@ -740,6 +1123,50 @@ func (client *projectClient) Catalogs() ([]CatalogClient, error) {
 	return result, nil
 }
 
+func (client *projectClient) ProjectRoleTemplateBinding(principalID string) (ProjectRoleTemplateBindingClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.memberClients[principalID]; exists {
+		return cache, nil
+	}
+	member, err := newProjectRoleTemplateBindingClient(principalID, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.memberClients[principalID] = member
+	return member, nil
+}
+
+func (client *projectClient) ProjectRoleTemplateBindings() ([]ProjectRoleTemplateBindingClient, error) {
+	backendRancherClient, err := client.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := backendRancherClient.ProjectRoleTemplateBinding.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"projectId": client.id,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ProjectRoleTemplateBindingClient, 0)
+	listed := make(map[string]bool)
+	for _, backendBinding := range collection.Data {
+		principalID := bindingPrincipalID(backendBinding)
+		if principalID == "" || listed[principalID] {
+			continue
+		}
+		listed[principalID] = true
+		member, err := client.ProjectRoleTemplateBinding(principalID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, member)
+	}
+	return result, nil
+}
+
 func (client *projectClient) backendRancherClient() (*backendRancherClient.Client, error) {
 	if err := client.init(); err != nil {
 		return nil, err
@@ -761,6 +1188,10 @@ func (client *projectClient) backendProjectClient() (*backendProjectClient.Clien
 	return client._backendProjectClient, nil
 }
 
+func (client *projectClient) backendKubernetesClient() (*kubernetesClient, error) {
+	return client.clusterClient.backendKubernetesClient()
+}
+
 func (client *projectClient) config() RancherConfig {
 	return client.rancherConfig
 }
@@ -17,6 +17,7 @@ package client
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/bitgrip/cattlectl/internal/pkg/assert"
@@ -148,6 +149,115 @@ func Test_projectClient_Upgrade(t *testing.T) {
 	}
 }
 
+func Test_projectClient_Create_withQuotas(t *testing.T) {
+	var created *backendRancherClient.Project
+	testClients := stubs.CreateBackendStubs(t)
+	projectOperationsStub := stubs.CreateProjectOperationsStub(t)
+	projectOperationsStub.DoCreate = func(project *backendRancherClient.Project) (*backendRancherClient.Project, error) {
+		created = project
+		project.ID = simpleProjectID
+		return project, nil
+	}
+	testClients.ManagementClient.Project = projectOperationsStub
+	rancherClient := simpleRancherClient()
+	rancherClient._backendRancherClient = testClients.ManagementClient
+	clusterClient := simpleClusterClient()
+	clusterClient.rancherClient = rancherClient
+	client := simpleProjectClient()
+	client.clusterClient = clusterClient
+	project := projectModel.Project{}
+	project.Metadata.Name = simpleProjectName
+	project.Metadata.ResourceQuota = &projectModel.ResourceQuota{LimitsCPU: "2000m", Pods: "20"}
+	project.Metadata.NamespaceDefaultResourceQuota = &projectModel.ResourceQuota{LimitsCPU: "500m", Pods: "5"}
+	project.Metadata.ContainerDefaultResourceLimit = &projectModel.ContainerResourceLimit{LimitsCPU: "100m"}
+	assert.Ok(t, client.SetData(project))
+
+	changed, err := client.Create(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Create should report a change")
+	assert.Equals(t, &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "2000m", Pods: "20"}, created.ResourceQuota.Limit)
+	assert.Equals(t, &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "500m", Pods: "5"}, created.NamespaceDefaultResourceQuota.Limit)
+	assert.Equals(t, &backendRancherClient.ContainerResourceLimit{LimitsCPU: "100m"}, created.ContainerDefaultResourceLimit)
+}
+
+func Test_projectClient_Upgrade_quotas(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      *backendRancherClient.ProjectResourceQuota
+		desired       *projectModel.ResourceQuota
+		wantedChanged bool
+	}{
+		{
+			name:          "undeclared-quota-is-kept",
+			existing:      &backendRancherClient.ProjectResourceQuota{Limit: &backendRancherClient.ResourceQuotaLimit{Pods: "10"}},
+			desired:       nil,
+			wantedChanged: false,
+		},
+		{
+			name:          "unchanged-quota",
+			existing:      &backendRancherClient.ProjectResourceQuota{Limit: &backendRancherClient.ResourceQuotaLimit{Pods: "10"}},
+			desired:       &projectModel.ResourceQuota{Pods: "10"},
+			wantedChanged: false,
+		},
+		{
+			name:          "changed-quota",
+			existing:      &backendRancherClient.ProjectResourceQuota{Limit: &backendRancherClient.ResourceQuotaLimit{Pods: "10"}},
+			desired:       &projectModel.ResourceQuota{Pods: "20"},
+			wantedChanged: true,
+		},
+		{
+			name:          "new-quota",
+			existing:      nil,
+			desired:       &projectModel.ResourceQuota{Pods: "20"},
+			wantedChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var replaced *backendRancherClient.Project
+			testClients := stubs.CreateBackendStubs(t)
+			projectOperationsStub := stubs.CreateProjectOperationsStub(t)
+			projectOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.ProjectCollection, error) {
+				return &backendRancherClient.ProjectCollection{
+					Data: []backendRancherClient.Project{
+						backendRancherClient.Project{
+							Resource: types.Resource{
+								ID: simpleProjectID,
+							},
+							Name:          simpleProjectName,
+							ResourceQuota: tt.existing,
+						},
+					},
+				}, nil
+			}
+			projectOperationsStub.DoReplace = func(project *backendRancherClient.Project) (*backendRancherClient.Project, error) {
+				replaced = project
+				return project, nil
+			}
+			testClients.ManagementClient.Project = projectOperationsStub
+			rancherClient := simpleRancherClient()
+			rancherClient._backendRancherClient = testClients.ManagementClient
+			clusterClient := simpleClusterClient()
+			clusterClient.rancherClient = rancherClient
+			client := simpleProjectClient()
+			client.clusterClient = clusterClient
+			project := projectModel.Project{}
+			project.Metadata.Name = simpleProjectName
+			project.Metadata.ResourceQuota = tt.desired
+			assert.Ok(t, client.SetData(project))
+
+			changed, err := client.Upgrade(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedChanged, changed)
+			if tt.wantedChanged {
+				assert.Equals(t, tt.desired.Pods, replaced.ResourceQuota.Limit.Pods)
+			} else {
+				assert.Assert(t, replaced == nil, "Unchanged project should not be replaced")
+			}
+		})
+	}
+}
+
 func Test_projectClient_Namespace(t *testing.T) {
 	type args struct {
 		name string
@@ -516,6 +626,23 @@ func Test_projectClient_ConfigMap(t *testing.T) {
 	}
 }
 
+func Test_projectClient_ConfigMap_Concurrent(t *testing.T) {
+	client := simpleProjectClient()
+	results := make([]ConfigMapClient, 20)
+	var waitGroup sync.WaitGroup
+	for index := range results {
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+			results[index], _ = client.ConfigMap(simpleConfigMapName, simpleNamespaceName)
+		}(index)
+	}
+	waitGroup.Wait()
+	for _, result := range results {
+		assert.Assert(t, result != nil && result == results[0], "concurrent calls should return the same object from cache")
+	}
+}
+
 func Test_projectClient_ConfigMaps(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -1654,6 +1781,9 @@ func simpleProjectClient() *projectClient {
 		deploymentClients:       make(map[string]DeploymentClient),
 		daemonSetClients:        make(map[string]DaemonSetClient),
 		statefulSetClients:      make(map[string]StatefulSetClient),
+		serviceClients:          make(map[string]ServiceClient),
+		ingressClients:          make(map[string]IngressClient),
+		memberClients:           make(map[string]ProjectRoleTemplateBindingClient),
 		catalogClients:          make(map[string]CatalogClient),
 	}
 }
@@ -0,0 +1,330 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newProjectRoleTemplateBindingClientWithData(
+	member projectModel.Member,
+	projectClient ProjectClient,
+	logger *logrus.Entry,
+) (ProjectRoleTemplateBindingClient, error) {
+	result, err := newProjectRoleTemplateBindingClient(
+		MemberPrincipalID(member),
+		projectClient,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(member)
+	return result, err
+}
+
+func newProjectRoleTemplateBindingClient(
+	principalID string,
+	projectClient ProjectClient,
+	logger *logrus.Entry,
+) (ProjectRoleTemplateBindingClient, error) {
+	return &projectRoleTemplateBindingClient{
+		resourceClient: resourceClient{
+			name:   principalID,
+			logger: logger.WithField("member_principal_id", principalID),
+		},
+		projectClient: projectClient,
+	}, nil
+}
+
+// MemberPrincipalID is the principal of a user or group member,
+// users without auth provider prefix are local users
+func MemberPrincipalID(member projectModel.Member) string {
+	if member.Group != "" {
+		return member.Group
+	}
+	if member.User != "" && !strings.Contains(member.User, "://") {
+		return "local://" + member.User
+	}
+	return member.User
+}
+
+// projectRoleTemplateBindingClient manages the bindings of all roles of one member
+type projectRoleTemplateBindingClient struct {
+	resourceClient
+	member        projectModel.Member
+	projectClient ProjectClient
+}
+
+func (client *projectRoleTemplateBindingClient) Type() string {
+	return rancherModel.ProjectMember
+}
+
+func (client *projectRoleTemplateBindingClient) Exists() (bool, error) {
+	bindings, err := client.loadExistingBindings()
+	if err != nil {
+		return false, err
+	}
+	if len(bindings) == 0 {
+		client.logger.Debug("Member not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *projectRoleTemplateBindingClient) Create(dryRun bool) (changed bool, err error) {
+	client.logger.Info("Create new member")
+	for _, role := range client.member.Roles {
+		if err = client.createBinding(role, dryRun); err != nil {
+			return
+		}
+	}
+	return true, nil
+}
+
+// Upgrade binds the missing roles, with prune it also removes the bindings cattlectl created for roles no longer declared
+func (client *projectRoleTemplateBindingClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.projectClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	bindings, err := client.loadExistingBindings()
+	if err != nil {
+		return
+	}
+	removed, added := client.pendingBindings(bindings)
+	for _, binding := range removed {
+		client.logger.WithField("role", binding.RoleTemplateID).Info("Remove role of member")
+		changed = true
+		if dryRun {
+			client.logger.WithField("object", binding).Info("Do Dry-Run Delete")
+		} else if err = backendClient.ProjectRoleTemplateBinding.Delete(&binding); err != nil {
+			return
+		}
+	}
+	for _, role := range added {
+		client.logger.WithField("role", role).Info("Add role to member")
+		changed = true
+		if err = client.createBinding(role, dryRun); err != nil {
+			return
+		}
+	}
+	if !changed {
+		client.logger.Debug("Skip upgrade member - no changes")
+	}
+	return
+}
+
+func (client *projectRoleTemplateBindingClient) Desired() (interface{}, error) {
+	return projectModel.Member{
+		User:  client.member.User,
+		Group: client.member.Group,
+		Roles: sortedRoles(client.member.Roles),
+	}, nil
+}
+
+func (client *projectRoleTemplateBindingClient) Existing() (interface{}, error) {
+	bindings, err := client.loadExistingBindings()
+	if err != nil || len(bindings) == 0 {
+		return nil, err
+	}
+	existing := projectModel.Member{
+		User:  client.member.User,
+		Group: client.member.Group,
+	}
+	for _, binding := range bindings {
+		existing.Roles = append(existing.Roles, binding.RoleTemplateID)
+	}
+	existing.Roles = sortedRoles(existing.Roles)
+	return existing, nil
+}
+
+func (client *projectRoleTemplateBindingClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *projectRoleTemplateBindingClient) Unchanged() (bool, error) {
+	bindings, err := client.loadExistingBindings()
+	if err != nil || len(bindings) == 0 {
+		return false, err
+	}
+	removed, added := client.pendingBindings(bindings)
+	return len(removed) == 0 && len(added) == 0, nil
+}
+
+// Delete removes the bindings cattlectl created, roles bound by others are kept
+func (client *projectRoleTemplateBindingClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.projectClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	bindings, err := client.loadExistingBindings()
+	if err != nil {
+		return
+	}
+	if len(bindings) == 0 {
+		return changed, fmt.Errorf("Member %v not found", client.name)
+	}
+	for _, binding := range bindings {
+		if !isOwned(binding.Labels) {
+			client.logger.WithField("role", binding.RoleTemplateID).Info("Keep role of member - not bound by cattlectl")
+			continue
+		}
+		changed = true
+		if dryRun {
+			client.logger.WithField("object", binding).Info("Do Dry-Run Delete")
+		} else if err = backendClient.ProjectRoleTemplateBinding.Delete(&binding); err != nil {
+			return
+		}
+	}
+	return changed, nil
+}
+
+// Owned is true if any role of the member was bound by cattlectl
+func (client *projectRoleTemplateBindingClient) Owned() (bool, error) {
+	bindings, err := client.loadExistingBindings()
+	if err != nil {
+		return false, err
+	}
+	for _, binding := range bindings {
+		if isOwned(binding.Labels) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (client *projectRoleTemplateBindingClient) Data() (projectModel.Member, error) {
+	return client.member, nil
+}
+
+func (client *projectRoleTemplateBindingClient) SetData(member projectModel.Member) error {
+	client.name = MemberPrincipalID(member)
+	client.member = member
+	return nil
+}
+
+func (client *projectRoleTemplateBindingClient) createBinding(role string, dryRun bool) (err error) {
+	backendClient, err := client.projectClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	projectID, err := client.projectClient.ID()
+	if err != nil {
+		return
+	}
+	newBinding := backendRancherClient.ProjectRoleTemplateBinding{
+		ProjectID:      projectID,
+		RoleTemplateID: role,
+		Labels: map[string]string{
+			"cattlectl.io/hash": hashOf(client.member),
+		},
+	}
+	if client.member.Group != "" {
+		newBinding.GroupPrincipalID = client.name
+	} else {
+		newBinding.UserPrincipalID = client.name
+	}
+
+	if dryRun {
+		client.logger.WithField("object", newBinding).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.ProjectRoleTemplateBinding.Create(&newBinding)
+	}
+	return
+}
+
+// pendingBindings are the bindings Upgrade removes and the roles it binds
+func (client *projectRoleTemplateBindingClient) pendingBindings(bindings []backendRancherClient.ProjectRoleTemplateBinding) (removed []backendRancherClient.ProjectRoleTemplateBinding, added []string) {
+	declared := make(map[string]bool)
+	for _, role := range client.member.Roles {
+		declared[role] = true
+	}
+	bound := make(map[string]bool)
+	for _, binding := range bindings {
+		if declared[binding.RoleTemplateID] {
+			bound[binding.RoleTemplateID] = true
+		} else if client.projectClient.config().Prune && isOwned(binding.Labels) {
+			removed = append(removed, binding)
+		}
+	}
+	for _, role := range client.member.Roles {
+		if !bound[role] {
+			added = append(added, role)
+		}
+	}
+	return
+}
+
+func (client *projectRoleTemplateBindingClient) loadExistingBindings() (bindings []backendRancherClient.ProjectRoleTemplateBinding, err error) {
+	backendClient, err := client.projectClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	projectID, err := client.projectClient.ID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.ProjectRoleTemplateBinding.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"projectId": projectID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read project role template binding list")
+		err = fmt.Errorf("Failed to read project role template binding list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if bindingPrincipalID(item) == client.name {
+			bindings = append(bindings, item)
+		}
+	}
+	return
+}
+
+// bindingPrincipalID is the principal of the user or group bound by a binding
+func bindingPrincipalID(binding backendRancherClient.ProjectRoleTemplateBinding) string {
+	return principalIDOf(binding.GroupPrincipalID, binding.UserPrincipalID, binding.UserID)
+}
+
+// principalIDOf is the principal of the group or user of a project or cluster role template binding
+func principalIDOf(groupPrincipalID, userPrincipalID, userID string) string {
+	switch {
+	case groupPrincipalID != "":
+		return groupPrincipalID
+	case userPrincipalID != "":
+		return userPrincipalID
+	case userID != "":
+		return "local://" + userID
+	default:
+		return ""
+	}
+}
+
+func sortedRoles(roles []string) []string {
+	result := append([]string{}, roles...)
+	sort.Strings(result)
+	return result
+}
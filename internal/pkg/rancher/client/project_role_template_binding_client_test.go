@@ -0,0 +1,215 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMemberPrincipalID(t *testing.T) {
+	tests := []struct {
+		name   string
+		member projectModel.Member
+		wanted string
+	}{
+		{
+			name:   "local_user",
+			member: projectModel.Member{User: "u-abcde"},
+			wanted: "local://u-abcde",
+		},
+		{
+			name:   "user_principal",
+			member: projectModel.Member{User: "github_user://42"},
+			wanted: "github_user://42",
+		},
+		{
+			name:   "group_principal",
+			member: projectModel.Member{Group: "github_team://4711"},
+			wanted: "github_team://4711",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equals(t, tt.wanted, MemberPrincipalID(tt.member))
+		})
+	}
+}
+
+func Test_projectRoleTemplateBindingClient_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  projectModel.Member
+		dryRun  bool
+		wantNew []backendRancherClient.ProjectRoleTemplateBinding
+	}{
+		{
+			name:   "user",
+			member: projectModel.Member{User: "u-abcde", Roles: []string{"project-member", "create-ns"}},
+			wantNew: []backendRancherClient.ProjectRoleTemplateBinding{
+				{ProjectID: simpleProjectID, RoleTemplateID: "project-member", UserPrincipalID: "local://u-abcde"},
+				{ProjectID: simpleProjectID, RoleTemplateID: "create-ns", UserPrincipalID: "local://u-abcde"},
+			},
+		},
+		{
+			name:   "group",
+			member: projectModel.Member{Group: "github_team://4711", Roles: []string{"read-only"}},
+			wantNew: []backendRancherClient.ProjectRoleTemplateBinding{
+				{ProjectID: simpleProjectID, RoleTemplateID: "read-only", GroupPrincipalID: "github_team://4711"},
+			},
+		},
+		{
+			name:    "dry_run",
+			member:  projectModel.Member{Group: "github_team://4711", Roles: []string{"read-only"}},
+			dryRun:  true,
+			wantNew: []backendRancherClient.ProjectRoleTemplateBinding{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, stub := testProjectRoleTemplateBindingClient(t, tt.member, nil)
+			created := make([]backendRancherClient.ProjectRoleTemplateBinding, 0)
+			stub.DoCreate = func(binding *backendRancherClient.ProjectRoleTemplateBinding) (*backendRancherClient.ProjectRoleTemplateBinding, error) {
+				assert.Equals(t, hashOf(tt.member), binding.Labels["cattlectl.io/hash"])
+				binding.Labels = nil
+				created = append(created, *binding)
+				return binding, nil
+			}
+			changed, err := client.Create(tt.dryRun)
+			assert.Ok(t, err)
+			assert.Assert(t, changed, "Create should report a change")
+			assert.Equals(t, tt.wantNew, created)
+		})
+	}
+}
+
+func Test_projectRoleTemplateBindingClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name        string
+		roles       []string
+		prune       bool
+		wantChanged bool
+		wantCreated []string
+		wantDeleted []string
+	}{
+		{
+			name:        "unchanged",
+			roles:       []string{"project-member", "create-ns", "workloads-view"},
+			prune:       true,
+			wantChanged: false,
+			wantCreated: []string{},
+			wantDeleted: []string{},
+		},
+		{
+			name:        "change_roles",
+			roles:       []string{"project-member", "read-only"},
+			prune:       true,
+			wantChanged: true,
+			wantCreated: []string{"read-only"},
+			wantDeleted: []string{"workloads-view"},
+		},
+		{
+			name:        "change_roles_without_prune",
+			roles:       []string{"project-member", "read-only"},
+			wantChanged: true,
+			wantCreated: []string{"read-only"},
+			wantDeleted: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, stub := testProjectRoleTemplateBindingClient(
+				t,
+				projectModel.Member{User: "u-abcde", Roles: tt.roles},
+				[]backendRancherClient.ProjectRoleTemplateBinding{
+					{ProjectID: simpleProjectID, RoleTemplateID: "project-member", UserID: "u-abcde"},
+					{ProjectID: simpleProjectID, RoleTemplateID: "create-ns", UserPrincipalID: "local://u-abcde"},
+					{ProjectID: simpleProjectID, RoleTemplateID: "workloads-view", UserPrincipalID: "local://u-abcde", Labels: map[string]string{"cattlectl.io/hash": "some-hash"}},
+					{ProjectID: simpleProjectID, RoleTemplateID: "project-owner", UserPrincipalID: "local://u-other"},
+				},
+			)
+			client.projectClient.(*projectClient).rancherConfig.Prune = tt.prune
+			created := make([]string, 0)
+			deleted := make([]string, 0)
+			stub.DoCreate = func(binding *backendRancherClient.ProjectRoleTemplateBinding) (*backendRancherClient.ProjectRoleTemplateBinding, error) {
+				created = append(created, binding.RoleTemplateID)
+				return binding, nil
+			}
+			stub.DoDelete = func(binding *backendRancherClient.ProjectRoleTemplateBinding) error {
+				deleted = append(deleted, binding.RoleTemplateID)
+				return nil
+			}
+			changed, err := client.Upgrade(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantChanged, changed)
+			assert.Equals(t, tt.wantCreated, created)
+			assert.Equals(t, tt.wantDeleted, deleted)
+		})
+	}
+}
+
+func Test_projectRoleTemplateBindingClient_Delete(t *testing.T) {
+	client, stub := testProjectRoleTemplateBindingClient(
+		t,
+		projectModel.Member{Group: "github_team://4711"},
+		[]backendRancherClient.ProjectRoleTemplateBinding{
+			{ProjectID: simpleProjectID, RoleTemplateID: "read-only", GroupPrincipalID: "github_team://4711", Labels: map[string]string{"cattlectl.io/hash": "some-hash"}},
+			{ProjectID: simpleProjectID, RoleTemplateID: "create-ns", GroupPrincipalID: "github_team://4711"},
+			{ProjectID: simpleProjectID, RoleTemplateID: "project-owner", UserPrincipalID: "local://u-other"},
+		},
+	)
+	deleted := make([]string, 0)
+	stub.DoDelete = func(binding *backendRancherClient.ProjectRoleTemplateBinding) error {
+		deleted = append(deleted, binding.RoleTemplateID)
+		return nil
+	}
+	owned, err := client.Owned()
+	assert.Ok(t, err)
+	assert.Assert(t, owned, "Member should be owned")
+	changed, err := client.Delete(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Delete should report a change")
+	assert.Equals(t, []string{"read-only"}, deleted)
+}
+
+func testProjectRoleTemplateBindingClient(t *testing.T, member projectModel.Member, existing []backendRancherClient.ProjectRoleTemplateBinding) (*projectRoleTemplateBindingClient, *stubs.ProjectRoleTemplateBindingOperationsStub) {
+	testClients := stubs.CreateBackendStubs(t)
+	bindingOperationsStub := stubs.CreateProjectRoleTemplateBindingOperationsStub(t)
+	bindingOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.ProjectRoleTemplateBindingCollection, error) {
+		assert.Equals(t, map[string]interface{}{"projectId": simpleProjectID}, opts.Filters)
+		return &backendRancherClient.ProjectRoleTemplateBindingCollection{Data: existing}, nil
+	}
+	testClients.ManagementClient.ProjectRoleTemplateBinding = bindingOperationsStub
+	rancherClient := simpleRancherClient()
+	rancherClient._backendRancherClient = testClients.ManagementClient
+	clusterClient := simpleClusterClient()
+	clusterClient.rancherClient = rancherClient
+	projectClient := simpleProjectClient()
+	projectClient.clusterClient = clusterClient
+	projectClient._backendProjectClient = testClients.ProjectClient
+	result, err := newProjectRoleTemplateBindingClientWithData(
+		member,
+		projectClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	return result.(*projectRoleTemplateBindingClient), bindingOperationsStub
+}
@@ -0,0 +1,96 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"math/big"
+	"regexp"
+)
+
+// quantityFormat splits a kubernetes quantity e.g. 1.5Gi or 500m into number and suffix
+var quantityFormat = regexp.MustCompile(`^([+-]?[0-9]*\.?[0-9]+)([eE][+-]?[0-9]+|[numkMGTPE]|[KMGTPE]i)?$`)
+
+// quantitySuffixes are the multipliers of the suffixes of kubernetes quantities
+var quantitySuffixes = map[string]string{
+	"":   "1",
+	"n":  "1/1000000000",
+	"u":  "1/1000000",
+	"m":  "1/1000",
+	"k":  "1000",
+	"M":  "1000000",
+	"G":  "1000000000",
+	"T":  "1000000000000",
+	"P":  "1000000000000000",
+	"E":  "1000000000000000000",
+	"Ki": "1024",
+	"Mi": "1048576",
+	"Gi": "1073741824",
+	"Ti": "1099511627776",
+	"Pi": "1125899906842624",
+	"Ei": "1152921504606846976",
+}
+
+// parseQuantity parses a kubernetes quantity into its exact value
+func parseQuantity(quantity string) (*big.Rat, bool) {
+	parts := quantityFormat.FindStringSubmatch(quantity)
+	if parts == nil {
+		return nil, false
+	}
+	number, multiplier := parts[1], quantitySuffixes[parts[2]]
+	if multiplier == "" {
+		// an exponent like 1e3 is parsed together with the number
+		number, multiplier = quantity, "1"
+	}
+	value, isNumber := new(big.Rat).SetString(number)
+	if !isNumber {
+		return nil, false
+	}
+	factor, _ := new(big.Rat).SetString(multiplier)
+	return value.Mul(value, factor), true
+}
+
+// quantitiesEqual compares two quantities by their value e.g. 1Gi equals 1024Mi,
+// values which are no quantities are compared as they are
+func quantitiesEqual(existing, desired string) bool {
+	if existing == desired {
+		return true
+	}
+	existingValue, isExistingQuantity := parseQuantity(existing)
+	desiredValue, isDesiredQuantity := parseQuantity(desired)
+	return isExistingQuantity && isDesiredQuantity && existingValue.Cmp(desiredValue) == 0
+}
+
+// quotaLimitsEqual compares the fields of two quota limits by their quantities
+func quotaLimitsEqual(existing, desired interface{}) (bool, error) {
+	existingFields := make(map[string]string)
+	desiredFields := make(map[string]string)
+	if err := convertByJSON(existing, &existingFields); err != nil {
+		return false, err
+	}
+	if err := convertByJSON(desired, &desiredFields); err != nil {
+		return false, err
+	}
+	for field, desiredValue := range desiredFields {
+		if !quantitiesEqual(existingFields[field], desiredValue) {
+			return false, nil
+		}
+	}
+	for field, existingValue := range existingFields {
+		if !quantitiesEqual(existingValue, desiredFields[field]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,91 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+)
+
+func Test_quantitiesEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		desired  string
+		wanted   bool
+	}{
+		{name: "same", existing: "1Gi", desired: "1Gi", wanted: true},
+		{name: "binary-suffix", existing: "1Gi", desired: "1024Mi", wanted: true},
+		{name: "milli-cpu", existing: "1000m", desired: "1", wanted: true},
+		{name: "decimal", existing: "0.5", desired: "500m", wanted: true},
+		{name: "exponent", existing: "1e3", desired: "1k", wanted: true},
+		{name: "different", existing: "1Gi", desired: "1G", wanted: false},
+		{name: "missing", existing: "", desired: "10", wanted: false},
+		{name: "no-quantity", existing: "unlimited", desired: "10", wanted: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equals(t, tt.wanted, quantitiesEqual(tt.existing, tt.desired))
+		})
+	}
+}
+
+func Test_quotaLimitsEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *backendRancherClient.ResourceQuotaLimit
+		desired  *backendRancherClient.ResourceQuotaLimit
+		wanted   bool
+	}{
+		{
+			name:     "normalized-by-rancher",
+			existing: &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "2000m", LimitsMemory: "2048Mi"},
+			desired:  &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "2", LimitsMemory: "2Gi"},
+			wanted:   true,
+		},
+		{
+			name:     "changed",
+			existing: &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "2000m"},
+			desired:  &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "4"},
+			wanted:   false,
+		},
+		{
+			name:     "field-added",
+			existing: &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "2"},
+			desired:  &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "2", Pods: "10"},
+			wanted:   false,
+		},
+		{
+			name:     "field-removed",
+			existing: &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "2", Pods: "10"},
+			desired:  &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "2"},
+			wanted:   false,
+		},
+		{
+			name:    "not-existing",
+			desired: &backendRancherClient.ResourceQuotaLimit{LimitsCPU: "2"},
+			wanted:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, err := quotaLimitsEqual(tt.existing, tt.desired)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wanted, equal)
+		})
+	}
+}
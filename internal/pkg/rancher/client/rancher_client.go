@@ -15,6 +15,10 @@
 package client
 
 import (
+	"sync"
+	"time"
+
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 	"github.com/rancher/norman/types"
 	backendRancherClient "github.com/rancher/types/client/management/v3"
 	"github.com/sirupsen/logrus"
@@ -23,10 +27,17 @@ import (
 // NewRancherClient creates a new rancher client
 func NewRancherClient(config RancherConfig) (RancherClient, error) {
 	return &rancherClient{
-		config:         config,
-		logger:         logrus.WithFields(logrus.Fields{}),
-		clusterClients: make(map[string]ClusterClient),
-		catalogClients: make(map[string]CatalogClient),
+		config:                   config,
+		logger:                   logrus.WithFields(logrus.Fields{}),
+		clusterClients:           make(map[string]ClusterClient),
+		catalogClients:           make(map[string]CatalogClient),
+		settingClients:           make(map[string]SettingClient),
+		roleTemplateClients:      make(map[string]RoleTemplateClient),
+		userClients:              make(map[string]UserClient),
+		globalRoleBindingClients: make(map[string]GlobalRoleBindingClient),
+		globalDNSProviderClients: make(map[string]GlobalDNSProviderClient),
+		globalDNSEntryClients:    make(map[string]GlobalDNSEntryClient),
+		multiClusterAppClients:   make(map[string]MultiClusterAppClient),
 	}, nil
 }
 
@@ -38,6 +49,15 @@ type RancherConfig struct {
 	Insecure     bool
 	CACerts      string
 	MergeAnswers bool
+	// WaitTimeout is the time to wait for created or upgraded apps and workloads to become ready,
+	// zero does not wait
+	WaitTimeout time.Duration
+	// InitScriptShell is the shell command the init scripts of persistent volumes are appended to e.g. "sh -c"
+	InitScriptShell string
+	// InitScriptTimeout is the time an init script of a persistent volume may run, zero does not limit it
+	InitScriptTimeout time.Duration
+	// Prune allows upgrades to remove what cattlectl created but is no longer declared e.g. the roles of members
+	Prune bool
 }
 
 type rancherClient struct {
@@ -46,9 +66,25 @@ type rancherClient struct {
 	logger                *logrus.Entry
 	clusterClients        map[string]ClusterClient
 	catalogClients        map[string]CatalogClient
+	settingClients        map[string]SettingClient
+	roleTemplateClients   map[string]RoleTemplateClient
+	userClients           map[string]UserClient
+	// globalRoleBindingClients are cached by the name of the binding, see globalRoleBindingName
+	globalRoleBindingClients map[string]GlobalRoleBindingClient
+	globalDNSProviderClients map[string]GlobalDNSProviderClient
+	globalDNSEntryClients    map[string]GlobalDNSEntryClient
+	multiClusterAppClients   map[string]MultiClusterAppClient
+	// cacheLock guards clusterClients, catalogClients, settingClients, roleTemplateClients, userClients,
+	// globalRoleBindingClients, globalDNSProviderClients, globalDNSEntryClients and multiClusterAppClients
+	// which are filled by the convergers of a rancher descriptor
+	cacheLock sync.Mutex
+	// initLock guards the creation of _backendRancherClient on first use
+	initLock sync.Mutex
 }
 
 func (client *rancherClient) init() error {
+	client.initLock.Lock()
+	defer client.initLock.Unlock()
 	if client._backendRancherClient != nil {
 		return nil
 	}
@@ -61,6 +97,8 @@ func (client *rancherClient) init() error {
 }
 
 func (client *rancherClient) Cluster(clusterName string) (ClusterClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.clusterClients[clusterName]; exists {
 		return cache, nil
 	}
@@ -95,6 +133,8 @@ func (client *rancherClient) Clusters() ([]ClusterClient, error) {
 }
 
 func (client *rancherClient) Catalog(catalogName string) (CatalogClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
 	if cache, exists := client.catalogClients[catalogName]; exists {
 		return cache, nil
 	}
@@ -128,6 +168,127 @@ func (client *rancherClient) Catalogs() ([]CatalogClient, error) {
 	return result, nil
 }
 
+func (client *rancherClient) Setting(settingName string) (SettingClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.settingClients[settingName]; exists {
+		return cache, nil
+	}
+	result, err := newSettingClient(settingName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.settingClients[settingName] = result
+	return result, nil
+}
+
+func (client *rancherClient) RoleTemplate(roleTemplateName string) (RoleTemplateClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.roleTemplateClients[roleTemplateName]; exists {
+		return cache, nil
+	}
+	result, err := newRoleTemplateClient(roleTemplateName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.roleTemplateClients[roleTemplateName] = result
+	return result, nil
+}
+
+func (client *rancherClient) User(username string) (UserClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.userClients[username]; exists {
+		return cache, nil
+	}
+	result, err := newUserClient(username, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.userClients[username] = result
+	return result, nil
+}
+
+func (client *rancherClient) GlobalRoleBinding(globalRoleBinding rancherModel.GlobalRoleBinding) (GlobalRoleBindingClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	name := globalRoleBindingName(globalRoleBinding)
+	if cache, exists := client.globalRoleBindingClients[name]; exists {
+		return cache, nil
+	}
+	result, err := newGlobalRoleBindingClientWithData(globalRoleBinding, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.globalRoleBindingClients[name] = result
+	return result, nil
+}
+
+func (client *rancherClient) GlobalDNSProvider(providerName string) (GlobalDNSProviderClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.globalDNSProviderClients[providerName]; exists {
+		return cache, nil
+	}
+	result, err := newGlobalDNSProviderClient(providerName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.globalDNSProviderClients[providerName] = result
+	return result, nil
+}
+
+func (client *rancherClient) GlobalDNSEntry(entryName string) (GlobalDNSEntryClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.globalDNSEntryClients[entryName]; exists {
+		return cache, nil
+	}
+	result, err := newGlobalDNSEntryClient(entryName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.globalDNSEntryClients[entryName] = result
+	return result, nil
+}
+
+func (client *rancherClient) MultiClusterApp(multiClusterAppName string) (MultiClusterAppClient, error) {
+	client.cacheLock.Lock()
+	defer client.cacheLock.Unlock()
+	if cache, exists := client.multiClusterAppClients[multiClusterAppName]; exists {
+		return cache, nil
+	}
+	result, err := newMultiClusterAppClient(multiClusterAppName, client, client.logger)
+	if err != nil {
+		return nil, err
+	}
+	client.multiClusterAppClients[multiClusterAppName] = result
+	return result, nil
+}
+
+func (client *rancherClient) MultiClusterApps() ([]MultiClusterAppClient, error) {
+	backendRancherClient, err := client.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := backendRancherClient.MultiClusterApp.List(&types.ListOpts{
+		Filters: map[string]interface{}{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]MultiClusterAppClient, len(collection.Data))
+	for i, backendMultiClusterApp := range collection.Data {
+		multiClusterApp, err := client.MultiClusterApp(backendMultiClusterApp.Name)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = multiClusterApp
+	}
+	return result, nil
+}
+
 func (client *rancherClient) backendRancherClient() (*backendRancherClient.Client, error) {
 	if err := client.init(); err != nil {
 		return nil, err
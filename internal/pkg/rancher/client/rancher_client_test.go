@@ -199,10 +199,17 @@ func Test_rancherClient_Catalogs(t *testing.T) {
 
 func simpleRancherClient() *rancherClient {
 	return &rancherClient{
-		config:         RancherConfig{},
-		logger:         logrus.WithFields(logrus.Fields{}),
-		clusterClients: make(map[string]ClusterClient),
-		catalogClients: make(map[string]CatalogClient),
+		config:                   RancherConfig{},
+		logger:                   logrus.WithFields(logrus.Fields{}),
+		clusterClients:           make(map[string]ClusterClient),
+		catalogClients:           make(map[string]CatalogClient),
+		settingClients:           make(map[string]SettingClient),
+		roleTemplateClients:      make(map[string]RoleTemplateClient),
+		userClients:              make(map[string]UserClient),
+		globalRoleBindingClients: make(map[string]GlobalRoleBindingClient),
+		globalDNSProviderClients: make(map[string]GlobalDNSProviderClient),
+		globalDNSEntryClients:    make(map[string]GlobalDNSEntryClient),
+		multiClusterAppClients:   make(map[string]MultiClusterAppClient),
 	}
 }
 
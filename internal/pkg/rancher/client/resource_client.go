@@ -16,6 +16,7 @@ package client
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 )
@@ -23,10 +24,22 @@ import (
 // EmptyResourceClient is a ResourceClient with always exists and dose nothing
 var EmptyResourceClient = emptyResourceClient{}
 
+// RolledBackError reports a failed upgrade which was rolled back to the previous revision
+type RolledBackError struct {
+	Revision string
+	Err      error
+}
+
+func (err RolledBackError) Error() string {
+	return fmt.Sprintf("%v, rolled back to revision %s", err.Err, err.Revision)
+}
+
 type resourceClient struct {
 	id     string
 	name   string
 	logger *logrus.Entry
+	// idLock guards the lookup of the id, which is shared by concurrent convergers
+	idLock sync.Mutex
 }
 
 func (client *resourceClient) ID() (string, error) {
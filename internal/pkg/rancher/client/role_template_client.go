@@ -0,0 +1,204 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newRoleTemplateClientWithData(
+	roleTemplate rancherModel.RoleTemplate,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (RoleTemplateClient, error) {
+	result, err := newRoleTemplateClient(
+		roleTemplate.Name,
+		rancherClient,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(roleTemplate)
+	return result, err
+}
+
+func newRoleTemplateClient(
+	name string,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (RoleTemplateClient, error) {
+	return &roleTemplateClient{
+		resourceClient: resourceClient{
+			name:   name,
+			logger: logger.WithField("role_template_name", name),
+		},
+		rancherClient: rancherClient,
+	}, nil
+}
+
+type roleTemplateClient struct {
+	resourceClient
+	roleTemplate  rancherModel.RoleTemplate
+	rancherClient RancherClient
+}
+
+func (client *roleTemplateClient) Type() string {
+	return rancherModel.RancherRoleTemplate
+}
+
+func (client *roleTemplateClient) Exists() (bool, error) {
+	existingRoleTemplate, err := client.loadExistingRoleTemplate()
+	if err != nil {
+		return false, err
+	}
+	if existingRoleTemplate == nil {
+		client.logger.Debug("Role template not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *roleTemplateClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+
+	client.logger.Info("Create new role template")
+	newRoleTemplate := backendRancherClient.RoleTemplate{
+		Name: client.roleTemplate.Name,
+		Labels: map[string]string{
+			"cattlectl.io/hash": hashOf(client.roleTemplate),
+		},
+	}
+	client.applyRoleTemplate(&newRoleTemplate)
+
+	if dryRun {
+		client.logger.WithField("object", newRoleTemplate).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.RoleTemplate.Create(&newRoleTemplate)
+	}
+	return err == nil, err
+}
+
+func (client *roleTemplateClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingRoleTemplate, err := client.loadExistingRoleTemplate()
+	if err != nil {
+		return
+	}
+	if existingRoleTemplate == nil {
+		return changed, fmt.Errorf("Role template %v not found", client.name)
+	}
+	if existingRoleTemplate.Builtin {
+		return changed, fmt.Errorf("Role template %v is builtin and can not be changed", client.name)
+	}
+	if existingRoleTemplate.Labels["cattlectl.io/hash"] == hashOf(client.roleTemplate) {
+		client.logger.Debug("Skip upgrade role template - no changes")
+		return
+	}
+	client.logger.Info("Upgrade RoleTemplate")
+	existingRoleTemplate.Labels = withHashLabel(existingRoleTemplate.Labels, hashOf(client.roleTemplate))
+	client.applyRoleTemplate(existingRoleTemplate)
+
+	if dryRun {
+		client.logger.WithField("object", existingRoleTemplate).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.RoleTemplate.Replace(existingRoleTemplate)
+	}
+	return err == nil, err
+}
+
+func (client *roleTemplateClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingRoleTemplate, err := client.loadExistingRoleTemplate()
+	if err != nil {
+		return
+	}
+	if existingRoleTemplate == nil {
+		return changed, fmt.Errorf("Role template %v not found", client.name)
+	}
+	if dryRun {
+		client.logger.WithField("object", existingRoleTemplate).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.RoleTemplate.Delete(existingRoleTemplate)
+	}
+	return err == nil, err
+}
+
+func (client *roleTemplateClient) Data() (rancherModel.RoleTemplate, error) {
+	return client.roleTemplate, nil
+}
+
+func (client *roleTemplateClient) SetData(roleTemplate rancherModel.RoleTemplate) error {
+	client.name = roleTemplate.Name
+	client.roleTemplate = roleTemplate
+	return nil
+}
+
+// applyRoleTemplate sets the declared fields of the role template on the backend object
+func (client *roleTemplateClient) applyRoleTemplate(backendRoleTemplate *backendRancherClient.RoleTemplate) {
+	backendRoleTemplate.Context = client.roleTemplate.Context
+	backendRoleTemplate.Description = client.roleTemplate.Description
+	backendRoleTemplate.RoleTemplateIDs = client.roleTemplate.RoleTemplateIDs
+	backendRoleTemplate.Locked = client.roleTemplate.Locked
+	backendRoleTemplate.ClusterCreatorDefault = client.roleTemplate.ClusterCreatorDefault
+	backendRoleTemplate.ProjectCreatorDefault = client.roleTemplate.ProjectCreatorDefault
+	backendRoleTemplate.Rules = make([]backendRancherClient.PolicyRule, len(client.roleTemplate.Rules))
+	for i, rule := range client.roleTemplate.Rules {
+		backendRoleTemplate.Rules[i] = backendRancherClient.PolicyRule{
+			APIGroups:       rule.APIGroups,
+			Resources:       rule.Resources,
+			ResourceNames:   rule.ResourceNames,
+			NonResourceURLs: rule.NonResourceURLs,
+			Verbs:           rule.Verbs,
+		}
+	}
+}
+
+func (client *roleTemplateClient) loadExistingRoleTemplate() (*backendRancherClient.RoleTemplate, error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.RoleTemplate.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": client.name,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read role template list")
+		return nil, fmt.Errorf("Failed to read role template list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,129 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+var simpleRoleTemplate = rancherModel.RoleTemplate{
+	Name:    "config-map-reader",
+	Context: "project",
+	Rules: []rancherModel.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	},
+}
+
+func Test_roleTemplateClient_Create(t *testing.T) {
+	testClients := stubs.CreateBackendStubs(t)
+	var created *backendRancherClient.RoleTemplate
+	roleTemplateOperationsStub := stubs.CreateRoleTemplateOperationsStub(t)
+	roleTemplateOperationsStub.DoCreate = func(roleTemplate *backendRancherClient.RoleTemplate) (*backendRancherClient.RoleTemplate, error) {
+		created = roleTemplate
+		return roleTemplate, nil
+	}
+	testClients.ManagementClient.RoleTemplate = roleTemplateOperationsStub
+	rancherClient := simpleRancherClient()
+	rancherClient._backendRancherClient = testClients.ManagementClient
+	client, err := newRoleTemplateClientWithData(simpleRoleTemplate, rancherClient, logrus.New().WithFields(logrus.Fields{}))
+	assert.Ok(t, err)
+
+	changed, err := client.Create(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Role template must be created")
+	assert.Equals(t, &backendRancherClient.RoleTemplate{
+		Name:    "config-map-reader",
+		Context: "project",
+		Rules: []backendRancherClient.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+		Labels: map[string]string{"cattlectl.io/hash": hashOf(simpleRoleTemplate)},
+	}, created)
+}
+
+func Test_roleTemplateClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []backendRancherClient.RoleTemplate
+		wantedChanged bool
+		wantErr       bool
+		wantedErr     string
+	}{
+		{
+			name: "Changed",
+			existing: []backendRancherClient.RoleTemplate{
+				{Name: "config-map-reader", Context: "project"},
+			},
+			wantedChanged: true,
+		},
+		{
+			name: "Unchanged",
+			existing: []backendRancherClient.RoleTemplate{
+				{Name: "config-map-reader", Labels: map[string]string{"cattlectl.io/hash": hashOf(simpleRoleTemplate)}},
+			},
+		},
+		{
+			name: "Builtin",
+			existing: []backendRancherClient.RoleTemplate{
+				{Name: "config-map-reader", Builtin: true},
+			},
+			wantErr:   true,
+			wantedErr: "Role template config-map-reader is builtin and can not be changed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			roleTemplateOperationsStub := stubs.CreateRoleTemplateOperationsStub(t)
+			roleTemplateOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.RoleTemplateCollection, error) {
+				return &backendRancherClient.RoleTemplateCollection{Data: tt.existing}, nil
+			}
+			roleTemplateOperationsStub.DoReplace = func(roleTemplate *backendRancherClient.RoleTemplate) (*backendRancherClient.RoleTemplate, error) {
+				assert.Equals(t, 1, len(roleTemplate.Rules))
+				assert.Equals(t, hashOf(simpleRoleTemplate), roleTemplate.Labels["cattlectl.io/hash"])
+				return roleTemplate, nil
+			}
+			testClients.ManagementClient.RoleTemplate = roleTemplateOperationsStub
+			rancherClient := simpleRancherClient()
+			rancherClient._backendRancherClient = testClients.ManagementClient
+			client, err := newRoleTemplateClientWithData(simpleRoleTemplate, rancherClient, logrus.New().WithFields(logrus.Fields{}))
+			assert.Ok(t, err)
+
+			changed, err := client.Upgrade(false)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Equals(t, tt.wantedErr, err.Error())
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wantedChanged, changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/config"
+)
+
+// shellSafe matches the values which are passed to a shell without quoting
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9@%+=:,./_-]+$`)
+
+// scriptRunner runs a script on the machine cattlectl is running on and returns its combined output
+type scriptRunner interface {
+	run(script string) (output string, err error)
+}
+
+// shellScriptRunner appends the script as last argument to the shell command
+type shellScriptRunner struct {
+	shell   []string
+	timeout time.Duration
+}
+
+func newShellScriptRunner(rancherConfig RancherConfig) scriptRunner {
+	shell := strings.Fields(rancherConfig.InitScriptShell)
+	if len(shell) == 0 {
+		shell = strings.Fields(config.DefaultInitScriptShell)
+	}
+	return shellScriptRunner{
+		shell:   shell,
+		timeout: rancherConfig.InitScriptTimeout,
+	}
+}
+
+func (runner shellScriptRunner) run(script string) (string, error) {
+	ctx := context.Background()
+	if runner.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, runner.timeout)
+		defer cancel()
+	}
+	// the output is written to a file instead of a pipe, otherwise reading the output of a killed
+	// shell blocks until its children (e.g. ssh) close the pipe
+	outputFile, err := ioutil.TempFile("", "cattlectl-script-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(outputFile.Name())
+	defer outputFile.Close()
+	command := exec.CommandContext(ctx, runner.shell[0], append(runner.shell[1:], script)...)
+	command.Stdout = outputFile
+	command.Stderr = outputFile
+	err = command.Run()
+	output, readErr := ioutil.ReadFile(outputFile.Name())
+	if ctx.Err() == context.DeadlineExceeded {
+		return string(output), fmt.Errorf("Script did not finish within %v", runner.timeout)
+	}
+	if err == nil {
+		err = readErr
+	}
+	return string(output), err
+}
+
+// shellQuote quotes the value as a single word of a POSIX shell command
+func shellQuote(value string) string {
+	if shellSafe.MatchString(value) {
+		return value
+	}
+	return "'" + strings.Replace(value, "'", `'"'"'`, -1) + "'"
+}
@@ -0,0 +1,102 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+)
+
+func Test_shellScriptRunner_run(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       RancherConfig
+		script       string
+		wantedOutput string
+		wantErr      bool
+		wantedErr    string
+	}{
+		{
+			name:         "default-shell",
+			script:       "echo hello",
+			wantedOutput: "hello\n",
+		},
+		{
+			name:         "configured-shell",
+			config:       RancherConfig{InitScriptShell: "sh -e -c"},
+			script:       "echo hello; false; echo unreachable",
+			wantedOutput: "hello\n",
+			wantErr:      true,
+			wantedErr:    "exit status 1",
+		},
+		{
+			name:      "timeout",
+			config:    RancherConfig{InitScriptTimeout: 10 * time.Millisecond},
+			script:    "sleep 5",
+			wantErr:   true,
+			wantedErr: "Script did not finish within 10ms",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := newShellScriptRunner(tt.config).run(tt.script)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+			} else {
+				assert.Ok(t, err)
+			}
+			assert.Equals(t, tt.wantedOutput, output)
+		})
+	}
+}
+
+func Test_shellQuote(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		wanted string
+	}{
+		{
+			name:   "safe",
+			value:  "/var/data/local-volume",
+			wanted: "/var/data/local-volume",
+		},
+		{
+			name:   "empty",
+			value:  "",
+			wanted: "''",
+		},
+		{
+			name:   "command",
+			value:  "node-1; rm -rf /",
+			wanted: "'node-1; rm -rf /'",
+		},
+		{
+			name:   "single-quote",
+			value:  "it's",
+			wanted: `'it'"'"'s'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equals(t, tt.wanted, shellQuote(tt.value))
+			output, err := newShellScriptRunner(RancherConfig{}).run("printf %s " + shellQuote(tt.value))
+			assert.Ok(t, err)
+			assert.Equals(t, tt.value, output)
+		})
+	}
+}
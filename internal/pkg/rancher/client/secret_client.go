@@ -63,6 +63,7 @@ func newSecretClient(
 
 type secretClient struct {
 	namespacedResourceClient
+	projectDeclaration
 	secret projectModel.ConfigMap
 }
 
@@ -145,11 +146,9 @@ func (client *secretClient) createInProject(dryRun bool) (changed bool, err erro
 		return
 	}
 	client.logger.Info("Create new Secret")
-	labels := make(map[string]string)
-	labels["cattlectl.io/hash"] = hashOf(client.secret)
 	newSecret := &backendProjectClient.Secret{
 		Name:      client.secret.Name,
-		Labels:    labels,
+		Labels:    client.desiredLabels(nil),
 		Data:      client.secret.Data,
 		ProjectID: projectID,
 	}
@@ -176,11 +175,9 @@ func (client *secretClient) createInNamespace(dryRun bool) (changed bool, err er
 		return changed, fmt.Errorf("Failed to read namespace ID, %v", err)
 	}
 	client.logger.Info("Create new Secret")
-	labels := make(map[string]string)
-	labels["cattlectl.io/hash"] = hashOf(client.secret)
 	newSecret := &backendProjectClient.NamespacedSecret{
 		Name:        client.secret.Name,
-		Labels:      labels,
+		Labels:      client.desiredLabels(nil),
 		Data:        client.secret.Data,
 		NamespaceId: namespaceID,
 		ProjectID:   projectID,
@@ -220,11 +217,12 @@ func (client *secretClient) upgradeInProject(dryRun bool) (changed bool, err err
 		return changed, fmt.Errorf("Secret %v not found", client.name)
 	}
 	existingSecret := collection.Data[0]
-	if isProjectSecretUnchanged(existingSecret, client.secret) {
+	if isProjectSecretUnchanged(existingSecret, client.secret) && client.hasProjectLabel(existingSecret.Labels) {
 		client.logger.Debug("Skip upgrade secret - no changes")
 		return
 	}
 	client.logger.Info("Upgrade Secret")
+	existingSecret.Labels = client.desiredLabels(existingSecret.Labels)
 	existingSecret.Data = client.secret.Data
 
 	if dryRun {
@@ -259,11 +257,12 @@ func (client *secretClient) upgradeInNamespace(dryRun bool) (changed bool, err e
 		return changed, fmt.Errorf("Secret %v not found", client.name)
 	}
 	existingSecret := collection.Data[0]
-	if isNamespacedSecretUnchanged(existingSecret, client.secret) {
+	if isNamespacedSecretUnchanged(existingSecret, client.secret) && client.hasProjectLabel(existingSecret.Labels) {
 		client.logger.Debug("Skip upgrade secret - no changes")
 		return
 	}
 	client.logger.Info("Upgrade Secret")
+	existingSecret.Labels = client.desiredLabels(existingSecret.Labels)
 	existingSecret.Data = client.secret.Data
 
 	if dryRun {
@@ -274,6 +273,70 @@ func (client *secretClient) upgradeInNamespace(dryRun bool) (changed bool, err e
 	return err == nil, err
 }
 
+func (client *secretClient) Delete(dryRun bool) (changed bool, err error) {
+	if client.namespace != "" {
+		return client.deleteInNamespace(dryRun)
+	}
+	return client.deleteInProject(dryRun)
+}
+
+func (client *secretClient) deleteInProject(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingSecret, err := client.loadExistingProjectSecret()
+	if err != nil {
+		return
+	}
+	if existingSecret == nil {
+		return changed, fmt.Errorf("Secret %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingSecret.Name).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.Secret.Delete(existingSecret)
+	}
+	return err == nil, err
+}
+
+func (client *secretClient) deleteInNamespace(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingSecret, err := client.loadExistingNamespacedSecret()
+	if err != nil {
+		return
+	}
+	if existingSecret == nil {
+		return changed, fmt.Errorf("Secret %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingSecret.Name).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.NamespacedSecret.Delete(existingSecret)
+	}
+	return err == nil, err
+}
+
+func (client *secretClient) Owned() (bool, error) {
+	if client.namespace != "" {
+		existingSecret, err := client.loadExistingNamespacedSecret()
+		if err != nil || existingSecret == nil {
+			return false, err
+		}
+		return isDeclaredByProject(existingSecret.Labels), nil
+	}
+	existingSecret, err := client.loadExistingProjectSecret()
+	if err != nil || existingSecret == nil {
+		return false, err
+	}
+	return isDeclaredByProject(existingSecret.Labels), nil
+}
+
 func (client *secretClient) Data() (projectModel.ConfigMap, error) {
 	return client.secret, nil
 }
@@ -284,6 +347,66 @@ func (client *secretClient) SetData(secret projectModel.ConfigMap) error {
 	return nil
 }
 
+func (client *secretClient) loadExistingProjectSecret() (existingSecret *backendProjectClient.Secret, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.Secret.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": client.name,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read secret list")
+		err = fmt.Errorf("Failed to read secret list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			existingSecret = &item
+			return
+		}
+	}
+	return
+}
+
+func (client *secretClient) loadExistingNamespacedSecret() (existingSecret *backendProjectClient.NamespacedSecret, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.NamespacedSecret.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read secret list")
+		err = fmt.Errorf("Failed to read secret list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name && item.NamespaceId == namespaceID {
+			existingSecret = &item
+			return
+		}
+	}
+	return
+}
+
+// desiredLabels adds the labels of cattlectl to the given labels
+func (client *secretClient) desiredLabels(labels map[string]string) map[string]string {
+	return client.withProjectLabel(withHashLabel(labels, hashOf(client.secret)))
+}
+
 func isProjectSecretUnchanged(existingSecret backendProjectClient.Secret, secret projectModel.ConfigMap) bool {
 	return reflect.DeepEqual(existingSecret.Data, secret.Data)
 }
@@ -109,6 +109,28 @@ func Test_secretClient_Create(t *testing.T) {
 	}
 }
 
+func Test_secretClient_Delete(t *testing.T) {
+	var deleted *backendProjectClient.NamespacedSecret
+	client := existingSecretClient(
+		t,
+		&types.ListOpts{
+			Filters: map[string]interface{}{
+				"name":        "existing-secret",
+				"namespaceId": "test-namespace-id",
+			},
+		},
+	)
+	namespacedSecretOperationsStub := client.project.(*projectClient)._backendProjectClient.NamespacedSecret.(*stubs.NamespacedSecretOperationsStub)
+	namespacedSecretOperationsStub.DoDelete = func(secret *backendProjectClient.NamespacedSecret) error {
+		deleted = secret
+		return nil
+	}
+	changed, err := client.Delete(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Delete should report a change")
+	assert.Equals(t, "existing-secret", deleted.Name)
+}
+
 func existingSecretClient(t *testing.T, expectedListOpts *types.ListOpts) *secretClient {
 	const (
 		projectID   = "test-project-id"
@@ -0,0 +1,261 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newServiceClientWithData(
+	service projectModel.Service,
+	namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (ServiceClient, error) {
+	result, err := newServiceClient(
+		service.Name,
+		namespace,
+		project,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(service)
+	return result, err
+}
+
+func newServiceClient(
+	name, namespace string,
+	project ProjectClient,
+	logger *logrus.Entry,
+) (ServiceClient, error) {
+	return &serviceClient{
+		namespacedResourceClient: namespacedResourceClient{
+			resourceClient: resourceClient{
+				name:   name,
+				logger: logger.WithField("service_name", name).WithField("namespace", namespace),
+			},
+			namespace: namespace,
+			project:   project,
+		},
+	}, nil
+}
+
+type serviceClient struct {
+	namespacedResourceClient
+	projectDeclaration
+	service projectModel.Service
+}
+
+func (client *serviceClient) Type() string {
+	return rancherModel.ServiceKind
+}
+
+func (client *serviceClient) Exists() (bool, error) {
+	existingService, err := client.loadExistingService()
+	if err != nil {
+		return false, err
+	}
+	if existingService == nil {
+		client.logger.Debug("Service not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *serviceClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	projectID, err := client.project.ID()
+	if err != nil {
+		return
+	}
+	client.logger.Info("Create new Service")
+	newService, err := client.desiredService()
+	if err != nil {
+		return
+	}
+	newService.NamespaceId = namespaceID
+	newService.ProjectID = projectID
+
+	if dryRun {
+		client.logger.WithField("object", newService).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.Service.Create(&newService)
+	}
+	return err == nil, err
+}
+
+func (client *serviceClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingService, err := client.loadExistingService()
+	if err != nil {
+		return
+	}
+	if existingService == nil {
+		return changed, fmt.Errorf("Service %v not found", client.name)
+	}
+	if isServiceUnchanged(*existingService, client.service) && client.hasProjectLabel(existingService.Labels) {
+		client.logger.Debug("Skip upgrade service - no changes")
+		return
+	}
+	client.logger.Info("Upgrade Service")
+	desiredService, err := client.desiredService()
+	if err != nil {
+		return
+	}
+	existingService.Labels = desiredService.Labels
+	existingService.Annotations = desiredService.Annotations
+	existingService.Kind = desiredService.Kind
+	existingService.Hostname = desiredService.Hostname
+	existingService.ExternalTrafficPolicy = desiredService.ExternalTrafficPolicy
+	existingService.LoadBalancerIP = desiredService.LoadBalancerIP
+	existingService.SessionAffinity = desiredService.SessionAffinity
+	existingService.Selector = desiredService.Selector
+	existingService.Ports = desiredService.Ports
+
+	if dryRun {
+		client.logger.WithField("object", existingService).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.Service.Replace(existingService)
+	}
+	return err == nil, err
+}
+
+func (client *serviceClient) Desired() (interface{}, error) {
+	return client.desiredService()
+}
+
+func (client *serviceClient) Existing() (interface{}, error) {
+	existingService, err := client.loadExistingService()
+	if err != nil || existingService == nil {
+		return nil, err
+	}
+	return *existingService, nil
+}
+
+func (client *serviceClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *serviceClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingService()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isServiceUnchanged(*existing, client.service) && client.hasProjectLabel(existing.Labels), nil
+}
+
+func (client *serviceClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingService, err := client.loadExistingService()
+	if err != nil {
+		return
+	}
+	if existingService == nil {
+		return changed, fmt.Errorf("Service %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingService).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.Service.Delete(existingService)
+	}
+	return err == nil, err
+}
+
+func (client *serviceClient) Owned() (bool, error) {
+	existingService, err := client.loadExistingService()
+	if err != nil || existingService == nil {
+		return false, err
+	}
+	return isDeclaredByProject(existingService.Labels), nil
+}
+
+func (client *serviceClient) Data() (projectModel.Service, error) {
+	return client.service, nil
+}
+
+func (client *serviceClient) SetData(service projectModel.Service) error {
+	client.name = service.Name
+	client.service = service
+	return nil
+}
+
+func (client *serviceClient) desiredService() (backendProjectClient.Service, error) {
+	service, err := projectModel.ConvertServiceToProjectAPI(client.service)
+	if err != nil {
+		return service, err
+	}
+	service.Labels = client.withProjectLabel(withHashLabel(service.Labels, hashOf(client.service)))
+	return service, nil
+}
+
+func (client *serviceClient) loadExistingService() (existingService *backendProjectClient.Service, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.Service.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read service list")
+		err = fmt.Errorf("Failed to read service list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name && item.NamespaceId == namespaceID {
+			existingService = &item
+			return
+		}
+	}
+	return
+}
+
+func isServiceUnchanged(existingService backendProjectClient.Service, service projectModel.Service) bool {
+	hash, hashExists := existingService.Labels["cattlectl.io/hash"]
+	if !hashExists {
+		return false
+	}
+	return hash == hashOf(service)
+}
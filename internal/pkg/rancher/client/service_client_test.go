@@ -0,0 +1,237 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_serviceClient_Exists(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []backendProjectClient.Service
+		wanted   bool
+	}{
+		{
+			name: "Existing",
+			existing: []backendProjectClient.Service{
+				{Name: "test-service", NamespaceId: "test-namespace-id"},
+			},
+			wanted: true,
+		},
+		{
+			name: "Other_Namespace",
+			existing: []backendProjectClient.Service{
+				{Name: "test-service", NamespaceId: "other-namespace-id"},
+			},
+			wanted: false,
+		},
+		{
+			name:     "Not_Existing",
+			existing: []backendProjectClient.Service{},
+			wanted:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := testServiceClient(t, tt.existing)
+			got, err := client.Exists()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wanted, got)
+		})
+	}
+}
+
+func Test_serviceClient_Create(t *testing.T) {
+	client, stub := testServiceClient(t, []backendProjectClient.Service{})
+	var created *backendProjectClient.Service
+	stub.DoCreate = func(service *backendProjectClient.Service) (*backendProjectClient.Service, error) {
+		created = service
+		return service, nil
+	}
+	changed, err := client.Create(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Create should report a change")
+	assert.Equals(t, "test-service", created.Name)
+	assert.Equals(t, "test-namespace-id", created.NamespaceId)
+	assert.Equals(t, "test-project-id", created.ProjectID)
+	assert.Equals(t, "ClusterIP", created.Kind)
+	assert.Equals(t, map[string]string{"app": "web"}, created.Selector)
+	assert.Equals(t, []backendProjectClient.ServicePort{
+		{Name: "http", Protocol: "TCP", Port: 80, TargetPort: intstr.FromString("http")},
+	}, created.Ports)
+	assert.Equals(t, hashOf(client.service), created.Labels["cattlectl.io/hash"])
+	assert.Equals(t, "web", created.Labels["tier"])
+	_, declared := created.Labels["cattlectl.io/project"]
+	assert.Assert(t, !declared, "Standalone service must not be marked as declared by the project")
+}
+
+func Test_serviceClient_Create_DeclaredByProject(t *testing.T) {
+	client, stub := testServiceClient(t, []backendProjectClient.Service{})
+	client.DeclaredBy("test-project-name")
+	var created *backendProjectClient.Service
+	stub.DoCreate = func(service *backendProjectClient.Service) (*backendProjectClient.Service, error) {
+		created = service
+		return service, nil
+	}
+	_, err := client.Create(false)
+	assert.Ok(t, err)
+	assert.Equals(t, "test-project-name", created.Labels["cattlectl.io/project"])
+}
+
+func Test_serviceClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name             string
+		hash             string
+		declaringProject string
+		wantChanged      bool
+	}{
+		{
+			name:        "Changed",
+			hash:        "outdated-hash",
+			wantChanged: true,
+		},
+		{
+			name:        "Unchanged",
+			wantChanged: false,
+		},
+		{
+			name:             "Declared_By_Project",
+			declaringProject: "test-project-name",
+			wantChanged:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := backendProjectClient.Service{
+				Name:        "test-service",
+				NamespaceId: "test-namespace-id",
+				ClusterIp:   "10.43.0.10",
+				Labels:      map[string]string{"cattlectl.io/hash": tt.hash},
+			}
+			client, stub := testServiceClient(t, []backendProjectClient.Service{existing})
+			client.DeclaredBy(tt.declaringProject)
+			if tt.hash == "" {
+				existing.Labels["cattlectl.io/hash"] = hashOf(client.service)
+			}
+			var replaced *backendProjectClient.Service
+			stub.DoReplace = func(service *backendProjectClient.Service) (*backendProjectClient.Service, error) {
+				replaced = service
+				return service, nil
+			}
+			changed, err := client.Upgrade(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantChanged, changed)
+			if tt.wantChanged {
+				assert.Equals(t, "10.43.0.10", replaced.ClusterIp)
+				assert.Equals(t, map[string]string{"app": "web"}, replaced.Selector)
+				assert.Equals(t, hashOf(client.service), replaced.Labels["cattlectl.io/hash"])
+				assert.Equals(t, tt.declaringProject, replaced.Labels["cattlectl.io/project"])
+			}
+		})
+	}
+}
+
+func Test_serviceClient_Owned(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		wanted bool
+	}{
+		{
+			name:   "Declared_By_Project",
+			labels: map[string]string{"cattlectl.io/hash": "some-hash", "cattlectl.io/project": "test-project-name"},
+			wanted: true,
+		},
+		{
+			name:   "Standalone_Service",
+			labels: map[string]string{"cattlectl.io/hash": "some-hash"},
+			wanted: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := testServiceClient(t, []backendProjectClient.Service{
+				{Name: "test-service", NamespaceId: "test-namespace-id", Labels: tt.labels},
+			})
+			got, err := client.Owned()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wanted, got)
+		})
+	}
+}
+
+func Test_serviceClient_Delete(t *testing.T) {
+	client, stub := testServiceClient(t, []backendProjectClient.Service{
+		{Name: "test-service", NamespaceId: "test-namespace-id", Labels: map[string]string{"cattlectl.io/hash": "some-hash", "cattlectl.io/project": "test-project-name"}},
+	})
+	var deleted *backendProjectClient.Service
+	stub.DoDelete = func(service *backendProjectClient.Service) error {
+		deleted = service
+		return nil
+	}
+	owned, err := client.Owned()
+	assert.Ok(t, err)
+	assert.Assert(t, owned, "Service should be owned")
+	changed, err := client.Delete(false)
+	assert.Ok(t, err)
+	assert.Assert(t, changed, "Delete should report a change")
+	assert.Equals(t, "test-service", deleted.Name)
+}
+
+func testServiceClient(t *testing.T, existing []backendProjectClient.Service) (*serviceClient, *stubs.ServiceOperationsStub) {
+	testClients := stubs.CreateBackendStubs(t)
+	serviceOperationsStub := stubs.CreateServiceOperationsStub(t)
+	serviceOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.ServiceCollection, error) {
+		assert.Equals(t, map[string]interface{}{
+			"name":        "test-service",
+			"namespaceId": "test-namespace-id",
+		}, opts.Filters)
+		return &backendProjectClient.ServiceCollection{Data: existing}, nil
+	}
+	testClients.ProjectClient.Service = serviceOperationsStub
+	result, err := newServiceClientWithData(
+		projectModel.Service{
+			Name:     "test-service",
+			Type:     "ClusterIP",
+			Selector: map[string]string{"app": "web"},
+			Ports: []projectModel.ServicePort{
+				{Name: "http", Protocol: "TCP", Port: 80, TargetPort: projectModel.IntOrString(intstr.FromString("http"))},
+			},
+			Labels: map[string]string{"tier": "web"},
+		},
+		"test-namespace",
+		&projectClient{
+			resourceClient: resourceClient{
+				name: "test-project-name",
+				id:   "test-project-id",
+			},
+			_backendProjectClient: testClients.ProjectClient,
+		},
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	serviceClientResult := result.(*serviceClient)
+	serviceClientResult.namespaceID = "test-namespace-id"
+	return serviceClientResult, serviceOperationsStub
+}
@@ -0,0 +1,179 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newSettingClientWithData(
+	setting rancherModel.Setting,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (SettingClient, error) {
+	result, err := newSettingClient(
+		setting.Name,
+		rancherClient,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(setting)
+	return result, err
+}
+
+func newSettingClient(
+	name string,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (SettingClient, error) {
+	return &settingClient{
+		resourceClient: resourceClient{
+			name:   name,
+			logger: logger.WithField("setting_name", name),
+		},
+		rancherClient: rancherClient,
+	}, nil
+}
+
+// settingClient manages a global setting of rancher, the value is compared directly
+// as most settings are created by rancher itself and not labeled by cattlectl
+type settingClient struct {
+	resourceClient
+	setting       rancherModel.Setting
+	rancherClient RancherClient
+}
+
+func (client *settingClient) Type() string {
+	return rancherModel.RancherSetting
+}
+
+func (client *settingClient) Exists() (bool, error) {
+	existingSetting, err := client.loadExistingSetting()
+	if err != nil {
+		return false, err
+	}
+	if existingSetting == nil {
+		client.logger.Debug("Setting not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *settingClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+
+	client.logger.Info("Create new setting")
+	newSetting := backendRancherClient.Setting{
+		Name:  client.setting.Name,
+		Value: client.setting.Value,
+	}
+
+	if dryRun {
+		client.logger.WithField("object", newSetting).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.Setting.Create(&newSetting)
+	}
+	return err == nil, err
+}
+
+func (client *settingClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingSetting, err := client.loadExistingSetting()
+	if err != nil {
+		return
+	}
+	if existingSetting == nil {
+		return changed, fmt.Errorf("Setting %v not found", client.name)
+	}
+	if existingSetting.Value == client.setting.Value {
+		client.logger.Debug("Skip upgrade setting - no changes")
+		return
+	}
+	client.logger.Info("Upgrade Setting")
+	existingSetting.Value = client.setting.Value
+
+	if dryRun {
+		client.logger.WithField("object", existingSetting).Info("Do Dry-Run Upgrade")
+	} else {
+		_, err = backendClient.Setting.Replace(existingSetting)
+	}
+	return err == nil, err
+}
+
+func (client *settingClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingSetting, err := client.loadExistingSetting()
+	if err != nil {
+		return
+	}
+	if existingSetting == nil {
+		return changed, fmt.Errorf("Setting %v not found", client.name)
+	}
+	if dryRun {
+		client.logger.WithField("object", existingSetting).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.Setting.Delete(existingSetting)
+	}
+	return err == nil, err
+}
+
+func (client *settingClient) Data() (rancherModel.Setting, error) {
+	return client.setting, nil
+}
+
+func (client *settingClient) SetData(setting rancherModel.Setting) error {
+	client.name = setting.Name
+	client.setting = setting
+	return nil
+}
+
+func (client *settingClient) loadExistingSetting() (*backendRancherClient.Setting, error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.Setting.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": client.name,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read setting list")
+		return nil, fmt.Errorf("Failed to read setting list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
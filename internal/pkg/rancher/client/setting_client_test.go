@@ -0,0 +1,96 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_settingClient_Upgrade(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []backendRancherClient.Setting
+		setting       rancherModel.Setting
+		dryRun        bool
+		wantedChanged bool
+		wantedValue   string
+		wantErr       bool
+		wantedErr     string
+	}{
+		{
+			name:          "Changed",
+			existing:      []backendRancherClient.Setting{{Name: "server-url", Value: "https://old.example.com"}},
+			setting:       rancherModel.Setting{Name: "server-url", Value: "https://rancher.example.com"},
+			wantedChanged: true,
+			wantedValue:   "https://rancher.example.com",
+		},
+		{
+			name:     "Unchanged",
+			existing: []backendRancherClient.Setting{{Name: "server-url", Value: "https://rancher.example.com"}},
+			setting:  rancherModel.Setting{Name: "server-url", Value: "https://rancher.example.com"},
+		},
+		{
+			name:          "Dry_Run",
+			existing:      []backendRancherClient.Setting{{Name: "server-url", Value: "https://old.example.com"}},
+			setting:       rancherModel.Setting{Name: "server-url", Value: "https://rancher.example.com"},
+			dryRun:        true,
+			wantedChanged: true,
+		},
+		{
+			name:      "Not_Existing",
+			existing:  []backendRancherClient.Setting{},
+			setting:   rancherModel.Setting{Name: "server-url", Value: "https://rancher.example.com"},
+			wantErr:   true,
+			wantedErr: "Setting server-url not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			replacedValue := ""
+			settingOperationsStub := stubs.CreateSettingOperationsStub(t)
+			settingOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.SettingCollection, error) {
+				assert.Equals(t, tt.setting.Name, opts.Filters["name"])
+				return &backendRancherClient.SettingCollection{Data: tt.existing}, nil
+			}
+			settingOperationsStub.DoReplace = func(setting *backendRancherClient.Setting) (*backendRancherClient.Setting, error) {
+				replacedValue = setting.Value
+				return setting, nil
+			}
+			testClients.ManagementClient.Setting = settingOperationsStub
+			rancherClient := simpleRancherClient()
+			rancherClient._backendRancherClient = testClients.ManagementClient
+			client, err := newSettingClientWithData(tt.setting, rancherClient, logrus.New().WithFields(logrus.Fields{}))
+			assert.Ok(t, err)
+
+			changed, err := client.Upgrade(tt.dryRun)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Equals(t, tt.wantedErr, err.Error())
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wantedChanged, changed)
+				assert.Equals(t, tt.wantedValue, replacedValue)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 	"github.com/rancher/norman/types"
+	backendProjectClient "github.com/rancher/types/client/project/v3"
 	"github.com/sirupsen/logrus"
 )
 
@@ -111,18 +112,83 @@ func (client *statefulSetClient) Create(dryRun bool) (changed bool, err error) {
 		return
 	}
 	pattern.NamespaceId = namespaceID
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.statefulSet))
 
 	if dryRun {
 		client.logger.WithField("object", pattern).Info("Do Dry-Run Create")
-	} else {
-		_, err = backendClient.StatefulSet.Create(&pattern)
+	} else if _, err = backendClient.StatefulSet.Create(&pattern); err == nil {
+		err = waitUntilReady(client.project.config(), client.logger, client.ready)
 	}
 	return err == nil, err
 }
 
 func (client *statefulSetClient) Upgrade(dryRun bool) (changed bool, err error) {
-	client.logger.Warn("Skip change existing statefulset")
-	return
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	existingStatefulSet, err := client.loadExistingStatefulSet()
+	if err != nil {
+		return
+	}
+	if existingStatefulSet == nil {
+		return changed, fmt.Errorf("StatefulSet %v not found", client.name)
+	}
+	if isStatefulSetUnchanged(*existingStatefulSet, client.statefulSet) {
+		client.logger.Debug("Skip upgrade statefulset - no changes")
+		return
+	}
+	client.logger.Info("Upgrade statefulset")
+	pattern, err := projectModel.ConvertStatefulSetToProjectAPI(client.statefulSet)
+	if err != nil {
+		return
+	}
+	pattern.Resource = existingStatefulSet.Resource
+	pattern.NamespaceId = existingStatefulSet.NamespaceId
+	if client.statefulSet.Autoscaling != nil {
+		// the replicas are managed by the horizontal pod autoscaler
+		pattern.Scale = existingStatefulSet.Scale
+	}
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.statefulSet))
+
+	if dryRun {
+		client.logger.WithField("object", pattern).Info("Do Dry-Run Upgrade")
+	} else if _, err = backendClient.StatefulSet.Replace(&pattern); err == nil {
+		err = waitUntilReady(client.project.config(), client.logger, client.ready)
+	}
+	return err == nil, err
+}
+
+func (client *statefulSetClient) Desired() (interface{}, error) {
+	pattern, err := projectModel.ConvertStatefulSetToProjectAPI(client.statefulSet)
+	if err != nil {
+		return nil, err
+	}
+	if client.statefulSet.Autoscaling != nil {
+		pattern.Scale = nil
+	}
+	pattern.WorkloadLabels = withHashLabel(pattern.WorkloadLabels, hashOf(client.statefulSet))
+	return pattern, nil
+}
+
+func (client *statefulSetClient) Existing() (interface{}, error) {
+	existingStatefulSet, err := client.loadExistingStatefulSet()
+	if err != nil || existingStatefulSet == nil {
+		return nil, err
+	}
+	return *existingStatefulSet, nil
+}
+
+func (client *statefulSetClient) SkipsUpgrade() bool {
+	return false
+}
+
+func (client *statefulSetClient) Unchanged() (bool, error) {
+	existing, err := client.loadExistingStatefulSet()
+	if err != nil || existing == nil {
+		return false, err
+	}
+	return isStatefulSetUnchanged(*existing, client.statefulSet), nil
 }
 
 func (client *statefulSetClient) Data() (projectModel.StatefulSet, error) {
@@ -134,3 +200,64 @@ func (client *statefulSetClient) SetData(statefulSet projectModel.StatefulSet) e
 	client.statefulSet = statefulSet
 	return nil
 }
+
+// ready is true if all replicas of the stateful set are ready
+func (client *statefulSetClient) ready() (bool, error) {
+	existingStatefulSet, err := client.loadExistingStatefulSet()
+	if err != nil {
+		return false, err
+	}
+	if existingStatefulSet == nil {
+		return false, fmt.Errorf("StatefulSet %v not found", client.name)
+	}
+	if err = transitioningError(existingStatefulSet.State, existingStatefulSet.Transitioning, existingStatefulSet.TransitioningMessage); err != nil {
+		return false, err
+	}
+	status := existingStatefulSet.StatefulSetStatus
+	if status == nil {
+		return false, nil
+	}
+	// right after a change the status may still describe the previous spec
+	if observed, err := generationObserved(client.project, workloadPath(client.namespace, "statefulsets", client.name), status.ObservedGeneration); err != nil || !observed {
+		return false, err
+	}
+	return status.ReadyReplicas >= desiredReplicas(existingStatefulSet.Scale), nil
+}
+
+func (client *statefulSetClient) loadExistingStatefulSet() (existingStatefulSet *backendProjectClient.StatefulSet, err error) {
+	backendClient, err := client.project.backendProjectClient()
+	if err != nil {
+		return
+	}
+	namespaceID, err := client.NamespaceID()
+	if err != nil {
+		return
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.StatefulSet.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name":        client.name,
+			"namespaceId": namespaceID,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read statefulSet list")
+		err = fmt.Errorf("Failed to read statefulSet list, %v", err)
+		return
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name && item.NamespaceId == namespaceID {
+			existingStatefulSet = &item
+			return
+		}
+	}
+	return
+}
+
+func isStatefulSetUnchanged(existingStatefulSet backendProjectClient.StatefulSet, statefulSet projectModel.StatefulSet) bool {
+	hash, hashExists := existingStatefulSet.WorkloadLabels["cattlectl.io/hash"]
+	if !hashExists {
+		return false
+	}
+	return hash == hashOf(statefulSet)
+}
@@ -109,6 +109,55 @@ func Test_statefulSetClient_Create(t *testing.T) {
 	}
 }
 
+func Test_statefulSetClient_Upgrade(t *testing.T) {
+	statefulSet := projectModel.StatefulSet{}
+	statefulSet.Name = "existing-statefulSet"
+	tests := []struct {
+		name          string
+		existingHash  string
+		dryRun        bool
+		wantedChanged bool
+		wantedReplace bool
+	}{
+		{
+			name:          "Unchanged",
+			existingHash:  hashOf(statefulSet),
+			wantedChanged: false,
+			wantedReplace: false,
+		},
+		{
+			name:          "Changed",
+			existingHash:  "outdated-hash",
+			wantedChanged: true,
+			wantedReplace: true,
+		},
+		{
+			name:          "Without_Hash",
+			existingHash:  "",
+			wantedChanged: true,
+			wantedReplace: true,
+		},
+		{
+			name:          "Changed_Dry_Run",
+			existingHash:  "outdated-hash",
+			dryRun:        true,
+			wantedChanged: true,
+			wantedReplace: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replaced := false
+			client := upgradeStatefulSetClient(t, tt.existingHash, &replaced)
+			assert.Ok(t, client.SetData(statefulSet))
+			changed, err := client.Upgrade(tt.dryRun)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedChanged, changed)
+			assert.Equals(t, tt.wantedReplace, replaced)
+		})
+	}
+}
+
 func existingStatefulSetClient(t *testing.T, expectedListOpts *types.ListOpts) *statefulSetClient {
 	const (
 		projectID       = "test-project-id"
@@ -214,3 +263,107 @@ func notExistingStatefulSetClient(t *testing.T, expectedListOpts *types.ListOpts
 	statefulSetClientResult.namespaceID = "test-namespace-id"
 	return statefulSetClientResult
 }
+
+func Test_statefulSetClient_ready(t *testing.T) {
+	scale := int64(2)
+	tests := []struct {
+		name        string
+		status      *backendProjectClient.StatefulSetStatus
+		wantedReady bool
+	}{
+		{
+			name:        "Ready",
+			status:      &backendProjectClient.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 2},
+			wantedReady: true,
+		},
+		{
+			name:        "Stale_Status",
+			status:      &backendProjectClient.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 2},
+			wantedReady: false,
+		},
+		{
+			name:        "Not_Ready",
+			status:      &backendProjectClient.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 1},
+			wantedReady: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newWorkloadServer(t, workloadPath("test-namespace", "statefulsets", "existing-statefulSet"), 2)
+			defer server.Close()
+			testClients := stubs.CreateBackendStubs(t)
+			statefulSetOperationsStub := stubs.CreateStatefulSetOperationsStub(t)
+			statefulSetOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.StatefulSetCollection, error) {
+				return &backendProjectClient.StatefulSetCollection{
+					Data: []backendProjectClient.StatefulSet{
+						backendProjectClient.StatefulSet{
+							Name:              "existing-statefulSet",
+							NamespaceId:       "test-namespace-id",
+							Scale:             &scale,
+							State:             "active",
+							StatefulSetStatus: tt.status,
+						},
+					},
+				}, nil
+			}
+			testClients.ProjectClient.StatefulSet = statefulSetOperationsStub
+			projectClient := kubernetesProjectClient(t, server.URL)
+			projectClient._backendProjectClient = testClients.ProjectClient
+			result, err := newStatefulSetClient(
+				"existing-statefulSet",
+				"test-namespace",
+				projectClient,
+				logrus.New().WithFields(logrus.Fields{}),
+			)
+			assert.Ok(t, err)
+			client := result.(*statefulSetClient)
+			client.namespaceID = "test-namespace-id"
+
+			ready, err := client.ready()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedReady, ready)
+		})
+	}
+}
+
+func upgradeStatefulSetClient(t *testing.T, existingHash string, replaced *bool) *statefulSetClient {
+	testClients := stubs.CreateBackendStubs(t)
+	existingStatefulSet := backendProjectClient.StatefulSet{
+		Resource: types.Resource{
+			ID: "test-namespace-id:existing-statefulSet",
+		},
+		Name:           "existing-statefulSet",
+		NamespaceId:    "test-namespace-id",
+		WorkloadLabels: map[string]string{},
+	}
+	if existingHash != "" {
+		existingStatefulSet.WorkloadLabels["cattlectl.io/hash"] = existingHash
+	}
+
+	statefulSetOperationsStub := stubs.CreateStatefulSetOperationsStub(t)
+	statefulSetOperationsStub.DoList = func(opts *types.ListOpts) (*backendProjectClient.StatefulSetCollection, error) {
+		return &backendProjectClient.StatefulSetCollection{
+			Data: []backendProjectClient.StatefulSet{existingStatefulSet},
+		}, nil
+	}
+	statefulSetOperationsStub.DoReplace = func(statefulSet *backendProjectClient.StatefulSet) (*backendProjectClient.StatefulSet, error) {
+		assert.Equals(t, existingStatefulSet.ID, statefulSet.ID)
+		assert.Equals(t, existingStatefulSet.NamespaceId, statefulSet.NamespaceId)
+		assert.Assert(t, statefulSet.WorkloadLabels["cattlectl.io/hash"] != existingHash, "Expected new hash label")
+		*replaced = true
+		return statefulSet, nil
+	}
+	testClients.ProjectClient.StatefulSet = statefulSetOperationsStub
+	projectClient := simpleProjectClient()
+	projectClient._backendProjectClient = testClients.ProjectClient
+	result, err := newStatefulSetClient(
+		"existing-statefulSet",
+		"test-namespace",
+		projectClient,
+		logrus.New().WithFields(logrus.Fields{}),
+	)
+	assert.Ok(t, err)
+	statefulSetClientResult := result.(*statefulSetClient)
+	statefulSetClientResult.namespaceID = "test-namespace-id"
+	return statefulSetClientResult
+}
@@ -66,26 +66,15 @@ func (client *storageClassClient) Type() string {
 }
 
 func (client *storageClassClient) Exists() (bool, error) {
-	backendClient, err := client.clusterClient.backendClusterClient()
+	existingStorageClass, err := client.loadExistingStorageClass()
 	if err != nil {
 		return false, err
 	}
-	collection, err := backendClient.StorageClass.List(&types.ListOpts{
-		Filters: map[string]interface{}{
-			"name": client.name,
-		},
-	})
-	if nil != err {
-		client.logger.WithError(err).Error("Failed to read storageClass list")
-		return false, fmt.Errorf("Failed to read storageClass list, %v", err)
-	}
-	for _, item := range collection.Data {
-		if item.Name == client.name {
-			return true, nil
-		}
+	if existingStorageClass == nil {
+		client.logger.Debug("StorageClass not found")
+		return false, nil
 	}
-	client.logger.Debug("StorageClass not found")
-	return false, nil
+	return true, nil
 }
 
 func (client *storageClassClient) Create(dryRun bool) (changed bool, err error) {
@@ -116,6 +105,27 @@ func (client *storageClassClient) Upgrade(dryRun bool) (changed bool, err error)
 	return
 }
 
+func (client *storageClassClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.clusterClient.backendClusterClient()
+	if err != nil {
+		return
+	}
+	existingStorageClass, err := client.loadExistingStorageClass()
+	if err != nil {
+		return
+	}
+	if existingStorageClass == nil {
+		return changed, fmt.Errorf("StorageClass %v not found", client.name)
+	}
+
+	if dryRun {
+		client.logger.WithField("object", existingStorageClass).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.StorageClass.Delete(existingStorageClass)
+	}
+	return err == nil, err
+}
+
 func (client *storageClassClient) Data() (projectModel.StorageClass, error) {
 	return client.storageClass, nil
 }
@@ -125,3 +135,25 @@ func (client *storageClassClient) SetData(storageClass projectModel.StorageClass
 	client.storageClass = storageClass
 	return nil
 }
+
+func (client *storageClassClient) loadExistingStorageClass() (*backendClusterClient.StorageClass, error) {
+	backendClient, err := client.clusterClient.backendClusterClient()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := backendClient.StorageClass.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": client.name,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read storageClass list")
+		return nil, fmt.Errorf("Failed to read storageClass list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Name == client.name {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
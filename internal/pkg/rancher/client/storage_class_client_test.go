@@ -103,6 +103,67 @@ func Test_storageClassClient_Create(t *testing.T) {
 	}
 }
 
+func Test_storageClassClient_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []backendClusterClient.StorageClass
+		dryRun        bool
+		wantedDeleted bool
+		wantErr       bool
+		wantedErr     string
+	}{
+		{
+			name:          "Delete",
+			existing:      []backendClusterClient.StorageClass{backendClusterClient.StorageClass{Name: "existing-storageClass"}},
+			wantedDeleted: true,
+		},
+		{
+			name:          "Dry_Run",
+			existing:      []backendClusterClient.StorageClass{backendClusterClient.StorageClass{Name: "existing-storageClass"}},
+			dryRun:        true,
+			wantedDeleted: false,
+		},
+		{
+			name:      "Not_Existing",
+			existing:  []backendClusterClient.StorageClass{},
+			wantErr:   true,
+			wantedErr: "StorageClass existing-storageClass not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			deleted := false
+			storageClassOperationsStub := stubs.CreateStorageClassOperationsStub(t)
+			storageClassOperationsStub.DoList = func(opts *types.ListOpts) (*backendClusterClient.StorageClassCollection, error) {
+				return &backendClusterClient.StorageClassCollection{Data: tt.existing}, nil
+			}
+			storageClassOperationsStub.DoDelete = func(storageClass *backendClusterClient.StorageClass) error {
+				assert.Equals(t, "existing-storageClass", storageClass.Name)
+				deleted = true
+				return nil
+			}
+			testClients.ClusterClient.StorageClass = storageClassOperationsStub
+			clusterClient := simpleClusterClient()
+			clusterClient._backendClusterClient = testClients.ClusterClient
+			client, err := newStorageClassClient(
+				"existing-storageClass",
+				clusterClient,
+				logrus.New().WithFields(logrus.Fields{}),
+			)
+			assert.Ok(t, err)
+
+			_, err = client.Delete(tt.dryRun)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+			} else {
+				assert.Ok(t, err)
+			}
+			assert.Equals(t, tt.wantedDeleted, deleted)
+		})
+	}
+}
+
 func existingStorageClassClient(t *testing.T, expectedListOpts *types.ListOpts) *storageClassClient {
 	const (
 		projectID        = "test-project-id"
@@ -0,0 +1,228 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func newUserClientWithData(
+	user rancherModel.User,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (UserClient, error) {
+	result, err := newUserClient(
+		user.Username,
+		rancherClient,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetData(user)
+	return result, err
+}
+
+func newUserClient(
+	username string,
+	rancherClient RancherClient,
+	logger *logrus.Entry,
+) (UserClient, error) {
+	return &userClient{
+		resourceClient: resourceClient{
+			name:   username,
+			logger: logger.WithField("username", username),
+		},
+		rancherClient: rancherClient,
+	}, nil
+}
+
+// userClient manages a local user identified by its username
+type userClient struct {
+	resourceClient
+	user          rancherModel.User
+	rancherClient RancherClient
+}
+
+func (client *userClient) Type() string {
+	return rancherModel.RancherUser
+}
+
+func (client *userClient) ID() (string, error) {
+	client.idLock.Lock()
+	defer client.idLock.Unlock()
+	if client.id != "" {
+		return client.id, nil
+	}
+	existingUser, err := client.loadExistingUser()
+	if err != nil {
+		return "", err
+	}
+	if existingUser == nil {
+		return "", fmt.Errorf("User %v not found", client.name)
+	}
+	client.id = existingUser.ID
+	return client.id, nil
+}
+
+func (client *userClient) Exists() (bool, error) {
+	existingUser, err := client.loadExistingUser()
+	if err != nil {
+		return false, err
+	}
+	if existingUser == nil {
+		client.logger.Debug("User not found")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (client *userClient) Create(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+
+	client.logger.Info("Create new user")
+	newUser := backendRancherClient.User{
+		Username:           client.user.Username,
+		Name:               client.user.Name,
+		Description:        client.user.Description,
+		Password:           client.user.Password,
+		MustChangePassword: client.user.MustChangePassword,
+		Enabled:            client.user.Enabled,
+		Labels: map[string]string{
+			"cattlectl.io/hash": client.userHash(),
+		},
+	}
+
+	if dryRun {
+		client.logger.WithField("object", newUser).Info("Do Dry-Run Create")
+	} else {
+		_, err = backendClient.User.Create(&newUser)
+	}
+	return err == nil, err
+}
+
+// Upgrade updates the user if the descriptor changed and sets the declared password if set_password is true
+func (client *userClient) Upgrade(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingUser, err := client.loadExistingUser()
+	if err != nil {
+		return
+	}
+	if existingUser == nil {
+		return changed, fmt.Errorf("User %v not found", client.name)
+	}
+	setPassword := client.user.SetPassword && client.user.Password != ""
+	if existingUser.Labels["cattlectl.io/hash"] == client.userHash() {
+		if !setPassword {
+			client.logger.Debug("Skip upgrade user - no changes")
+			return
+		}
+	} else {
+		client.logger.Info("Upgrade User")
+		existingUser.Labels = withHashLabel(existingUser.Labels, client.userHash())
+		existingUser.Name = client.user.Name
+		existingUser.Description = client.user.Description
+		existingUser.MustChangePassword = client.user.MustChangePassword
+		if client.user.Enabled != nil {
+			existingUser.Enabled = client.user.Enabled
+		}
+		if dryRun {
+			client.logger.WithField("object", existingUser).Info("Do Dry-Run Upgrade")
+		} else if existingUser, err = backendClient.User.Replace(existingUser); err != nil {
+			return
+		}
+	}
+	if setPassword {
+		client.logger.Info("Set password of user")
+		if !dryRun {
+			_, err = backendClient.User.ActionSetpassword(existingUser, &backendRancherClient.SetPasswordInput{
+				NewPassword: client.user.Password,
+			})
+		}
+	}
+	return err == nil, err
+}
+
+func (client *userClient) Delete(dryRun bool) (changed bool, err error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return
+	}
+	existingUser, err := client.loadExistingUser()
+	if err != nil {
+		return
+	}
+	if existingUser == nil {
+		return changed, fmt.Errorf("User %v not found", client.name)
+	}
+	if dryRun {
+		client.logger.WithField("object", existingUser).Info("Do Dry-Run Delete")
+	} else {
+		err = backendClient.User.Delete(existingUser)
+	}
+	return err == nil, err
+}
+
+func (client *userClient) Data() (rancherModel.User, error) {
+	return client.user, nil
+}
+
+func (client *userClient) SetData(user rancherModel.User) error {
+	client.name = user.Username
+	client.user = user
+	return nil
+}
+
+// userHash is the hash of the user without its password, which must not leak into the labels
+func (client *userClient) userHash() string {
+	user := client.user
+	user.Password = ""
+	user.SetPassword = false
+	return hashOf(user)
+}
+
+func (client *userClient) loadExistingUser() (*backendRancherClient.User, error) {
+	backendClient, err := client.rancherClient.backendRancherClient()
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Trace("Load from rancher")
+	collection, err := backendClient.User.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"username": client.name,
+		},
+	})
+	if nil != err {
+		client.logger.WithError(err).Error("Failed to read user list")
+		return nil, fmt.Errorf("Failed to read user list, %v", err)
+	}
+	for _, item := range collection.Data {
+		if item.Username == client.name {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,148 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/stubs"
+	"github.com/rancher/norman/types"
+	backendRancherClient "github.com/rancher/types/client/management/v3"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_userClient_Upgrade(t *testing.T) {
+	user := rancherModel.User{
+		Username: "deployer",
+		Name:     "Deployer",
+		Password: "new-secret",
+	}
+	// the hash of the user without the password
+	userHash := hashOf(rancherModel.User{
+		Username: "deployer",
+		Name:     "Deployer",
+	})
+	tests := []struct {
+		name           string
+		existing       []backendRancherClient.User
+		setPassword    bool
+		dryRun         bool
+		wantedChanged  bool
+		wantedReplaced bool
+		wantedPassword string
+		wantErr        bool
+		wantedErr      string
+	}{
+		{
+			name: "Changed",
+			existing: []backendRancherClient.User{
+				{Resource: types.Resource{ID: "u-abcde"}, Username: "deployer", Name: "Old Name"},
+			},
+			wantedChanged:  true,
+			wantedReplaced: true,
+		},
+		{
+			name: "Unchanged",
+			existing: []backendRancherClient.User{
+				{Resource: types.Resource{ID: "u-abcde"}, Username: "deployer", Labels: map[string]string{"cattlectl.io/hash": userHash}},
+			},
+		},
+		{
+			name: "Set_Password",
+			existing: []backendRancherClient.User{
+				{Resource: types.Resource{ID: "u-abcde"}, Username: "deployer", Labels: map[string]string{"cattlectl.io/hash": userHash}},
+			},
+			setPassword:    true,
+			wantedChanged:  true,
+			wantedPassword: "new-secret",
+		},
+		{
+			name: "Changed_And_Set_Password",
+			existing: []backendRancherClient.User{
+				{Resource: types.Resource{ID: "u-abcde"}, Username: "deployer", Name: "Old Name"},
+			},
+			setPassword:    true,
+			wantedChanged:  true,
+			wantedReplaced: true,
+			wantedPassword: "new-secret",
+		},
+		{
+			name: "Dry_Run_Set_Password",
+			existing: []backendRancherClient.User{
+				{Resource: types.Resource{ID: "u-abcde"}, Username: "deployer", Labels: map[string]string{"cattlectl.io/hash": userHash}},
+			},
+			setPassword:   true,
+			dryRun:        true,
+			wantedChanged: true,
+		},
+		{
+			name: "Dry_Run",
+			existing: []backendRancherClient.User{
+				{Resource: types.Resource{ID: "u-abcde"}, Username: "deployer", Name: "Old Name"},
+			},
+			dryRun:        true,
+			wantedChanged: true,
+		},
+		{
+			name:      "Not_Existing",
+			existing:  []backendRancherClient.User{},
+			wantErr:   true,
+			wantedErr: "User deployer not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClients := stubs.CreateBackendStubs(t)
+			password := ""
+			replaced := false
+			userOperationsStub := stubs.CreateUserOperationsStub(t)
+			userOperationsStub.DoList = func(opts *types.ListOpts) (*backendRancherClient.UserCollection, error) {
+				assert.Equals(t, "deployer", opts.Filters["username"])
+				return &backendRancherClient.UserCollection{Data: tt.existing}, nil
+			}
+			userOperationsStub.DoReplace = func(existing *backendRancherClient.User) (*backendRancherClient.User, error) {
+				assert.Equals(t, "Deployer", existing.Name)
+				assert.Equals(t, userHash, existing.Labels["cattlectl.io/hash"])
+				replaced = true
+				return existing, nil
+			}
+			userOperationsStub.DoActionSetpassword = func(existing *backendRancherClient.User, input *backendRancherClient.SetPasswordInput) (*backendRancherClient.User, error) {
+				assert.Equals(t, "u-abcde", existing.ID)
+				password = input.NewPassword
+				return existing, nil
+			}
+			testClients.ManagementClient.User = userOperationsStub
+			rancherClient := simpleRancherClient()
+			rancherClient._backendRancherClient = testClients.ManagementClient
+			declared := user
+			declared.SetPassword = tt.setPassword
+			client, err := newUserClientWithData(declared, rancherClient, logrus.New().WithFields(logrus.Fields{}))
+			assert.Ok(t, err)
+
+			changed, err := client.Upgrade(tt.dryRun)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Equals(t, tt.wantedErr, err.Error())
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wantedChanged, changed)
+				assert.Equals(t, tt.wantedReplaced, replaced)
+				assert.Equals(t, tt.wantedPassword, password)
+			}
+		})
+	}
+}
@@ -16,8 +16,11 @@ package client
 
 import (
 	"crypto/sha1"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/rancher/norman/clientbase"
 	backendClusterClient "github.com/rancher/types/client/cluster/v3"
@@ -31,6 +34,9 @@ var (
 	newBackendClusterClient = backendClusterClient.NewClient
 	newManagementClient     = backendRancherClient.NewClient
 	newBackendProjectClient = backendProjectClient.NewClient
+
+	// readyPollInterval is the time between two checks of a resource waiting to become ready
+	readyPollInterval = 2 * time.Second
 )
 
 func createClientOpts(config RancherConfig) *clientbase.ClientOpts {
@@ -121,11 +127,155 @@ func createProjectClient(config RancherConfig, clusterID string, projectID strin
 	return pc, nil
 }
 
+// hashOf is the hash of the yaml of data, the depends_on of a resource only orders the apply
+// and is not part of the hash
 func hashOf(data interface{}) string {
 
-	dataBytes, _ := yaml.Marshal(data)
+	dataBytes, _ := yaml.Marshal(withoutDependsOn(data))
 	h := sha1.New()
 	h.Write(dataBytes)
 	bs := h.Sum(nil)
 	return fmt.Sprintf("%x", bs)
 }
+
+// withoutDependsOn is a copy of a struct without its DependsOn field, other data is returned as it is
+func withoutDependsOn(data interface{}) interface{} {
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Struct {
+		return data
+	}
+	if field := value.FieldByName("DependsOn"); !field.IsValid() || field.IsZero() {
+		return data
+	}
+	result := reflect.New(value.Type()).Elem()
+	result.Set(value)
+	dependsOn := result.FieldByName("DependsOn")
+	dependsOn.Set(reflect.Zero(dependsOn.Type()))
+	return result.Interface()
+}
+
+func withHashLabel(labels map[string]string, hash string) map[string]string {
+	result := make(map[string]string, len(labels)+1)
+	for key, value := range labels {
+		result[key] = value
+	}
+	result["cattlectl.io/hash"] = hash
+	return result
+}
+
+// isOwned is true if the labels mark a resource created by cattlectl
+func isOwned(labels map[string]string) bool {
+	_, hashExists := labels["cattlectl.io/hash"]
+	return hashExists
+}
+
+// declaringProjectLabel names the project whose descriptor declares the resource
+const declaringProjectLabel = "cattlectl.io/project"
+
+// projectDeclaration marks a resource as declared by a project descriptor,
+// pruning a project deletes only the resources declared by it
+type projectDeclaration struct {
+	declaringProject string
+}
+
+// DeclaredBy marks the resource as declared by the descriptor of the given project
+func (declaration *projectDeclaration) DeclaredBy(projectName string) {
+	declaration.declaringProject = projectName
+}
+
+// withProjectLabel adds the cattlectl.io/project label if the resource is declared by a project descriptor
+func (declaration *projectDeclaration) withProjectLabel(labels map[string]string) map[string]string {
+	if declaration.declaringProject == "" {
+		return labels
+	}
+	result := make(map[string]string, len(labels)+1)
+	for key, value := range labels {
+		result[key] = value
+	}
+	result[declaringProjectLabel] = declaration.declaringProject
+	return result
+}
+
+// hasProjectLabel is true if the labels carry the cattlectl.io/project label the resource needs
+func (declaration *projectDeclaration) hasProjectLabel(labels map[string]string) bool {
+	return labels[declaringProjectLabel] == declaration.declaringProject
+}
+
+// isDeclaredByProject is true if the labels mark a resource declared by a project descriptor
+func isDeclaredByProject(labels map[string]string) bool {
+	_, projectExists := labels[declaringProjectLabel]
+	return projectExists
+}
+
+// waitUntilReady polls ready until the resource is ready, failed or the wait timeout of config exceeded.
+// Without wait timeout it returns at once.
+func waitUntilReady(config RancherConfig, logger *logrus.Entry, ready func() (bool, error)) error {
+	if config.WaitTimeout <= 0 {
+		return nil
+	}
+	logger.Info("Wait until ready")
+	deadline := time.Now().Add(config.WaitTimeout)
+	for {
+		isReady, err := ready()
+		if err != nil {
+			logger.WithError(err).Error("Failed to become ready")
+			return err
+		}
+		if isReady {
+			logger.Debug("Ready")
+			return nil
+		}
+		if time.Now().After(deadline) {
+			logger.Error("Not ready in time")
+			return fmt.Errorf("Not ready after %v", config.WaitTimeout)
+		}
+		time.Sleep(readyPollInterval)
+	}
+}
+
+// generationObserved reports if the status of the workload at path was observed for its latest spec,
+// the generation of a workload is not part of the rancher API and is read from kubernetes
+func generationObserved(project ProjectClient, path string, observedGeneration int64) (bool, error) {
+	backendClient, err := project.backendKubernetesClient()
+	if err != nil {
+		return false, err
+	}
+	workload := struct {
+		Metadata kubernetesObjectMeta `json:"metadata"`
+	}{}
+	found, err := backendClient.get(path, &workload)
+	if err != nil || !found {
+		return false, err
+	}
+	return observedGeneration >= workload.Metadata.Generation, nil
+}
+
+// workloadPath is the kubernetes API path of a workload e.g. of kind deployments
+func workloadPath(namespace, kind, name string) string {
+	return fmt.Sprintf("/apis/apps/v1/namespaces/%s/%s/%s", namespace, kind, name)
+}
+
+// transitioningError is the failure rancher reports for a resource in an error state
+func transitioningError(state, transitioning, transitioningMessage string) error {
+	if transitioning == "error" || state == "error" || state == "failed" {
+		return fmt.Errorf("Resource is %s, %s", state, transitioningMessage)
+	}
+	return nil
+}
+
+// desiredReplicas is the scale of a workload, which defaults to one
+func desiredReplicas(scale *int64) int64 {
+	if scale == nil {
+		return 1
+	}
+	return *scale
+}
+
+// convertByJSON copies source into target using the JSON representation of both
+func convertByJSON(source, target interface{}) error {
+	transferContent, err := json.Marshal(source)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(transferContent, target)
+}
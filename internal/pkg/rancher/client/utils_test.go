@@ -0,0 +1,37 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+)
+
+func Test_hashOf_DependsOn(t *testing.T) {
+	configMap := projectModel.ConfigMap{
+		Name: "settings",
+		Data: map[string]string{"key": "value"},
+	}
+	dependingConfigMap := configMap
+	dependingConfigMap.DependsOn = []projectModel.Dependency{{Kind: "Namespace", Name: "web"}}
+
+	assert.Equals(t, hashOf(configMap), hashOf(dependingConfigMap))
+	assert.Equals(t, 1, len(dependingConfigMap.DependsOn))
+	changedConfigMap := dependingConfigMap
+	changedConfigMap.Data = map[string]string{"key": "changed"}
+	assert.Assert(t, hashOf(configMap) != hashOf(changedConfigMap), "Changed data should change the hash")
+}
@@ -15,6 +15,8 @@
 package rancher
 
 import (
+	"fmt"
+
 	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
 	clusterModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/model"
 	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
@@ -22,23 +24,88 @@ import (
 
 // NewClusterConverger creates a Converger for a given github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/model.Cluster
 func NewClusterConverger(cluster clusterModel.Cluster, rancherClient client.RancherClient) (descriptor.Converger, error) {
-	clusterClient, err := rancherClient.Cluster(cluster.Metadata.Name)
+	converger, _, err := newClusterConverger(cluster, rancherClient)
+	if err != nil {
+		return nil, err
+	}
+	return converger, nil
+}
+
+// NewPruningClusterConverger creates a Converger for a given github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/model.Cluster
+// which also removes the members bound by cattlectl but not declared in the cluster anymore, if members are declared
+func NewPruningClusterConverger(cluster clusterModel.Cluster, rancherClient client.RancherClient) (descriptor.Converger, error) {
+	converger, clusterClient, err := newClusterConverger(cluster, rancherClient)
 	if err != nil {
 		return nil, err
 	}
+	if cluster.Members != nil {
+		converger.Children = append(converger.Children, &clusterMemberPruner{
+			members:       cluster.Members,
+			clusterClient: clusterClient,
+		})
+	}
+	return converger, nil
+}
+
+func newClusterConverger(cluster clusterModel.Cluster, rancherClient client.RancherClient) (*descriptor.ResourceClientConverger, client.ClusterClient, error) {
+	clusterClient, err := rancherClient.Cluster(cluster.Metadata.Name)
+	if err != nil {
+		return nil, nil, err
+	}
 	childConvergers := make([]descriptor.Converger, 0)
 	for _, catalog := range cluster.Catalogs {
 		catalogClient, err := clusterClient.Catalog(catalog.Name)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		catalogClient.SetData(catalog)
 		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
 			Client: catalogClient,
 		})
 	}
+	for _, storageClass := range cluster.StorageClasses {
+		storageClassClient, err := clusterClient.StorageClass(storageClass.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		storageClassClient.SetData(storageClass)
+		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
+			Client: storageClassClient,
+		})
+	}
+	for _, persistentVolume := range cluster.PersistentVolumes {
+		persistentVolumeClient, err := clusterClient.PersistentVolume(persistentVolume.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		persistentVolumeClient.SetData(persistentVolume)
+		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
+			Client: persistentVolumeClient,
+		})
+	}
+	for _, member := range cluster.Members {
+		if (member.User == "") == (member.Group == "") {
+			return nil, nil, fmt.Errorf("Member with roles %v needs either a user or a group", member.Roles)
+		}
+		memberClient, err := clusterClient.ClusterRoleTemplateBinding(client.MemberPrincipalID(member))
+		if err != nil {
+			return nil, nil, err
+		}
+		memberClient.SetData(member)
+		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
+			Client: memberClient,
+		})
+	}
+	// only a cluster declaring a rke_config is created or upgraded, otherwise it has to exist already
+	var rootClient client.ResourceClient = client.EmptyResourceClient
+	if cluster.RKEConfig != nil {
+		if err = clusterClient.SetData(cluster); err != nil {
+			return nil, nil, err
+		}
+		rootClient = clusterClient
+	}
 	return &descriptor.ResourceClientConverger{
-		Client:   client.EmptyResourceClient,
+		Client:   rootClient,
 		Children: childConvergers,
-	}, nil
+	}, clusterClient, nil
 }
@@ -0,0 +1,87 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rancher
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+)
+
+func TestClusterMemberPruner_Converge(t *testing.T) {
+	declared := &testClusterMemberClient{principalID: "local://u-declared", owned: true}
+	undeclared := &testClusterMemberClient{principalID: "github_team://4711", owned: true}
+	creator := &testClusterMemberClient{principalID: "local://u-creator", owned: false}
+	pruner := &clusterMemberPruner{
+		members: []projectModel.Member{
+			{User: "u-declared", Roles: []string{"cluster-member"}},
+		},
+		clusterClient: &testMemberClusterClient{
+			members: []client.ClusterRoleTemplateBindingClient{declared, undeclared, creator},
+		},
+	}
+
+	diff, err := pruner.Diff()
+	assert.Ok(t, err)
+	assert.Equals(t, []descriptor.ResourceDiff{
+		{Type: rancherModel.ClusterMember, Name: "github_team://4711", Action: descriptor.DiffDeleted},
+	}, diff.Resources)
+
+	result, err := pruner.Converge(false)
+	assert.Ok(t, err)
+	assert.Equals(t, []descriptor.ResourceDescriptor{
+		{Type: rancherModel.ClusterMember, Name: "github_team://4711"},
+	}, result.DeletedResources)
+	assert.Assert(t, !declared.deleted, "Declared member must be kept")
+	assert.Assert(t, undeclared.deleted, "Undeclared member must be deleted")
+	assert.Assert(t, !creator.deleted, "Member not bound by cattlectl must be kept")
+}
+
+type testMemberClusterClient struct {
+	client.ClusterClient
+	members []client.ClusterRoleTemplateBindingClient
+}
+
+func (clusterClient *testMemberClusterClient) ClusterRoleTemplateBindings() ([]client.ClusterRoleTemplateBindingClient, error) {
+	return clusterClient.members, nil
+}
+
+type testClusterMemberClient struct {
+	client.ClusterRoleTemplateBindingClient
+	principalID string
+	owned       bool
+	deleted     bool
+}
+
+func (member *testClusterMemberClient) Type() string {
+	return rancherModel.ClusterMember
+}
+
+func (member *testClusterMemberClient) Name() (string, error) {
+	return member.principalID, nil
+}
+
+func (member *testClusterMemberClient) Owned() (bool, error) {
+	return member.owned, nil
+}
+
+func (member *testClusterMemberClient) Delete(dryRun bool) (bool, error) {
+	member.deleted = !dryRun
+	return true, nil
+}
@@ -15,6 +15,8 @@
 package rancher
 
 import (
+	clusterModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/model"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 	"github.com/sirupsen/logrus"
@@ -25,5 +27,31 @@ func NewClusterParser(descriptorFile string, values map[string]interface{}) desc
 	logger := logrus.WithFields(logrus.Fields{
 		"descriptor_file": descriptorFile,
 	})
-	return descriptor.NewLogginParser(rancherModel.ClusterKind, logger, values)
+	return clusterParser{
+		Parser: descriptor.NewLogginParser(rancherModel.ClusterKind, logger, values),
+	}
+}
+
+// clusterParser validates the persistent volumes and node pools of the parsed cluster
+type clusterParser struct {
+	descriptor.Parser
+}
+
+func (parser clusterParser) Parse(data []byte, target interface{}) error {
+	if err := parser.Parser.Parse(data, target); err != nil {
+		return err
+	}
+	cluster, isCluster := target.(*clusterModel.Cluster)
+	if !isCluster {
+		return nil
+	}
+	if err := clusterModel.ValidateProvisioning(*cluster); err != nil {
+		return err
+	}
+	for _, persistentVolume := range cluster.PersistentVolumes {
+		if err := projectModel.ValidatePersistentVolume(persistentVolume); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,115 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rancher
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	clusterModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/model"
+)
+
+func TestClusterParser_Parse(t *testing.T) {
+	tests := []struct {
+		name                    string
+		data                    string
+		wantedStorageClasses    int
+		wantedPersistentVolumes int
+		wantErr                 bool
+		wantedErr               string
+	}{
+		{
+			name: "storage",
+			data: `---
+api_version: v1.0
+kind: Cluster
+metadata:
+  name: test-cluster
+storage_classes:
+  - name: local-storage
+    provisioner: kubernetes.io/no-provisioner
+    volume_bind_mode: WaitForFirstConsumer
+persistent_volumes:
+  - name: data
+    storage_class_name: local-storage
+    path: /var/lib/data
+    node: worker-1
+    capacity: 5Gi
+`,
+			wantedStorageClasses:    1,
+			wantedPersistentVolumes: 1,
+		},
+		{
+			name: "invalid-persistent-volume",
+			data: `---
+api_version: v1.0
+kind: Cluster
+metadata:
+  name: test-cluster
+persistent_volumes:
+  - name: shared-data
+    type: nfs
+    path: /exports/shared-data
+`,
+			wantErr:   true,
+			wantedErr: "Persistent volume shared-data of type nfs requires server",
+		},
+		{
+			name: "rke",
+			data: `---
+api_version: v1.0
+kind: Cluster
+metadata:
+  name: test-cluster
+rke_config:
+  kubernetes_version: v1.17.4-rancher1-2
+  network_plugin: canal
+node_pools:
+  - hostname_prefix: worker-
+    node_template: small
+    quantity: 3
+    worker: true
+`,
+		},
+		{
+			name: "node-pools-without-rke-config",
+			data: `---
+api_version: v1.0
+kind: Cluster
+metadata:
+  name: test-cluster
+node_pools:
+  - hostname_prefix: worker-
+    node_template: small
+`,
+			wantErr:   true,
+			wantedErr: "Node pools of cluster test-cluster require a rke_config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := clusterModel.Cluster{}
+			err := NewClusterParser("cluster.yaml", map[string]interface{}{}).Parse([]byte(tt.data), &cluster)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Equals(t, tt.wantedErr, err.Error())
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wantedStorageClasses, len(cluster.StorageClasses))
+				assert.Equals(t, tt.wantedPersistentVolumes, len(cluster.PersistentVolumes))
+			}
+		})
+	}
+}
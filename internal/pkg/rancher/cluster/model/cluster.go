@@ -15,15 +15,23 @@
 package model
 
 import (
+	"fmt"
+
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 )
 
 // Cluster represents global members
 type Cluster struct {
-	APIVersion string                 `yaml:"api_version"`
-	Kind       string                 `yaml:"kind"`
-	Metadata   ClusterMetadata        `yaml:"metadata"`
-	Catalogs   []rancherModel.Catalog `yaml:"catalogs,omitempty"`
+	APIVersion        string                          `yaml:"api_version"`
+	Kind              string                          `yaml:"kind"`
+	Metadata          ClusterMetadata                 `yaml:"metadata"`
+	Catalogs          []rancherModel.Catalog          `yaml:"catalogs,omitempty"`
+	StorageClasses    []projectModel.StorageClass     `yaml:"storage_classes,omitempty"`
+	PersistentVolumes []projectModel.PersistentVolume `yaml:"persistent_volumes,omitempty"`
+	Members           []projectModel.Member           `yaml:"members,omitempty"`
+	RKEConfig         *RKEConfig                      `yaml:"rke_config,omitempty"`
+	NodePools         []NodePool                      `yaml:"node_pools,omitempty"`
 }
 
 // ClusterrMetadata are global meta informations
@@ -35,3 +43,72 @@ type ClusterMetadata struct {
 	SecretKey  string `yaml:"secret_key,omitempty"`
 	TokenKey   string `yaml:"token_key,omitempty"`
 }
+
+// RKEConfig are the supported options of a cluster provisioned by rancher with RKE
+type RKEConfig struct {
+	KubernetesVersion   string            `yaml:"kubernetes_version,omitempty"`
+	NetworkPlugin       string            `yaml:"network_plugin,omitempty"`
+	NetworkOptions      map[string]string `yaml:"network_options,omitempty"`
+	IgnoreDockerVersion *bool             `yaml:"ignore_docker_version,omitempty"`
+	Services            RKEServices       `yaml:"services,omitempty"`
+}
+
+// RKEServices are the options of the kubernetes services of a RKE cluster
+type RKEServices struct {
+	Etcd           RKEServiceOptions `yaml:"etcd,omitempty"`
+	KubeAPI        RKEKubeAPIService `yaml:"kube_api,omitempty"`
+	KubeController RKEServiceOptions `yaml:"kube_controller,omitempty"`
+	Kubelet        RKEKubeletService `yaml:"kubelet,omitempty"`
+	Kubeproxy      RKEServiceOptions `yaml:"kubeproxy,omitempty"`
+	Scheduler      RKEServiceOptions `yaml:"scheduler,omitempty"`
+}
+
+// RKEServiceOptions are the options common to all kubernetes services of a RKE cluster
+type RKEServiceOptions struct {
+	ExtraArgs  map[string]string `yaml:"extra_args,omitempty"`
+	ExtraBinds []string          `yaml:"extra_binds,omitempty"`
+	ExtraEnv   []string          `yaml:"extra_env,omitempty"`
+}
+
+// RKEKubeAPIService are the options of the kube api service of a RKE cluster
+type RKEKubeAPIService struct {
+	RKEServiceOptions     `yaml:",inline"`
+	ServiceClusterIPRange string `yaml:"service_cluster_ip_range,omitempty"`
+	ServiceNodePortRange  string `yaml:"service_node_port_range,omitempty"`
+	PodSecurityPolicy     *bool  `yaml:"pod_security_policy,omitempty"`
+}
+
+// RKEKubeletService are the options of the kubelet service of a RKE cluster
+type RKEKubeletService struct {
+	RKEServiceOptions `yaml:",inline"`
+	ClusterDomain     string `yaml:"cluster_domain,omitempty"`
+	ClusterDNSServer  string `yaml:"cluster_dns_server,omitempty"`
+	FailSwapOn        *bool  `yaml:"fail_swap_on,omitempty"`
+}
+
+// NodePool are nodes of a RKE cluster provisioned by rancher with a node template
+type NodePool struct {
+	HostnamePrefix string            `yaml:"hostname_prefix"`
+	NodeTemplate   string            `yaml:"node_template"`
+	Quantity       int64             `yaml:"quantity,omitempty"`
+	ControlPlane   bool              `yaml:"control_plane,omitempty"`
+	Etcd           bool              `yaml:"etcd,omitempty"`
+	Worker         bool              `yaml:"worker,omitempty"`
+	NodeLabels     map[string]string `yaml:"node_labels,omitempty"`
+}
+
+// ValidateProvisioning checks that node pools are only declared for a RKE cluster and name their nodes and node template
+func ValidateProvisioning(cluster Cluster) error {
+	if len(cluster.NodePools) > 0 && cluster.RKEConfig == nil {
+		return fmt.Errorf("Node pools of cluster %v require a rke_config", cluster.Metadata.Name)
+	}
+	for _, nodePool := range cluster.NodePools {
+		if nodePool.HostnamePrefix == "" {
+			return fmt.Errorf("Node pool of cluster %v requires hostname_prefix", cluster.Metadata.Name)
+		}
+		if nodePool.NodeTemplate == "" {
+			return fmt.Errorf("Node pool %v of cluster %v requires node_template", nodePool.HostnamePrefix, cluster.Metadata.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"fmt"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+)
+
+// newAutoscalerConverger creates a Converger for the horizontal pod autoscaler of a workload.
+// The autoscaler has the name of the workload it scales.
+func newAutoscalerConverger(autoscaling *projectModel.Autoscaling, workloadType, name, namespace string, projectClient client.ProjectClient) (descriptor.Converger, error) {
+	autoscalerClient, err := projectClient.HorizontalPodAutoscaler(name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if autoscaling == nil {
+		return &autoscalerPruner{autoscalerClient: autoscalerClient}, nil
+	}
+	err = autoscalerClient.SetData(projectModel.HorizontalPodAutoscaler{
+		Name:        name,
+		WorkloadID:  fmt.Sprintf("%s:%s:%s", workloadType, namespace, name),
+		Autoscaling: *autoscaling,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &descriptor.ResourceClientConverger{
+		Client: autoscalerClient,
+	}, nil
+}
+
+// autoscalerPruner removes the horizontal pod autoscaler created by cattlectl for a workload which is not autoscaled anymore
+type autoscalerPruner struct {
+	autoscalerClient client.HorizontalPodAutoscalerClient
+}
+
+func (pruner *autoscalerPruner) Converge(dryRun bool) (descriptor.ConvergeResult, error) {
+	return pruner.converge(dryRun, false)
+}
+
+func (pruner *autoscalerPruner) ConvergeAll(dryRun bool) (descriptor.ConvergeResult, error) {
+	return pruner.converge(dryRun, true)
+}
+
+func (pruner *autoscalerPruner) converge(dryRun, keepGoing bool) (result descriptor.ConvergeResult, err error) {
+	candidates, err := pruner.candidates()
+	if err != nil {
+		return
+	}
+	return descriptor.DeleteCandidates(candidates, dryRun, keepGoing)
+}
+
+func (pruner *autoscalerPruner) Diff() (result descriptor.DiffResult, err error) {
+	candidates, err := pruner.candidates()
+	if err != nil {
+		return
+	}
+	return descriptor.DiffCandidates(candidates)
+}
+
+// candidates is the autoscaler of the workload if it exists and is owned by cattlectl
+func (pruner *autoscalerPruner) candidates() (result []client.ResourceClient, err error) {
+	exists, err := pruner.autoscalerClient.Exists()
+	if err != nil || !exists {
+		return
+	}
+	owned, err := pruner.autoscalerClient.Owned()
+	if err != nil || !owned {
+		return
+	}
+	return []client.ResourceClient{pruner.autoscalerClient}, nil
+}
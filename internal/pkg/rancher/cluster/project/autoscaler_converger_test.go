@@ -0,0 +1,136 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+)
+
+func TestAutoscalerConverger_Declared(t *testing.T) {
+	autoscaler := &testAutoscalerClient{}
+	converger, err := newAutoscalerConverger(
+		&projectModel.Autoscaling{MaxReplicas: 5},
+		"statefulset",
+		"web",
+		"test-namespace",
+		&testAutoscalerProjectClient{autoscaler: autoscaler},
+	)
+	assert.Ok(t, err)
+	_, isResourceConverger := converger.(*descriptor.ResourceClientConverger)
+	assert.Assert(t, isResourceConverger, "Declared autoscaling must be converged by its client")
+	assert.Equals(t, projectModel.HorizontalPodAutoscaler{
+		Name:        "web",
+		WorkloadID:  "statefulset:test-namespace:web",
+		Autoscaling: projectModel.Autoscaling{MaxReplicas: 5},
+	}, autoscaler.data)
+}
+
+func TestAutoscalerConverger_Removed(t *testing.T) {
+	tests := []struct {
+		name          string
+		exists        bool
+		owned         bool
+		wantedDeleted bool
+	}{
+		{
+			name:          "owned",
+			exists:        true,
+			owned:         true,
+			wantedDeleted: true,
+		},
+		{
+			name:          "not-owned",
+			exists:        true,
+			owned:         false,
+			wantedDeleted: false,
+		},
+		{
+			name:          "not-existing",
+			exists:        false,
+			wantedDeleted: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			autoscaler := &testAutoscalerClient{exists: tt.exists, owned: tt.owned}
+			converger, err := newAutoscalerConverger(nil, "deployment", "web", "test-namespace", &testAutoscalerProjectClient{autoscaler: autoscaler})
+			assert.Ok(t, err)
+
+			result, err := converger.Converge(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedDeleted, autoscaler.deleted)
+			if tt.wantedDeleted {
+				assert.Equals(t, []descriptor.ResourceDescriptor{
+					{Type: rancherModel.HorizontalPodAutoscaler, Name: "web"},
+				}, result.DeletedResources)
+			} else {
+				assert.Equals(t, 0, len(result.DeletedResources))
+			}
+		})
+	}
+}
+
+type testAutoscalerProjectClient struct {
+	client.ProjectClient
+	autoscaler *testAutoscalerClient
+}
+
+func (projectClient *testAutoscalerProjectClient) HorizontalPodAutoscaler(name, namespaceName string) (client.HorizontalPodAutoscalerClient, error) {
+	return projectClient.autoscaler, nil
+}
+
+type testAutoscalerClient struct {
+	client.HorizontalPodAutoscalerClient
+	data    projectModel.HorizontalPodAutoscaler
+	exists  bool
+	owned   bool
+	deleted bool
+}
+
+func (autoscaler *testAutoscalerClient) Type() string {
+	return rancherModel.HorizontalPodAutoscaler
+}
+
+func (autoscaler *testAutoscalerClient) Name() (string, error) {
+	return "web", nil
+}
+
+func (autoscaler *testAutoscalerClient) Namespace() (string, error) {
+	return "test-namespace", nil
+}
+
+func (autoscaler *testAutoscalerClient) Exists() (bool, error) {
+	return autoscaler.exists, nil
+}
+
+func (autoscaler *testAutoscalerClient) Owned() (bool, error) {
+	return autoscaler.owned, nil
+}
+
+func (autoscaler *testAutoscalerClient) Delete(dryRun bool) (bool, error) {
+	autoscaler.deleted = !dryRun
+	return true, nil
+}
+
+func (autoscaler *testAutoscalerClient) SetData(data projectModel.HorizontalPodAutoscaler) error {
+	autoscaler.data = data
+	return nil
+}
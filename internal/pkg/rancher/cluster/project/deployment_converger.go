@@ -30,7 +30,18 @@ func NewDeploymentConverger(deploymentDescriptor projectModel.DeploymentDescript
 	if err != nil {
 		return nil, err
 	}
+	autoscalerConverger, err := newAutoscalerConverger(
+		deploymentDescriptor.Spec.Autoscaling,
+		"deployment",
+		deploymentDescriptor.Spec.Name,
+		deploymentDescriptor.Metadata.Namespace,
+		projectClient,
+	)
+	if err != nil {
+		return nil, err
+	}
 	return &descriptor.ResourceClientConverger{
-		Client: deploymentClient,
+		Client:   deploymentClient,
+		Children: []descriptor.Converger{autoscalerConverger},
 	}, nil
 }
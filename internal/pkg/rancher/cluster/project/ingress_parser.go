@@ -0,0 +1,29 @@
+// Copyright © 2018 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+	"github.com/sirupsen/logrus"
+)
+
+// NewIngressParser creates a Parser that is printing prettified representations
+func NewIngressParser(descriptorFile string, values map[string]interface{}) descriptor.Parser {
+	logger := logrus.WithFields(logrus.Fields{
+		"descriptor_file": descriptorFile,
+	})
+	return descriptor.NewLogginParser(rancherModel.IngressKind, logger, values)
+}
@@ -0,0 +1,85 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+)
+
+// memberPruner removes the members bound by cattlectl which are not declared in the project anymore
+type memberPruner struct {
+	members       []projectModel.Member
+	projectClient client.ProjectClient
+}
+
+func (pruner *memberPruner) Converge(dryRun bool) (descriptor.ConvergeResult, error) {
+	return pruner.converge(dryRun, false)
+}
+
+func (pruner *memberPruner) ConvergeAll(dryRun bool) (descriptor.ConvergeResult, error) {
+	return pruner.converge(dryRun, true)
+}
+
+func (pruner *memberPruner) converge(dryRun, keepGoing bool) (result descriptor.ConvergeResult, err error) {
+	candidates, err := pruner.candidates()
+	if err != nil {
+		return
+	}
+	return descriptor.DeleteCandidates(candidates, dryRun, keepGoing)
+}
+
+func (pruner *memberPruner) Diff() (result descriptor.DiffResult, err error) {
+	candidates, err := pruner.candidates()
+	if err != nil {
+		return
+	}
+	return descriptor.DiffCandidates(candidates)
+}
+
+// candidates lists the members owned by cattlectl which are not part of the project descriptor,
+// members bound by others e.g. the creator of the project are kept
+func (pruner *memberPruner) candidates() (result []client.ResourceClient, err error) {
+	projectID, err := pruner.projectClient.ID()
+	if err != nil || projectID == "" {
+		return
+	}
+	declared := make(map[string]bool)
+	for _, member := range pruner.members {
+		declared[client.MemberPrincipalID(member)] = true
+	}
+	members, err := pruner.projectClient.ProjectRoleTemplateBindings()
+	if err != nil {
+		return
+	}
+	for _, member := range members {
+		var principalID string
+		if principalID, err = member.Name(); err != nil {
+			return
+		}
+		if declared[principalID] {
+			continue
+		}
+		var owned bool
+		if owned, err = member.Owned(); err != nil {
+			return
+		}
+		if owned {
+			result = append(result, member)
+		}
+	}
+	return
+}
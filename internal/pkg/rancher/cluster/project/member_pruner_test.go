@@ -0,0 +1,91 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+)
+
+func TestMemberPruner_Converge(t *testing.T) {
+	declared := &testMemberClient{principalID: "local://u-declared", owned: true}
+	undeclared := &testMemberClient{principalID: "github_team://4711", owned: true}
+	creator := &testMemberClient{principalID: "local://u-creator", owned: false}
+	pruner := &memberPruner{
+		members: []projectModel.Member{
+			{User: "u-declared", Roles: []string{"project-member"}},
+		},
+		projectClient: &testMemberProjectClient{
+			members: []client.ProjectRoleTemplateBindingClient{declared, undeclared, creator},
+		},
+	}
+
+	diff, err := pruner.Diff()
+	assert.Ok(t, err)
+	assert.Equals(t, []descriptor.ResourceDiff{
+		{Type: rancherModel.ProjectMember, Name: "github_team://4711", Action: descriptor.DiffDeleted},
+	}, diff.Resources)
+
+	result, err := pruner.Converge(false)
+	assert.Ok(t, err)
+	assert.Equals(t, []descriptor.ResourceDescriptor{
+		{Type: rancherModel.ProjectMember, Name: "github_team://4711"},
+	}, result.DeletedResources)
+	assert.Assert(t, !declared.deleted, "Declared member must be kept")
+	assert.Assert(t, undeclared.deleted, "Undeclared member must be deleted")
+	assert.Assert(t, !creator.deleted, "Member not bound by cattlectl must be kept")
+}
+
+type testMemberProjectClient struct {
+	client.ProjectClient
+	members []client.ProjectRoleTemplateBindingClient
+}
+
+func (projectClient *testMemberProjectClient) ID() (string, error) {
+	return "test-project-id", nil
+}
+
+func (projectClient *testMemberProjectClient) ProjectRoleTemplateBindings() ([]client.ProjectRoleTemplateBindingClient, error) {
+	return projectClient.members, nil
+}
+
+type testMemberClient struct {
+	client.ProjectRoleTemplateBindingClient
+	principalID string
+	owned       bool
+	deleted     bool
+}
+
+func (member *testMemberClient) Type() string {
+	return rancherModel.ProjectMember
+}
+
+func (member *testMemberClient) Name() (string, error) {
+	return member.principalID, nil
+}
+
+func (member *testMemberClient) Owned() (bool, error) {
+	return member.owned, nil
+}
+
+func (member *testMemberClient) Delete(dryRun bool) (bool, error) {
+	member.deleted = !dryRun
+	return true, nil
+}
@@ -27,9 +27,14 @@ func MergeProject(child projectModel.Project, parent *projectModel.Project) erro
 	parent.Resources.ConfigMaps = mergeConfigMaps(child.Resources.ConfigMaps, parent.Resources.ConfigMaps)
 	parent.Resources.DockerCredentials = mergeDockerCredentials(child.Resources.DockerCredentials, parent.Resources.DockerCredentials)
 	parent.Resources.Secrets = mergeConfigMaps(child.Resources.Secrets, parent.Resources.Secrets)
+	parent.Resources.Services = mergeServices(child.Resources.Services, parent.Resources.Services)
+	parent.Resources.Ingresses = mergeIngresses(child.Resources.Ingresses, parent.Resources.Ingresses)
 	parent.StorageClasses = mergeStorageClasses(child.StorageClasses, parent.StorageClasses)
 	parent.PersistentVolumes = mergePersistentVolumes(child.PersistentVolumes, parent.PersistentVolumes)
 	parent.Apps = mergeApps(child.Apps, parent.Apps)
+	parent.Members = mergeMembers(child.Members, parent.Members)
+	parent.NetworkPolicies = mergeNetworkPolicies(child.NetworkPolicies, parent.NetworkPolicies)
+	parent.PersistentVolumeClaims = mergePersistentVolumeClaims(child.PersistentVolumeClaims, parent.PersistentVolumeClaims)
 	return nil
 }
 
@@ -89,6 +94,34 @@ CHILD_LOOP:
 	return dst
 }
 
+func mergeServices(childServices, parentServices []projectModel.Service) []projectModel.Service {
+	dst := parentServices
+CHILD_LOOP:
+	for _, childService := range childServices {
+		for _, parentService := range parentServices {
+			if childService.Name == parentService.Name {
+				continue CHILD_LOOP
+			}
+		}
+		dst = append(dst, childService)
+	}
+	return dst
+}
+
+func mergeIngresses(childIngresses, parentIngresses []projectModel.Ingress) []projectModel.Ingress {
+	dst := parentIngresses
+CHILD_LOOP:
+	for _, childIngress := range childIngresses {
+		for _, parentIngress := range parentIngresses {
+			if childIngress.Name == parentIngress.Name {
+				continue CHILD_LOOP
+			}
+		}
+		dst = append(dst, childIngress)
+	}
+	return dst
+}
+
 func mergeStorageClasses(childStorageClasses, parentStorageClasses []projectModel.StorageClass) []projectModel.StorageClass {
 	dst := parentStorageClasses
 CHILD_LOOP:
@@ -130,3 +163,45 @@ CHILD_LOOP:
 	}
 	return dst
 }
+
+func mergeMembers(childMembers, parentMembers []projectModel.Member) []projectModel.Member {
+	dst := parentMembers
+CHILD_LOOP:
+	for _, childMember := range childMembers {
+		for _, parentMember := range parentMembers {
+			if childMember.User == parentMember.User && childMember.Group == parentMember.Group {
+				continue CHILD_LOOP
+			}
+		}
+		dst = append(dst, childMember)
+	}
+	return dst
+}
+
+func mergeNetworkPolicies(childNetworkPolicies, parentNetworkPolicies []projectModel.NetworkPolicy) []projectModel.NetworkPolicy {
+	dst := parentNetworkPolicies
+CHILD_LOOP:
+	for _, childNetworkPolicy := range childNetworkPolicies {
+		for _, parentNetworkPolicy := range parentNetworkPolicies {
+			if childNetworkPolicy.Name == parentNetworkPolicy.Name {
+				continue CHILD_LOOP
+			}
+		}
+		dst = append(dst, childNetworkPolicy)
+	}
+	return dst
+}
+
+func mergePersistentVolumeClaims(childVolumeClaims, parentVolumeClaims []projectModel.VolumeClaim) []projectModel.VolumeClaim {
+	dst := parentVolumeClaims
+CHILD_LOOP:
+	for _, childVolumeClaim := range childVolumeClaims {
+		for _, parentVolumeClaim := range parentVolumeClaims {
+			if childVolumeClaim.Name == parentVolumeClaim.Name {
+				continue CHILD_LOOP
+			}
+		}
+		dst = append(dst, childVolumeClaim)
+	}
+	return dst
+}
@@ -0,0 +1,72 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+
+	projectAPI "github.com/rancher/types/client/project/v3"
+)
+
+// Autoscaling scales a workload between MinReplicas and MaxReplicas based on metrics
+type Autoscaling struct {
+	MinReplicas *int64              `json:"minReplicas,omitempty" yaml:"minReplicas,omitempty"`
+	MaxReplicas int64               `json:"maxReplicas,omitempty" yaml:"maxReplicas,omitempty"`
+	Metrics     []AutoscalingMetric `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+}
+
+// AutoscalingMetric is a metric the autoscaler targets e.g. the cpu utilization of the pods
+type AutoscalingMetric struct {
+	Type            string                       `json:"type,omitempty" yaml:"type,omitempty"`
+	Name            string                       `json:"name,omitempty" yaml:"name,omitempty"`
+	Selector        *LabelSelector               `json:"selector,omitempty" yaml:"selector,omitempty"`
+	DescribedObject *CrossVersionObjectReference `json:"describedObject,omitempty" yaml:"describedObject,omitempty"`
+	Target          *MetricTarget                `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+// CrossVersionObjectReference references the kubernetes object an object metric describes
+type CrossVersionObjectReference struct {
+	APIVersion string `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Name       string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// MetricTarget is the value of a metric the autoscaler scales to
+type MetricTarget struct {
+	Type         string `json:"type,omitempty" yaml:"type,omitempty"`
+	Utilization  *int64 `json:"utilization,omitempty" yaml:"utilization,omitempty"`
+	AverageValue string `json:"averageValue,omitempty" yaml:"averageValue,omitempty"`
+	Value        string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// HorizontalPodAutoscaler is the autoscaling of the workload with WorkloadID
+type HorizontalPodAutoscaler struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	WorkloadID  string `json:"workloadId,omitempty" yaml:"workloadId,omitempty"`
+	Autoscaling `yaml:"autoscaling,inline"`
+}
+
+func ConvertHorizontalPodAutoscalerToProjectAPI(horizontalPodAutoscaler HorizontalPodAutoscaler) (projectAPI.HorizontalPodAutoscaler, error) {
+	result := projectAPI.HorizontalPodAutoscaler{}
+	transferContent, err := json.Marshal(horizontalPodAutoscaler)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(transferContent, &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
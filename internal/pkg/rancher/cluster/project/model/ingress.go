@@ -0,0 +1,102 @@
+// Copyright © 2018 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	projectAPI "github.com/rancher/types/client/project/v3"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// IngressDescriptor is the standalone descriptor of an Ingress
+type IngressDescriptor struct {
+	APIVersion string `yaml:"api_version"`
+	Kind       string
+	Metadata   WorkloadMetadata
+	Spec       Ingress
+}
+
+// Ingress represent a K8S Ingress
+type Ingress struct {
+	Name           string            `yaml:"name,omitempty"`
+	Namespace      string            `yaml:"namespace,omitempty"`
+	DefaultBackend *IngressBackend   `yaml:"default_backend,omitempty"`
+	Rules          []IngressRule     `yaml:"rules,omitempty"`
+	TLS            []IngressTLS      `yaml:"tls,omitempty"`
+	Labels         map[string]string `yaml:"labels,omitempty"`
+	Annotations    map[string]string `yaml:"annotations,omitempty"`
+	DependsOn      []Dependency      `yaml:"depends_on,omitempty"`
+}
+
+// IngressRule routes the requests of a host
+type IngressRule struct {
+	Host  string        `yaml:"host,omitempty"`
+	Paths []IngressPath `yaml:"paths,omitempty"`
+}
+
+// IngressPath routes the requests of a path to a backend
+type IngressPath struct {
+	Path           string `yaml:"path,omitempty"`
+	IngressBackend `yaml:",inline"`
+}
+
+// IngressBackend is a port of a Service in the namespace of the Ingress
+type IngressBackend struct {
+	Service    string      `yaml:"service"`
+	TargetPort IntOrString `yaml:"target_port"`
+}
+
+// IngressTLS secures hosts with a Certificate in the namespace of the Ingress
+type IngressTLS struct {
+	Certificate string   `yaml:"certificate,omitempty"`
+	Hosts       []string `yaml:"hosts,omitempty"`
+}
+
+// ConvertIngressToProjectAPI converts an Ingress to the rancher project API,
+// services and certificates are referenced in the namespace with namespaceID
+func ConvertIngressToProjectAPI(ingress Ingress, namespaceID string) projectAPI.Ingress {
+	result := projectAPI.Ingress{
+		Name:        ingress.Name,
+		Labels:      ingress.Labels,
+		Annotations: ingress.Annotations,
+		NamespaceId: namespaceID,
+	}
+	if ingress.DefaultBackend != nil {
+		result.DefaultBackend = &projectAPI.IngressBackend{
+			ServiceID:  fmt.Sprintf("%s:%s", namespaceID, ingress.DefaultBackend.Service),
+			TargetPort: intstr.IntOrString(ingress.DefaultBackend.TargetPort),
+		}
+	}
+	for _, rule := range ingress.Rules {
+		backendRule := projectAPI.IngressRule{Host: rule.Host}
+		for _, path := range rule.Paths {
+			backendRule.Paths = append(backendRule.Paths, projectAPI.HTTPIngressPath{
+				Path:       path.Path,
+				ServiceID:  fmt.Sprintf("%s:%s", namespaceID, path.Service),
+				TargetPort: intstr.IntOrString(path.TargetPort),
+			})
+		}
+		result.Rules = append(result.Rules, backendRule)
+	}
+	for _, tls := range ingress.TLS {
+		backendTLS := projectAPI.IngressTLS{Hosts: tls.Hosts}
+		if tls.Certificate != "" {
+			backendTLS.CertificateID = fmt.Sprintf("%s:%s", namespaceID, tls.Certificate)
+		}
+		result.TLS = append(result.TLS, backendTLS)
+	}
+	return result
+}
@@ -15,20 +15,25 @@
 package model
 
 import (
+	"fmt"
+
 	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
 )
 
 // Project is a subsection of a cluster
 type Project struct {
-	APIVersion        string                 `yaml:"api_version"`
-	Kind              string                 `yaml:"kind,omitempty"`
-	Metadata          ProjectMetadata        `yaml:"metadata,omitempty"`
-	Catalogs          []rancherModel.Catalog `yaml:"catalogs,omitempty"`
-	Namespaces        []Namespace            `yaml:"namespaces,omitempty"`
-	Resources         Resources              `yaml:"resources,omitempty"`
-	StorageClasses    []StorageClass         `yaml:"storage_classes,omitempty"`
-	PersistentVolumes []PersistentVolume     `yaml:"persistent_volumes,omitempty"`
-	Apps              []App                  `yaml:"apps,omitempty"`
+	APIVersion             string                 `yaml:"api_version"`
+	Kind                   string                 `yaml:"kind,omitempty"`
+	Metadata               ProjectMetadata        `yaml:"metadata,omitempty"`
+	Catalogs               []rancherModel.Catalog `yaml:"catalogs,omitempty"`
+	Namespaces             []Namespace            `yaml:"namespaces,omitempty"`
+	Resources              Resources              `yaml:"resources,omitempty"`
+	StorageClasses         []StorageClass         `yaml:"storage_classes,omitempty"`
+	PersistentVolumes      []PersistentVolume     `yaml:"persistent_volumes,omitempty"`
+	Apps                   []App                  `yaml:"apps,omitempty"`
+	Members                []Member               `yaml:"members,omitempty"`
+	NetworkPolicies        []NetworkPolicy        `yaml:"network_policies,omitempty"`
+	PersistentVolumeClaims []VolumeClaim          `yaml:"persistent_volume_claims,omitempty"`
 }
 
 // ProjectMetadata the meta informations about a Project
@@ -42,6 +47,62 @@ type ProjectMetadata struct {
 	ClusterName string    `yaml:"cluster_name,omitempty"`
 	ClusterID   string    `yaml:"cluster_id,omitempty"`
 	Includes    []Include `yaml:"includes,omitempty"`
+	// DependsOn other descriptors of the same file
+	DependsOn []Dependency `yaml:"depends_on,omitempty"`
+	// ResourceQuota limits the resources of all namespaces of the project
+	ResourceQuota *ResourceQuota `yaml:"resource_quota,omitempty"`
+	// NamespaceDefaultResourceQuota is the quota of each namespace without its own resource quota
+	NamespaceDefaultResourceQuota *ResourceQuota `yaml:"namespace_default_resource_quota,omitempty"`
+	// ContainerDefaultResourceLimit is applied to containers without own requests and limits
+	ContainerDefaultResourceLimit *ContainerResourceLimit `yaml:"container_default_resource_limit,omitempty"`
+}
+
+// Dependency references a resource which has to be applied before the depending resource
+type Dependency struct {
+	Kind      string `yaml:"kind"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// Member is a user or a group with its roles in a Project or Cluster
+type Member struct {
+	User  string   `yaml:"user,omitempty"`
+	Group string   `yaml:"group,omitempty"`
+	Roles []string `yaml:"roles,omitempty"`
+}
+
+// ResourceQuota limits the resources used by a project or namespace
+type ResourceQuota struct {
+	ConfigMaps             string `json:"configMaps,omitempty" yaml:"config_maps,omitempty"`
+	LimitsCPU              string `json:"limitsCpu,omitempty" yaml:"limits_cpu,omitempty"`
+	LimitsMemory           string `json:"limitsMemory,omitempty" yaml:"limits_memory,omitempty"`
+	PersistentVolumeClaims string `json:"persistentVolumeClaims,omitempty" yaml:"persistent_volume_claims,omitempty"`
+	Pods                   string `json:"pods,omitempty" yaml:"pods,omitempty"`
+	ReplicationControllers string `json:"replicationControllers,omitempty" yaml:"replication_controllers,omitempty"`
+	RequestsCPU            string `json:"requestsCpu,omitempty" yaml:"requests_cpu,omitempty"`
+	RequestsMemory         string `json:"requestsMemory,omitempty" yaml:"requests_memory,omitempty"`
+	RequestsStorage        string `json:"requestsStorage,omitempty" yaml:"requests_storage,omitempty"`
+	Secrets                string `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	Services               string `json:"services,omitempty" yaml:"services,omitempty"`
+	ServicesLoadBalancers  string `json:"servicesLoadBalancers,omitempty" yaml:"services_load_balancers,omitempty"`
+	ServicesNodePorts      string `json:"servicesNodePorts,omitempty" yaml:"services_node_ports,omitempty"`
+}
+
+// ValidateResourceQuotas checks that the resource quota of a project is declared together with
+// the namespace default resource quota, rancher requires both of them
+func ValidateResourceQuotas(metadata ProjectMetadata) error {
+	if (metadata.ResourceQuota == nil) != (metadata.NamespaceDefaultResourceQuota == nil) {
+		return fmt.Errorf("Project %v has to declare resource_quota and namespace_default_resource_quota together", metadata.Name)
+	}
+	return nil
+}
+
+// ContainerResourceLimit are the default requests and limits of a container
+type ContainerResourceLimit struct {
+	LimitsCPU      string `json:"limitsCpu,omitempty" yaml:"limits_cpu,omitempty"`
+	LimitsMemory   string `json:"limitsMemory,omitempty" yaml:"limits_memory,omitempty"`
+	RequestsCPU    string `json:"requestsCpu,omitempty" yaml:"requests_cpu,omitempty"`
+	RequestsMemory string `json:"requestsMemory,omitempty" yaml:"requests_memory,omitempty"`
 }
 
 // Include is used to merge multiple descriptors into one
@@ -53,7 +114,12 @@ type Include struct {
 
 // Namespace is a subsection of a Project and is represented in K8S as namespace
 type Namespace struct {
-	Name string
+	Name        string
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	// ResourceQuota overrides the namespace default resource quota of the project
+	ResourceQuota *ResourceQuota `yaml:"resource_quota,omitempty"`
+	DependsOn     []Dependency   `yaml:"depends_on,omitempty"`
 }
 
 // Resources of a Project
@@ -62,6 +128,8 @@ type Resources struct {
 	ConfigMaps        []ConfigMap        `yaml:"config_maps,omitempty"`
 	DockerCredentials []DockerCredential `yaml:"docker_credentials,omitempty"`
 	Secrets           []ConfigMap        `yaml:"secrets,omitempty"`
+	Services          []Service          `yaml:"services,omitempty"`
+	Ingresses         []Ingress          `yaml:"ingresses,omitempty"`
 }
 
 // Certificate TLS certs used e.g. for https endpoints
@@ -69,14 +137,16 @@ type Certificate struct {
 	Name      string
 	Key       string
 	Certs     string
-	Namespace string `yaml:"namespace,omitempty"`
+	Namespace string       `yaml:"namespace,omitempty"`
+	DependsOn []Dependency `yaml:"depends_on,omitempty"`
 }
 
 // ConfigMap data structure used for K8S configmaps and secrets
 type ConfigMap struct {
 	Name      string
 	Data      map[string]string
-	Namespace string `yaml:"namespace,omitempty"`
+	Namespace string       `yaml:"namespace,omitempty"`
+	DependsOn []Dependency `yaml:"depends_on,omitempty"`
 }
 
 //DockerCredential to access docker registries
@@ -84,6 +154,7 @@ type DockerCredential struct {
 	Name       string               `yaml:"name,omitempty"`
 	Namespace  string               `yaml:"namespace,omitempty"`
 	Registries []RegistryCredential `yaml:"registries,omitempty"`
+	DependsOn  []Dependency         `yaml:"depends_on,omitempty"`
 }
 
 // RegistryCredential credentials of one docker registry
@@ -101,18 +172,7 @@ type StorageClass struct {
 	VolumeBindMode string            `yaml:"volume_bind_mode"`
 	Parameters     map[string]string `yaml:"parameters,omitempty"`
 	MountOptions   []string          `yaml:"mount_options,omitempty"`
-}
-
-// PersistentVolume represent a K8S PersistentVolume
-type PersistentVolume struct {
-	Name             string
-	Type             string
-	Path             string
-	Node             string
-	StorageClassName string   `yaml:"storage_class_name"`
-	AccessModes      []string `yaml:"access_modes"`
-	Capacity         string
-	InitScript       string `yaml:"init_script"`
+	DependsOn      []Dependency      `yaml:"depends_on,omitempty"`
 }
 
 // App deployment using a Helm- or Rancher-Chart
@@ -126,4 +186,5 @@ type App struct {
 	SkipUpgrade bool              `yaml:"skip_upgrade,omitempty"`
 	Answers     map[string]string `yaml:"answers,omitempty"`
 	ValuesYaml  string            `yaml:"values_yaml,omitempty"`
+	DependsOn   []Dependency      `yaml:"depends_on,omitempty"`
 }
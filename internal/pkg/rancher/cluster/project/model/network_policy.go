@@ -0,0 +1,63 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// NetworkPolicy represent a K8S NetworkPolicy restricting the traffic of the pods of a namespace.
+// The json tags describe the spec of the kubernetes object.
+type NetworkPolicy struct {
+	Name        string            `json:"-" yaml:"name,omitempty"`
+	Namespace   string            `json:"-" yaml:"namespace,omitempty"`
+	Labels      map[string]string `json:"-" yaml:"labels,omitempty"`
+	Annotations map[string]string `json:"-" yaml:"annotations,omitempty"`
+	// PodSelector selects the pods of the policy, an empty selector selects all pods of the namespace
+	PodSelector LabelSelector              `json:"podSelector" yaml:"pod_selector,omitempty"`
+	PolicyTypes []string                   `json:"policyTypes,omitempty" yaml:"policy_types,omitempty"`
+	Ingress     []NetworkPolicyIngressRule `json:"ingress,omitempty" yaml:"ingress,omitempty"`
+	Egress      []NetworkPolicyEgressRule  `json:"egress,omitempty" yaml:"egress,omitempty"`
+	// ProjectIsolation allows the ingress from all namespaces of the project
+	ProjectIsolation bool         `json:"-" yaml:"project_isolation,omitempty"`
+	DependsOn        []Dependency `json:"-" yaml:"depends_on,omitempty"`
+}
+
+// NetworkPolicyIngressRule allows traffic from the peers to the ports, empty lists allow all
+type NetworkPolicyIngressRule struct {
+	From  []NetworkPolicyPeer `json:"from,omitempty" yaml:"from,omitempty"`
+	Ports []NetworkPolicyPort `json:"ports,omitempty" yaml:"ports,omitempty"`
+}
+
+// NetworkPolicyEgressRule allows traffic to the peers on the ports, empty lists allow all
+type NetworkPolicyEgressRule struct {
+	To    []NetworkPolicyPeer `json:"to,omitempty" yaml:"to,omitempty"`
+	Ports []NetworkPolicyPort `json:"ports,omitempty" yaml:"ports,omitempty"`
+}
+
+// NetworkPolicyPeer selects pods, namespaces or IP blocks
+type NetworkPolicyPeer struct {
+	PodSelector       *LabelSelector `json:"podSelector,omitempty" yaml:"pod_selector,omitempty"`
+	NamespaceSelector *LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespace_selector,omitempty"`
+	IPBlock           *IPBlock       `json:"ipBlock,omitempty" yaml:"ip_block,omitempty"`
+}
+
+// IPBlock is a CIDR without the excepted CIDRs
+type IPBlock struct {
+	CIDR   string   `json:"cidr" yaml:"cidr"`
+	Except []string `json:"except,omitempty" yaml:"except,omitempty"`
+}
+
+// NetworkPolicyPort is a port of a NetworkPolicy rule
+type NetworkPolicyPort struct {
+	Protocol string       `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Port     *IntOrString `json:"port,omitempty" yaml:"port,omitempty"`
+}
@@ -0,0 +1,119 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "fmt"
+
+const (
+	// PersistentVolumeTypeLocal is a local volume of a node (default)
+	PersistentVolumeTypeLocal = "local"
+	// PersistentVolumeTypeHostPath is a directory of the host a pod is scheduled to
+	PersistentVolumeTypeHostPath = "hostPath"
+	// PersistentVolumeTypeNFS is a NFS export
+	PersistentVolumeTypeNFS = "nfs"
+	// PersistentVolumeTypeCSI is a volume provided by a CSI driver
+	PersistentVolumeTypeCSI = "csi"
+)
+
+// PersistentVolume represent a K8S PersistentVolume
+type PersistentVolume struct {
+	Name string
+	// Type is one of local, hostPath, nfs or csi
+	Type             string
+	Path             string
+	Node             string
+	StorageClassName string   `yaml:"storage_class_name"`
+	AccessModes      []string `yaml:"access_modes"`
+	Capacity         string
+	ReclaimPolicy    string   `yaml:"reclaim_policy,omitempty"`
+	MountOptions     []string `yaml:"mount_options,omitempty"`
+	VolumeMode       string   `yaml:"volume_mode,omitempty"`
+	// HostPathType is the type of a hostPath volume e.g. DirectoryOrCreate
+	HostPathType string `yaml:"host_path_type,omitempty"`
+	// Server is the host of a nfs volume
+	Server string `yaml:"server,omitempty"`
+	// Driver, VolumeHandle and VolumeAttributes identify a csi volume
+	Driver           string            `yaml:"driver,omitempty"`
+	VolumeHandle     string            `yaml:"volume_handle,omitempty"`
+	VolumeAttributes map[string]string `yaml:"volume_attributes,omitempty"`
+	FSType           string            `yaml:"fs_type,omitempty"`
+	ReadOnly         bool              `yaml:"read_only,omitempty"`
+	InitScript       string            `yaml:"init_script"`
+	DependsOn        []Dependency      `yaml:"depends_on,omitempty"`
+}
+
+// ValidatePersistentVolume checks that a persistent volume declares the fields required by its type
+// and no fields of other types
+func ValidatePersistentVolume(persistentVolume PersistentVolume) error {
+	var (
+		path         = volumeField{"path", persistentVolume.Path}
+		node         = volumeField{"node", persistentVolume.Node}
+		hostPathType = volumeField{"host_path_type", persistentVolume.HostPathType}
+		server       = volumeField{"server", persistentVolume.Server}
+		driver       = volumeField{"driver", persistentVolume.Driver}
+		volumeHandle = volumeField{"volume_handle", persistentVolume.VolumeHandle}
+		fsType       = volumeField{"fs_type", persistentVolume.FSType}
+		volumeType   = persistentVolume.Type
+		required     []volumeField
+		unsupported  []volumeField
+	)
+	switch volumeType {
+	case "", PersistentVolumeTypeLocal:
+		volumeType = PersistentVolumeTypeLocal
+		required = []volumeField{path, node}
+		unsupported = []volumeField{hostPathType, server, driver, volumeHandle}
+	case PersistentVolumeTypeHostPath:
+		required = []volumeField{path}
+		unsupported = []volumeField{server, driver, volumeHandle, fsType}
+	case PersistentVolumeTypeNFS:
+		required = []volumeField{server, path}
+		unsupported = []volumeField{node, hostPathType, driver, volumeHandle, fsType}
+	case PersistentVolumeTypeCSI:
+		required = []volumeField{driver, volumeHandle}
+		unsupported = []volumeField{path, hostPathType, server}
+	default:
+		return fmt.Errorf("Unknown type %s of persistent volume %s, expected one of local, hostPath, nfs or csi", volumeType, persistentVolume.Name)
+	}
+	for _, field := range required {
+		if field.value == "" {
+			return fmt.Errorf("Persistent volume %s of type %s requires %s", persistentVolume.Name, volumeType, field.name)
+		}
+	}
+	for _, field := range unsupported {
+		if field.value != "" {
+			return fmt.Errorf("Persistent volume %s of type %s does not support %s", persistentVolume.Name, volumeType, field.name)
+		}
+	}
+	if len(persistentVolume.VolumeAttributes) > 0 && volumeType != PersistentVolumeTypeCSI {
+		return fmt.Errorf("Persistent volume %s of type %s does not support volume_attributes", persistentVolume.Name, volumeType)
+	}
+	switch persistentVolume.ReclaimPolicy {
+	case "", "Retain", "Delete", "Recycle":
+	default:
+		return fmt.Errorf("Unknown reclaim_policy %s of persistent volume %s, expected one of Retain, Delete or Recycle", persistentVolume.ReclaimPolicy, persistentVolume.Name)
+	}
+	switch persistentVolume.VolumeMode {
+	case "", "Filesystem", "Block":
+	default:
+		return fmt.Errorf("Unknown volume_mode %s of persistent volume %s, expected one of Filesystem or Block", persistentVolume.VolumeMode, persistentVolume.Name)
+	}
+	return nil
+}
+
+// volumeField is a type specific field of a persistent volume
+type volumeField struct {
+	name  string
+	value string
+}
@@ -0,0 +1,58 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+
+	projectAPI "github.com/rancher/types/client/project/v3"
+)
+
+// VolumeClaim represent a K8S PersistentVolumeClaim of a namespace
+type VolumeClaim struct {
+	Name        string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace   string   `json:"-" yaml:"namespace,omitempty"`
+	AccessModes []string `json:"accessModes,omitempty" yaml:"access_modes,omitempty"`
+	// Storage is the requested size of the volume, it may be increased on upgrade
+	Storage string `json:"-" yaml:"storage,omitempty"`
+	// StorageClassName is empty to use the default storage class of the cluster
+	StorageClassName string `json:"storageClassId,omitempty" yaml:"storage_class_name,omitempty"`
+	// VolumeName binds the claim to an existing persistent volume
+	VolumeName  string            `json:"volumeId,omitempty" yaml:"volume_name,omitempty"`
+	VolumeMode  string            `json:"volumeMode,omitempty" yaml:"volume_mode,omitempty"`
+	Selector    *LabelSelector    `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	DependsOn   []Dependency      `json:"-" yaml:"depends_on,omitempty"`
+}
+
+// ConvertVolumeClaimToProjectAPI converts a VolumeClaim to the rancher project API
+func ConvertVolumeClaimToProjectAPI(volumeClaim VolumeClaim) (projectAPI.PersistentVolumeClaim, error) {
+	result := projectAPI.PersistentVolumeClaim{}
+	transferContent, err := json.Marshal(volumeClaim)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(transferContent, &result)
+	if err != nil {
+		return result, err
+	}
+	if volumeClaim.Storage != "" {
+		result.Resources = &projectAPI.ResourceRequirements{
+			Requests: map[string]string{"storage": volumeClaim.Storage},
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,112 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+)
+
+func TestValidatePersistentVolume(t *testing.T) {
+	tests := []struct {
+		name             string
+		persistentVolume PersistentVolume
+		wantErr          bool
+		wantedErr        string
+	}{
+		{
+			name:             "local-without-type",
+			persistentVolume: PersistentVolume{Name: "data", Path: "/var/data", Node: "node-1"},
+		},
+		{
+			name:             "local-without-node",
+			persistentVolume: PersistentVolume{Name: "data", Type: "local", Path: "/var/data"},
+			wantErr:          true,
+			wantedErr:        "Persistent volume data of type local requires node",
+		},
+		{
+			name:             "host-path",
+			persistentVolume: PersistentVolume{Name: "data", Type: "hostPath", Path: "/var/data", HostPathType: "DirectoryOrCreate"},
+		},
+		{
+			name:             "nfs",
+			persistentVolume: PersistentVolume{Name: "data", Type: "nfs", Server: "nfs.example.com", Path: "/exports/data", ReadOnly: true},
+		},
+		{
+			name:             "nfs-without-server",
+			persistentVolume: PersistentVolume{Name: "data", Type: "nfs", Path: "/exports/data"},
+			wantErr:          true,
+			wantedErr:        "Persistent volume data of type nfs requires server",
+		},
+		{
+			name:             "nfs-with-node",
+			persistentVolume: PersistentVolume{Name: "data", Type: "nfs", Server: "nfs.example.com", Path: "/exports/data", Node: "node-1"},
+			wantErr:          true,
+			wantedErr:        "Persistent volume data of type nfs does not support node",
+		},
+		{
+			name: "csi",
+			persistentVolume: PersistentVolume{
+				Name:             "data",
+				Type:             "csi",
+				Driver:           "ebs.csi.aws.com",
+				VolumeHandle:     "vol-4711",
+				VolumeAttributes: map[string]string{"partition": "1"},
+				FSType:           "ext4",
+			},
+		},
+		{
+			name:             "csi-with-path",
+			persistentVolume: PersistentVolume{Name: "data", Type: "csi", Driver: "ebs.csi.aws.com", VolumeHandle: "vol-4711", Path: "/var/data"},
+			wantErr:          true,
+			wantedErr:        "Persistent volume data of type csi does not support path",
+		},
+		{
+			name:             "volume-attributes-without-csi",
+			persistentVolume: PersistentVolume{Name: "data", Type: "hostPath", Path: "/var/data", VolumeAttributes: map[string]string{"partition": "1"}},
+			wantErr:          true,
+			wantedErr:        "Persistent volume data of type hostPath does not support volume_attributes",
+		},
+		{
+			name:             "unknown-type",
+			persistentVolume: PersistentVolume{Name: "data", Type: "iscsi"},
+			wantErr:          true,
+			wantedErr:        "Unknown type iscsi of persistent volume data, expected one of local, hostPath, nfs or csi",
+		},
+		{
+			name:             "unknown-reclaim-policy",
+			persistentVolume: PersistentVolume{Name: "data", Type: "hostPath", Path: "/var/data", ReclaimPolicy: "Keep"},
+			wantErr:          true,
+			wantedErr:        "Unknown reclaim_policy Keep of persistent volume data, expected one of Retain, Delete or Recycle",
+		},
+		{
+			name:             "unknown-volume-mode",
+			persistentVolume: PersistentVolume{Name: "data", Type: "hostPath", Path: "/var/data", VolumeMode: "Raw"},
+			wantErr:          true,
+			wantedErr:        "Unknown volume_mode Raw of persistent volume data, expected one of Filesystem or Block",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePersistentVolume(tt.persistentVolume)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+			} else {
+				assert.Ok(t, err)
+			}
+		})
+	}
+}
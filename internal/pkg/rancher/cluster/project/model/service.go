@@ -0,0 +1,69 @@
+// Copyright © 2018 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+
+	projectAPI "github.com/rancher/types/client/project/v3"
+)
+
+// ServiceDescriptor is the standalone descriptor of a Service
+type ServiceDescriptor struct {
+	APIVersion string `yaml:"api_version"`
+	Kind       string
+	Metadata   WorkloadMetadata
+	Spec       Service
+}
+
+// Service represent a K8S Service
+type Service struct {
+	Name                  string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace             string            `json:"-" yaml:"namespace,omitempty"`
+	Type                  string            `json:"kind,omitempty" yaml:"type,omitempty"`
+	ClusterIP             string            `json:"clusterIp,omitempty" yaml:"cluster_ip,omitempty"`
+	ExternalName          string            `json:"hostname,omitempty" yaml:"external_name,omitempty"`
+	ExternalTrafficPolicy string            `json:"externalTrafficPolicy,omitempty" yaml:"external_traffic_policy,omitempty"`
+	LoadBalancerIP        string            `json:"loadBalancerIP,omitempty" yaml:"load_balancer_ip,omitempty"`
+	SessionAffinity       string            `json:"sessionAffinity,omitempty" yaml:"session_affinity,omitempty"`
+	Selector              map[string]string `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Ports                 []ServicePort     `json:"ports,omitempty" yaml:"ports,omitempty"`
+	Labels                map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations           map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	DependsOn             []Dependency      `json:"-" yaml:"depends_on,omitempty"`
+}
+
+// ServicePort is a port exposed by a Service
+type ServicePort struct {
+	Name       string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Protocol   string      `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Port       int64       `json:"port,omitempty" yaml:"port,omitempty"`
+	TargetPort IntOrString `json:"targetPort,omitempty" yaml:"target_port,omitempty"`
+	NodePort   int64       `json:"nodePort,omitempty" yaml:"node_port,omitempty"`
+}
+
+// ConvertServiceToProjectAPI converts a Service to the rancher project API
+func ConvertServiceToProjectAPI(service Service) (projectAPI.Service, error) {
+	result := projectAPI.Service{}
+	transferContent, err := json.Marshal(service)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(transferContent, &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
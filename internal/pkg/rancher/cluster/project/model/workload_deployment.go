@@ -31,6 +31,7 @@ type Deployment struct {
 	baseWorkload     `yaml:"baseWorkload,inline"`
 	DeploymentConfig *DeploymentConfig `json:"deploymentConfig,omitempty" yaml:"deploymentConfig,omitempty"`
 	Scale            *int64            `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Autoscaling      *Autoscaling      `json:"-" yaml:"autoscaling,omitempty"`
 }
 
 type DeploymentConfig struct {
@@ -25,6 +25,8 @@ type WorkloadMetadata struct {
 	TokenKey    string `yaml:"token_key,omitempty"`
 	ClusterName string `yaml:"cluster_name,omitempty"`
 	ClusterID   string `yaml:"cluster_id,omitempty"`
+	// DependsOn other descriptors of the same file
+	DependsOn []Dependency `yaml:"depends_on,omitempty"`
 }
 
 type baseWorkload struct {
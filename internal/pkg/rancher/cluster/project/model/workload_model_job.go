@@ -28,10 +28,18 @@ type JobDescriptor struct {
 	Spec       Job
 }
 
+const (
+	// JobUpgradeStrategySkip keeps an existing job untouched
+	JobUpgradeStrategySkip = "skip"
+	// JobUpgradeStrategyRecreate deletes and creates an existing job if its spec changed
+	JobUpgradeStrategyRecreate = "recreate"
+)
+
 type Job struct {
 	baseWorkload            `yaml:"baseWorkload,inline"`
 	JobConfig               *JobConfig `json:"jobConfig,omitempty" yaml:"jobConfig,omitempty"`
 	TTLSecondsAfterFinished *int64     `yaml:"TTLSecondsAfterFinished,omitempty"`
+	UpgradeStrategy         string     `json:"-" yaml:"upgradeStrategy,omitempty"`
 }
 
 type JobConfig struct {
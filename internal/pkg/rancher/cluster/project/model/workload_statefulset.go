@@ -31,6 +31,7 @@ type StatefulSet struct {
 	baseWorkload      `yaml:"baseWorkload,inline"`
 	StatefulSetConfig *StatefulSetConfig `json:"statefulSetConfig,omitempty" yaml:"statefulSetConfig,omitempty"`
 	Scale             *int64             `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Autoscaling       *Autoscaling       `json:"-" yaml:"autoscaling,omitempty"`
 }
 
 type StatefulSetConfig struct {
@@ -93,6 +93,14 @@ func (parser fileParser) Parse(projectData []byte, target interface{}) error {
 			}
 		}
 	}
+	if err := projectModel.ValidateResourceQuotas(targetProject.Metadata); err != nil {
+		return err
+	}
+	for _, persistentVolume := range targetProject.PersistentVolumes {
+		if err := projectModel.ValidatePersistentVolume(persistentVolume); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -65,6 +65,28 @@ func TestErrorOnParsingClusterDescriptor(t *testing.T) {
 	}
 }
 
+func TestErrorOnParsingInvalidPersistentVolume(t *testing.T) {
+	projectFile := "testdata/input/invalid-persistent-volume.yaml"
+	projectData, err := ioutil.ReadFile(projectFile)
+	assert.Ok(t, err)
+
+	err = NewProjectParser(projectFile, map[string]interface{}{}).Parse(projectData, &model.Project{})
+
+	assert.NotOk(t, err, "Persistent volume shared-data of type nfs requires server")
+	assert.Equals(t, "Persistent volume shared-data of type nfs requires server", err.Error())
+}
+
+func TestErrorOnParsingResourceQuotaWithoutNamespaceDefault(t *testing.T) {
+	projectFile := "testdata/input/invalid-resource-quota.yaml"
+	projectData, err := ioutil.ReadFile(projectFile)
+	assert.Ok(t, err)
+
+	err = NewProjectParser(projectFile, map[string]interface{}{}).Parse(projectData, &model.Project{})
+
+	assert.NotOk(t, err, "Project test-project has to declare resource_quota and namespace_default_resource_quota together")
+	assert.Equals(t, "Project test-project has to declare resource_quota and namespace_default_resource_quota together", err.Error())
+}
+
 func TestParseValidProjectDescriptor(t *testing.T) {
 	testName := "valid-project"
 	//Arrange
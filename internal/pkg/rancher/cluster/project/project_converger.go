@@ -15,27 +15,45 @@
 package project
 
 import (
+	"fmt"
+
 	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
 	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
 	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
 )
 
+const (
+	basePhase     = "phase:base"
+	resourcePhase = "phase:resources"
+)
+
 // NewProjectConverger creates a Converger for a given github.com/bitgrip/cattlectl/internal/pkg/projectModel.Project
-func NewProjectConverger(project projectModel.Project, clusterClient client.ClusterClient) (descriptor.Converger, error) {
+//
+// The resources are converged in phases, up to parallelism resources are converged concurrently:
+//
+// * catalogs, namespaces, storage classes and members
+// * certificates, config maps, docker credentials, secrets, services and persistent volumes
+// * ingresses and apps
+//
+// Resources declaring depends_on are converged after the resources they depend on.
+func NewProjectConverger(project projectModel.Project, clusterClient client.ClusterClient, parallelism int) (descriptor.Converger, error) {
+	return newProjectConverger(project, clusterClient, parallelism)
+}
+
+func newProjectConverger(project projectModel.Project, clusterClient client.ClusterClient, parallelism int) (*descriptor.ResourceClientConverger, error) {
 	projectClient, err := clusterClient.Project(project.Metadata.Name)
 	if err != nil {
 		return nil, err
 	}
-	childConvergers := make([]descriptor.Converger, 0)
+	projectClient.SetData(project)
+	graph := &projectGraph{projectName: project.Metadata.Name}
 	for _, catalog := range project.Catalogs {
 		catalogClient, err := projectClient.Catalog(catalog.Name)
 		if err != nil {
 			return nil, err
 		}
 		catalogClient.SetData(catalog)
-		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
-			Client: catalogClient,
-		})
+		graph.add("", catalogClient, "", catalog.Name, nil)
 	}
 	for _, namespace := range project.Namespaces {
 		namespaceClient, err := projectClient.Namespace(namespace.Name)
@@ -43,19 +61,35 @@ func NewProjectConverger(project projectModel.Project, clusterClient client.Clus
 			return nil, err
 		}
 		namespaceClient.SetData(namespace)
-		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
-			Client: namespaceClient,
-		})
+		graph.add("", namespaceClient, "", namespace.Name, namespace.DependsOn)
 	}
+	for _, storageClass := range project.StorageClasses {
+		storageClassClient, err := clusterClient.StorageClass(storageClass.Name)
+		if err != nil {
+			return nil, err
+		}
+		storageClassClient.SetData(storageClass)
+		graph.add("", storageClassClient, "", storageClass.Name, storageClass.DependsOn)
+	}
+	for _, member := range project.Members {
+		if (member.User == "") == (member.Group == "") {
+			return nil, fmt.Errorf("Member with roles %v needs either a user or a group", member.Roles)
+		}
+		memberClient, err := projectClient.ProjectRoleTemplateBinding(client.MemberPrincipalID(member))
+		if err != nil {
+			return nil, err
+		}
+		memberClient.SetData(member)
+		graph.add("", memberClient, "", client.MemberPrincipalID(member), nil)
+	}
+	graph.closePhase(basePhase)
 	for _, certificate := range project.Resources.Certificates {
 		certificateClient, err := projectClient.Certificate(certificate.Name, certificate.Namespace)
 		if err != nil {
 			return nil, err
 		}
 		certificateClient.SetData(certificate)
-		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
-			Client: certificateClient,
-		})
+		graph.add(basePhase, certificateClient, certificate.Namespace, certificate.Name, certificate.DependsOn)
 	}
 	for _, configMap := range project.Resources.ConfigMaps {
 		configMapClient, err := projectClient.ConfigMap(configMap.Name, configMap.Namespace)
@@ -63,9 +97,7 @@ func NewProjectConverger(project projectModel.Project, clusterClient client.Clus
 			return nil, err
 		}
 		configMapClient.SetData(configMap)
-		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
-			Client: configMapClient,
-		})
+		graph.add(basePhase, configMapClient, configMap.Namespace, configMap.Name, configMap.DependsOn)
 	}
 	for _, dockerCredential := range project.Resources.DockerCredentials {
 		dockerCredentialClient, err := projectClient.DockerCredential(dockerCredential.Name, dockerCredential.Namespace)
@@ -73,9 +105,7 @@ func NewProjectConverger(project projectModel.Project, clusterClient client.Clus
 			return nil, err
 		}
 		dockerCredentialClient.SetData(dockerCredential)
-		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
-			Client: dockerCredentialClient,
-		})
+		graph.add(basePhase, dockerCredentialClient, dockerCredential.Namespace, dockerCredential.Name, dockerCredential.DependsOn)
 	}
 	for _, secret := range project.Resources.Secrets {
 		secretClient, err := projectClient.Secret(secret.Name, secret.Namespace)
@@ -83,19 +113,23 @@ func NewProjectConverger(project projectModel.Project, clusterClient client.Clus
 			return nil, err
 		}
 		secretClient.SetData(secret)
-		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
-			Client: secretClient,
-		})
+		graph.add(basePhase, secretClient, secret.Namespace, secret.Name, secret.DependsOn)
 	}
-	for _, storageClass := range project.StorageClasses {
-		storageClassClient, err := clusterClient.StorageClass(storageClass.Name)
+	for _, service := range project.Resources.Services {
+		serviceClient, err := projectClient.Service(service.Name, service.Namespace)
 		if err != nil {
 			return nil, err
 		}
-		storageClassClient.SetData(storageClass)
-		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
-			Client: storageClassClient,
-		})
+		serviceClient.SetData(service)
+		graph.add(basePhase, serviceClient, service.Namespace, service.Name, service.DependsOn)
+	}
+	for _, networkPolicy := range project.NetworkPolicies {
+		networkPolicyClient, err := projectClient.NetworkPolicy(networkPolicy.Name, networkPolicy.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		networkPolicyClient.SetData(networkPolicy)
+		graph.add(basePhase, networkPolicyClient, networkPolicy.Namespace, networkPolicy.Name, networkPolicy.DependsOn)
 	}
 	for _, persistentVolume := range project.PersistentVolumes {
 		persistentVolumeClient, err := clusterClient.PersistentVolume(persistentVolume.Name)
@@ -103,9 +137,24 @@ func NewProjectConverger(project projectModel.Project, clusterClient client.Clus
 			return nil, err
 		}
 		persistentVolumeClient.SetData(persistentVolume)
-		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
-			Client: persistentVolumeClient,
-		})
+		graph.add(basePhase, persistentVolumeClient, "", persistentVolume.Name, persistentVolume.DependsOn)
+	}
+	for _, volumeClaim := range project.PersistentVolumeClaims {
+		volumeClaimClient, err := projectClient.PersistentVolumeClaim(volumeClaim.Name, volumeClaim.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		volumeClaimClient.SetData(volumeClaim)
+		graph.add(basePhase, volumeClaimClient, volumeClaim.Namespace, volumeClaim.Name, volumeClaim.DependsOn)
+	}
+	graph.closePhase(resourcePhase)
+	for _, ingress := range project.Resources.Ingresses {
+		ingressClient, err := projectClient.Ingress(ingress.Name, ingress.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		ingressClient.SetData(ingress)
+		graph.add(resourcePhase, ingressClient, ingress.Namespace, ingress.Name, ingress.DependsOn)
 	}
 	for _, app := range project.Apps {
 		appClient, err := projectClient.App(app.Name)
@@ -113,12 +162,67 @@ func NewProjectConverger(project projectModel.Project, clusterClient client.Clus
 			return nil, err
 		}
 		appClient.SetData(app)
-		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
-			Client: appClient,
-		})
+		graph.add(resourcePhase, appClient, "", app.Name, app.DependsOn)
+	}
+	parallelConverger, err := descriptor.NewParallelConverger(graph.nodes, parallelism)
+	if err != nil {
+		return nil, err
 	}
 	return &descriptor.ResourceClientConverger{
 		Client:   projectClient,
-		Children: childConvergers,
+		Children: []descriptor.Converger{parallelConverger},
 	}, nil
 }
+
+// projectGraph collects the resources of a project and their dependencies
+type projectGraph struct {
+	projectName string
+	nodes       []descriptor.ParallelNode
+	phaseNodes  []string
+}
+
+// add a resource which depends on the given phase and the declared dependencies,
+// the resource is marked as declared by the project to be pruned with it
+func (graph *projectGraph) add(phase string, resourceClient client.ResourceClient, namespace, name string, dependsOn []projectModel.Dependency) {
+	if declaredClient, ok := resourceClient.(client.ProjectDeclaredResourceClient); ok {
+		declaredClient.DeclaredBy(graph.projectName)
+	}
+	keys := DependencyKeys(resourceClient.Type(), namespace, name)
+	node := descriptor.ParallelNode{
+		Keys:      keys,
+		Converger: &descriptor.ResourceClientConverger{Client: resourceClient},
+	}
+	if phase != "" {
+		node.After = append(node.After, phase)
+	}
+	for _, dependency := range dependsOn {
+		node.DependsOn = append(node.DependsOn, DependencyKey(dependency))
+	}
+	graph.nodes = append(graph.nodes, node)
+	graph.phaseNodes = append(graph.phaseNodes, keys[len(keys)-1])
+}
+
+// closePhase adds a node which is converged after all resources added since the last phase,
+// a failed resource does not skip the resources of the following phases
+func (graph *projectGraph) closePhase(phase string) {
+	graph.nodes = append(graph.nodes, descriptor.ParallelNode{
+		Keys:  []string{phase},
+		After: graph.phaseNodes,
+	})
+	graph.phaseNodes = []string{phase}
+}
+
+// DependencyKeys are the keys a resource can be referenced by in depends_on
+func DependencyKeys(kind, namespace, name string) []string {
+	if namespace == "" {
+		return []string{fmt.Sprintf("%s/%s", kind, name)}
+	}
+	return []string{fmt.Sprintf("%s/%s", kind, name), fmt.Sprintf("%s/%s/%s", kind, namespace, name)}
+}
+
+// DependencyKey is the key of the resource(s) referenced by a dependency,
+// a dependency without namespace references the resources of all namespaces
+func DependencyKey(dependency projectModel.Dependency) string {
+	keys := DependencyKeys(dependency.Kind, dependency.Namespace, dependency.Name)
+	return keys[len(keys)-1]
+}
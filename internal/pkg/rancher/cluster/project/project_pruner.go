@@ -0,0 +1,231 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"fmt"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+)
+
+// NewPruningProjectConverger creates a Converger for a given github.com/bitgrip/cattlectl/internal/pkg/projectModel.Project
+// which also deletes the resources created by cattlectl but not declared in the project anymore.
+// If members are declared, the members bound by cattlectl but not declared anymore are removed.
+func NewPruningProjectConverger(project projectModel.Project, clusterClient client.ClusterClient, parallelism int) (descriptor.Converger, error) {
+	converger, err := newProjectConverger(project, clusterClient, parallelism)
+	if err != nil {
+		return nil, err
+	}
+	projectClient, err := clusterClient.Project(project.Metadata.Name)
+	if err != nil {
+		return nil, err
+	}
+	if project.Members != nil {
+		converger.Children = append(converger.Children, &memberPruner{
+			members:       project.Members,
+			projectClient: projectClient,
+		})
+	}
+	converger.Children = append(converger.Children, &projectPruner{
+		project:       project,
+		projectClient: projectClient,
+	})
+	return converger, nil
+}
+
+type projectPruner struct {
+	project       projectModel.Project
+	projectClient client.ProjectClient
+}
+
+func (pruner *projectPruner) Converge(dryRun bool) (descriptor.ConvergeResult, error) {
+	return pruner.converge(dryRun, false)
+}
+
+func (pruner *projectPruner) ConvergeAll(dryRun bool) (descriptor.ConvergeResult, error) {
+	return pruner.converge(dryRun, true)
+}
+
+func (pruner *projectPruner) converge(dryRun, keepGoing bool) (result descriptor.ConvergeResult, err error) {
+	candidates, err := pruner.candidates()
+	if err != nil {
+		return
+	}
+	return descriptor.DeleteCandidates(candidates, dryRun, keepGoing)
+}
+
+func (pruner *projectPruner) Diff() (result descriptor.DiffResult, err error) {
+	candidates, err := pruner.candidates()
+	if err != nil {
+		return
+	}
+	return descriptor.DiffCandidates(candidates)
+}
+
+// candidates lists all resources declared by a project descriptor which are not part of the project descriptor anymore.
+// Resources created by other descriptors e.g. a standalone service descriptor are kept.
+// Namespaces are listed last as deleting them also deletes all their resources.
+func (pruner *projectPruner) candidates() (result []client.ResourceClient, err error) {
+	projectID, err := pruner.projectClient.ID()
+	if err != nil || projectID == "" {
+		return
+	}
+	declared := pruner.declaredResources()
+	namespaces, err := pruner.projectClient.Namespaces()
+	if err != nil {
+		return
+	}
+	for _, namespace := range namespaces {
+		var namespaceName string
+		if namespaceName, err = namespace.Name(); err != nil {
+			return
+		}
+		var configMaps, secrets []client.ConfigMapClient
+		if configMaps, err = pruner.projectClient.ConfigMaps(namespaceName); err != nil {
+			return
+		}
+		if secrets, err = pruner.projectClient.Secrets(namespaceName); err != nil {
+			return
+		}
+		if result, err = appendUndeclaredConfigMaps(result, declared, namespaceName, configMaps); err != nil {
+			return
+		}
+		if result, err = appendUndeclaredConfigMaps(result, declared, namespaceName, secrets); err != nil {
+			return
+		}
+		var ingresses []client.IngressClient
+		if ingresses, err = pruner.projectClient.Ingresses(namespaceName); err != nil {
+			return
+		}
+		for _, ingress := range ingresses {
+			if result, err = appendUndeclared(result, declared, namespaceName, ingress, ingress.Owned); err != nil {
+				return
+			}
+		}
+		var services []client.ServiceClient
+		if services, err = pruner.projectClient.Services(namespaceName); err != nil {
+			return
+		}
+		for _, service := range services {
+			if result, err = appendUndeclared(result, declared, namespaceName, service, service.Owned); err != nil {
+				return
+			}
+		}
+		var networkPolicies []client.NetworkPolicyClient
+		if networkPolicies, err = pruner.projectClient.NetworkPolicies(namespaceName); err != nil {
+			return
+		}
+		for _, networkPolicy := range networkPolicies {
+			if result, err = appendUndeclared(result, declared, namespaceName, networkPolicy, networkPolicy.Owned); err != nil {
+				return
+			}
+		}
+		var volumeClaims []client.PersistentVolumeClaimClient
+		if volumeClaims, err = pruner.projectClient.PersistentVolumeClaims(namespaceName); err != nil {
+			return
+		}
+		for _, volumeClaim := range volumeClaims {
+			if result, err = appendUndeclared(result, declared, namespaceName, volumeClaim, volumeClaim.Owned); err != nil {
+				return
+			}
+		}
+	}
+	globalSecrets, err := pruner.projectClient.GlobalSecrets()
+	if err != nil {
+		return
+	}
+	if result, err = appendUndeclaredConfigMaps(result, declared, "", globalSecrets); err != nil {
+		return
+	}
+	apps, err := pruner.projectClient.Apps()
+	if err != nil {
+		return
+	}
+	for _, app := range apps {
+		if result, err = appendUndeclared(result, declared, "", app, app.Owned); err != nil {
+			return
+		}
+	}
+	for _, namespace := range namespaces {
+		if result, err = appendUndeclared(result, declared, "", namespace, namespace.Owned); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (pruner *projectPruner) declaredResources() map[string]bool {
+	declared := make(map[string]bool)
+	for _, namespace := range pruner.project.Namespaces {
+		declared[resourceKey(rancherModel.Namespace, "", namespace.Name)] = true
+	}
+	for _, configMap := range pruner.project.Resources.ConfigMaps {
+		declared[resourceKey(rancherModel.ConfigMap, configMap.Namespace, configMap.Name)] = true
+	}
+	for _, secret := range pruner.project.Resources.Secrets {
+		declared[resourceKey(rancherModel.Secret, secret.Namespace, secret.Name)] = true
+	}
+	for _, service := range pruner.project.Resources.Services {
+		declared[resourceKey(rancherModel.ServiceKind, service.Namespace, service.Name)] = true
+	}
+	for _, ingress := range pruner.project.Resources.Ingresses {
+		declared[resourceKey(rancherModel.IngressKind, ingress.Namespace, ingress.Name)] = true
+	}
+	for _, networkPolicy := range pruner.project.NetworkPolicies {
+		declared[resourceKey(rancherModel.NetworkPolicy, networkPolicy.Namespace, networkPolicy.Name)] = true
+	}
+	for _, volumeClaim := range pruner.project.PersistentVolumeClaims {
+		declared[resourceKey(rancherModel.PersistentVolumeClaim, volumeClaim.Namespace, volumeClaim.Name)] = true
+	}
+	for _, app := range pruner.project.Apps {
+		declared[resourceKey(rancherModel.App, "", app.Name)] = true
+	}
+	return declared
+}
+
+func appendUndeclaredConfigMaps(result []client.ResourceClient, declared map[string]bool, namespace string, configMaps []client.ConfigMapClient) ([]client.ResourceClient, error) {
+	var err error
+	for _, configMap := range configMaps {
+		if result, err = appendUndeclared(result, declared, namespace, configMap, configMap.Owned); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func appendUndeclared(result []client.ResourceClient, declared map[string]bool, namespace string, resource client.ResourceClient, owned func() (bool, error)) ([]client.ResourceClient, error) {
+	name, err := resource.Name()
+	if err != nil {
+		return result, err
+	}
+	if declared[resourceKey(resource.Type(), namespace, name)] {
+		return result, nil
+	}
+	isOwned, err := owned()
+	if err != nil {
+		return result, err
+	}
+	if !isOwned {
+		return result, nil
+	}
+	return append(result, resource), nil
+}
+
+func resourceKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s::%s::%s", kind, namespace, name)
+}
@@ -0,0 +1,148 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	projectModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/cluster/project/model"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+)
+
+func TestProjectPruner_Converge(t *testing.T) {
+	declared := &testPrunedServiceClient{name: "declared-service", declaredByProject: true}
+	removed := &testPrunedServiceClient{name: "removed-service", declaredByProject: true}
+	standalone := &testPrunedServiceClient{name: "standalone-service", declaredByProject: false}
+	pruner := &projectPruner{
+		project: projectModel.Project{
+			Resources: projectModel.Resources{
+				Services: []projectModel.Service{
+					{Name: "declared-service", Namespace: "test-namespace"},
+				},
+			},
+		},
+		projectClient: &testPrunedProjectClient{
+			services: []client.ServiceClient{declared, removed, standalone},
+		},
+	}
+
+	diff, err := pruner.Diff()
+	assert.Ok(t, err)
+	assert.Equals(t, []descriptor.ResourceDiff{
+		{Type: rancherModel.ServiceKind, Name: "removed-service", Action: descriptor.DiffDeleted},
+	}, diff.Resources)
+
+	result, err := pruner.Converge(false)
+	assert.Ok(t, err)
+	assert.Equals(t, []descriptor.ResourceDescriptor{
+		{Type: rancherModel.ServiceKind, Name: "removed-service"},
+	}, result.DeletedResources)
+	assert.Assert(t, !declared.deleted, "Declared service must be kept")
+	assert.Assert(t, removed.deleted, "Service removed from the project must be deleted")
+	assert.Assert(t, !standalone.deleted, "Service of a standalone descriptor must be kept")
+}
+
+type testPrunedProjectClient struct {
+	client.ProjectClient
+	services []client.ServiceClient
+}
+
+func (projectClient *testPrunedProjectClient) ID() (string, error) {
+	return "test-project-id", nil
+}
+
+func (projectClient *testPrunedProjectClient) Namespaces() ([]client.NamespaceClient, error) {
+	return []client.NamespaceClient{&testPrunedNamespaceClient{}}, nil
+}
+
+func (projectClient *testPrunedProjectClient) ConfigMaps(namespaceName string) ([]client.ConfigMapClient, error) {
+	return nil, nil
+}
+
+func (projectClient *testPrunedProjectClient) Secrets(namespaceName string) ([]client.ConfigMapClient, error) {
+	return nil, nil
+}
+
+func (projectClient *testPrunedProjectClient) Ingresses(namespaceName string) ([]client.IngressClient, error) {
+	return nil, nil
+}
+
+func (projectClient *testPrunedProjectClient) Services(namespaceName string) ([]client.ServiceClient, error) {
+	return projectClient.services, nil
+}
+
+func (projectClient *testPrunedProjectClient) NetworkPolicies(namespaceName string) ([]client.NetworkPolicyClient, error) {
+	return nil, nil
+}
+
+func (projectClient *testPrunedProjectClient) PersistentVolumeClaims(namespaceName string) ([]client.PersistentVolumeClaimClient, error) {
+	return nil, nil
+}
+
+func (projectClient *testPrunedProjectClient) GlobalSecrets() ([]client.ConfigMapClient, error) {
+	return nil, nil
+}
+
+func (projectClient *testPrunedProjectClient) Apps() ([]client.AppClient, error) {
+	return nil, nil
+}
+
+type testPrunedNamespaceClient struct {
+	client.NamespaceClient
+}
+
+func (namespace *testPrunedNamespaceClient) Type() string {
+	return rancherModel.Namespace
+}
+
+func (namespace *testPrunedNamespaceClient) Name() (string, error) {
+	return "test-namespace", nil
+}
+
+func (namespace *testPrunedNamespaceClient) Owned() (bool, error) {
+	return false, nil
+}
+
+// testPrunedServiceClient is owned like a real service client, only if a project descriptor declared it
+type testPrunedServiceClient struct {
+	client.ServiceClient
+	name              string
+	declaredByProject bool
+	deleted           bool
+}
+
+func (service *testPrunedServiceClient) Type() string {
+	return rancherModel.ServiceKind
+}
+
+func (service *testPrunedServiceClient) Name() (string, error) {
+	return service.name, nil
+}
+
+func (service *testPrunedServiceClient) Namespace() (string, error) {
+	return "test-namespace", nil
+}
+
+func (service *testPrunedServiceClient) Owned() (bool, error) {
+	return service.declaredByProject, nil
+}
+
+func (service *testPrunedServiceClient) Delete(dryRun bool) (bool, error) {
+	service.deleted = !dryRun
+	return true, nil
+}
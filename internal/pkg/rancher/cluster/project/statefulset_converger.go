@@ -30,7 +30,18 @@ func NewStatefulSetConverger(statefulSetDescriptor projectModel.StatefulSetDescr
 	if err != nil {
 		return nil, err
 	}
+	autoscalerConverger, err := newAutoscalerConverger(
+		statefulSetDescriptor.Spec.Autoscaling,
+		"statefulset",
+		statefulSetDescriptor.Spec.Name,
+		statefulSetDescriptor.Metadata.Namespace,
+		projectClient,
+	)
+	if err != nil {
+		return nil, err
+	}
 	return &descriptor.ResourceClientConverger{
-		Client: statefulSetClient,
+		Client:   statefulSetClient,
+		Children: []descriptor.Converger{autoscalerConverger},
 	}, nil
 }
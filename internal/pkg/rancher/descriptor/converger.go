@@ -15,16 +15,44 @@
 package descriptor
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
 )
 
+const (
+	// ActionCreated reports a resource created by the converger
+	ActionCreated = "created"
+	// ActionUpgraded reports a existing resource upgraded by the converger
+	ActionUpgraded = "upgraded"
+	// ActionUnchanged reports a existing resource left untouched by the converger
+	ActionUnchanged = "unchanged"
+	// ActionDeleted reports a resource deleted by the converger
+	ActionDeleted = "deleted"
+	// ActionFailed reports a resource the converger failed to handle
+	ActionFailed = "failed"
+	// ActionRolledBack reports a resource which failed to upgrade and was rolled back
+	ActionRolledBack = "rolled-back"
+)
+
 type Converger interface {
 	Converge(bool) (ConvergeResult, error)
 }
 
+// KeepGoingConverger converges all resources even if single resources fail
+type KeepGoingConverger interface {
+	Converger
+	// ConvergeAll returns a MultiError of all failed resources
+	ConvergeAll(bool) (ConvergeResult, error)
+}
+
 type ConvergeResult struct {
-	CreatedResources  []ResourceDescriptor `json:"created_resources"`
-	UpgradedResources []ResourceDescriptor `json:"upgraded_resources"`
+	CreatedResources    []ResourceDescriptor `json:"created_resources"`
+	UpgradedResources   []ResourceDescriptor `json:"upgraded_resources"`
+	DeletedResources    []ResourceDescriptor `json:"deleted_resources"`
+	RolledBackResources []ResourceDescriptor `json:"rolled_back_resources"`
+	Reports             []ResourceReport     `json:"reports"`
 }
 
 type ResourceDescriptor struct {
@@ -32,47 +60,154 @@ type ResourceDescriptor struct {
 	Name string
 }
 
+// ResourceReport is the outcome of handling a single resource
+type ResourceReport struct {
+	Type      string `json:"type" yaml:"type"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Action    string `json:"action" yaml:"action"`
+	// Duration in seconds
+	Duration float64 `json:"duration" yaml:"duration"`
+	Error    string  `json:"error,omitempty" yaml:"error,omitempty"`
+	// Outputs are values the user needs after apply, e.g. the command to register nodes of a new cluster
+	Outputs map[string]string `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// NewResourceReport creates the report of a resource handled by a resource client since start,
+// a failure to read the outputs of the resource is reported and returned as its error
+func NewResourceReport(resourceClient client.ResourceClient, name, action string, start time.Time, err error) (ResourceReport, error) {
+	namespace := ""
+	if namespacedClient, isNamespaced := resourceClient.(client.NamespacedResourceClient); isNamespaced {
+		namespace, _ = namespacedClient.Namespace()
+	}
+	var outputs map[string]string
+	if outputClient, hasOutputs := resourceClient.(client.OutputResourceClient); hasOutputs && err == nil {
+		if outputs, err = outputClient.Outputs(); err != nil {
+			err = fmt.Errorf("Failed to read outputs of %v %v, %v", resourceClient.Type(), name, err)
+		}
+	}
+	report := NewReport(resourceClient.Type(), namespace, name, action, start, err)
+	report.Outputs = outputs
+	return report, err
+}
+
+// NewReport creates the report of a resource handled since start
+func NewReport(resourceType, namespace, name, action string, start time.Time, err error) ResourceReport {
+	report := ResourceReport{
+		Type:      resourceType,
+		Name:      name,
+		Namespace: namespace,
+		Action:    action,
+		Duration:  time.Since(start).Seconds(),
+	}
+	if err != nil {
+		// a rolled back resource failed too, but keeps its action
+		if action != ActionRolledBack {
+			report.Action = ActionFailed
+		}
+		report.Error = err.Error()
+	}
+	return report
+}
+
 type ResourceClientConverger struct {
 	Client   client.ResourceClient
 	Children []Converger
 }
 
-func (converger *ResourceClientConverger) Converge(dryRun bool) (result ConvergeResult, err error) {
+func (converger *ResourceClientConverger) Converge(dryRun bool) (ConvergeResult, error) {
+	return converger.converge(dryRun, false)
+}
+
+// ConvergeAll converges all children even if some of them fail.
+// The children of a failed resource are skipped.
+func (converger *ResourceClientConverger) ConvergeAll(dryRun bool) (ConvergeResult, error) {
+	return converger.converge(dryRun, true)
+}
+
+func (converger *ResourceClientConverger) converge(dryRun, keepGoing bool) (result ConvergeResult, err error) {
 	var (
-		name    string
-		exists  bool
-		changed bool
+		name   string
+		action string
+		start  = time.Now()
 	)
 	if name, err = converger.Client.Name(); err != nil {
 		return
 	}
-	if exists, err = converger.Client.Exists(); err != nil {
+	action, err = converger.convergeClient(dryRun)
+	if converger.Client != client.EmptyResourceClient {
+		var report ResourceReport
+		report, err = NewResourceReport(converger.Client, name, action, start, err)
+		result.Reports = append(result.Reports, report)
+		if err != nil && keepGoing {
+			err = MultiError{ResourceError{Type: report.Type, Namespace: report.Namespace, Name: name, Err: err}}
+		}
+	}
+	if action == ActionRolledBack {
+		result.RolledBackResources = append(result.RolledBackResources, ResourceDescriptor{Type: converger.Client.Type(), Name: name})
+	}
+	if err != nil {
 		return
 	}
-	if exists {
-		changed, err = converger.Client.Upgrade(dryRun)
-		if err != nil {
-			return result, err
-		}
-		if changed {
-			result.UpgradedResources = append(result.UpgradedResources, ResourceDescriptor{Type: converger.Client.Type(), Name: name})
-		}
-	} else {
-		changed, err = converger.Client.Create(dryRun)
-		if err != nil {
+	switch action {
+	case ActionCreated:
+		result.CreatedResources = append(result.CreatedResources, ResourceDescriptor{Type: converger.Client.Type(), Name: name})
+	case ActionUpgraded:
+		result.UpgradedResources = append(result.UpgradedResources, ResourceDescriptor{Type: converger.Client.Type(), Name: name})
+	}
+	if pendingClient, canBePending := converger.Client.(client.PendingResourceClient); canBePending && len(converger.Children) > 0 {
+		var pending bool
+		if pending, err = pendingClient.Pending(); err != nil || pending {
 			return
 		}
-		if changed {
-			result.CreatedResources = append(result.CreatedResources, ResourceDescriptor{Type: converger.Client.Type(), Name: name})
-		}
 	}
+	var errs MultiError
 	for _, child := range converger.Children {
-		childResult, err := child.Converge(dryRun)
-		result.CreatedResources = append(result.CreatedResources, childResult.CreatedResources...)
-		result.UpgradedResources = append(result.UpgradedResources, childResult.UpgradedResources...)
-		if err != nil {
-			return result, err
+		childResult, childErr := convergeChild(child, dryRun, keepGoing)
+		result.merge(childResult)
+		if childErr != nil {
+			if !keepGoing {
+				return result, childErr
+			}
+			errs = errs.Append(childErr)
 		}
 	}
-	return
+	return result, errs.ErrorOrNil()
+}
+
+func convergeChild(child Converger, dryRun, keepGoing bool) (ConvergeResult, error) {
+	if keepGoingChild, isKeepGoing := child.(KeepGoingConverger); keepGoing && isKeepGoing {
+		return keepGoingChild.ConvergeAll(dryRun)
+	}
+	return child.Converge(dryRun)
+}
+
+func (result *ConvergeResult) merge(other ConvergeResult) {
+	result.CreatedResources = append(result.CreatedResources, other.CreatedResources...)
+	result.UpgradedResources = append(result.UpgradedResources, other.UpgradedResources...)
+	result.DeletedResources = append(result.DeletedResources, other.DeletedResources...)
+	result.RolledBackResources = append(result.RolledBackResources, other.RolledBackResources...)
+	result.Reports = append(result.Reports, other.Reports...)
+}
+
+func (converger *ResourceClientConverger) convergeClient(dryRun bool) (string, error) {
+	exists, err := converger.Client.Exists()
+	if err != nil {
+		return ActionFailed, err
+	}
+	if exists {
+		changed, err := converger.Client.Upgrade(dryRun)
+		if _, isRolledBack := err.(client.RolledBackError); isRolledBack {
+			return ActionRolledBack, err
+		}
+		if err != nil || !changed {
+			return ActionUnchanged, err
+		}
+		return ActionUpgraded, nil
+	}
+	changed, err := converger.Client.Create(dryRun)
+	if err != nil || !changed {
+		return ActionUnchanged, err
+	}
+	return ActionCreated, nil
 }
@@ -0,0 +1,211 @@
+// Copyright © 2020 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+)
+
+func TestResourceClientConverger_Converge_Reports(t *testing.T) {
+	tests := []struct {
+		name       string
+		children   []Converger
+		wantResult ConvergeResult
+		wantErr    string
+	}{
+		{
+			name: "created_upgraded_unchanged",
+			children: []Converger{
+				&ResourceClientConverger{Client: testResourceClient{name: "created", changed: true}},
+				&ResourceClientConverger{Client: testResourceClient{name: "upgraded", exists: true, changed: true}},
+				&ResourceClientConverger{Client: testResourceClient{name: "unchanged", exists: true}},
+			},
+			wantResult: ConvergeResult{
+				CreatedResources:  []ResourceDescriptor{{Type: "Test", Name: "created"}},
+				UpgradedResources: []ResourceDescriptor{{Type: "Test", Name: "upgraded"}},
+				Reports: []ResourceReport{
+					{Type: "Test", Name: "created", Action: ActionCreated},
+					{Type: "Test", Name: "upgraded", Action: ActionUpgraded},
+					{Type: "Test", Name: "unchanged", Action: ActionUnchanged},
+				},
+			},
+		},
+		{
+			name: "failed",
+			children: []Converger{
+				&ResourceClientConverger{Client: testResourceClient{name: "created", changed: true}},
+				&ResourceClientConverger{Client: testResourceClient{name: "failed", err: fmt.Errorf("upgrade failed")}},
+				&ResourceClientConverger{Client: testResourceClient{name: "not-handled", changed: true}},
+			},
+			wantResult: ConvergeResult{
+				CreatedResources: []ResourceDescriptor{{Type: "Test", Name: "created"}},
+				Reports: []ResourceReport{
+					{Type: "Test", Name: "created", Action: ActionCreated},
+					{Type: "Test", Name: "failed", Action: ActionFailed, Error: "upgrade failed"},
+				},
+			},
+			wantErr: "upgrade failed",
+		},
+		{
+			name: "rolled_back",
+			children: []Converger{
+				&ResourceClientConverger{Client: testResourceClient{name: "rolled-back", exists: true, err: client.RolledBackError{Revision: "r-1", Err: fmt.Errorf("not ready")}}},
+			},
+			wantResult: ConvergeResult{
+				RolledBackResources: []ResourceDescriptor{{Type: "Test", Name: "rolled-back"}},
+				Reports: []ResourceReport{
+					{Type: "Test", Name: "rolled-back", Action: ActionRolledBack, Error: "not ready, rolled back to revision r-1"},
+				},
+			},
+			wantErr: "not ready, rolled back to revision r-1",
+		},
+		{
+			name: "outputs",
+			children: []Converger{
+				&ResourceClientConverger{Client: testOutputClient{testResourceClient: testResourceClient{name: "created", changed: true}}},
+				&ResourceClientConverger{Client: testOutputClient{testResourceClient: testResourceClient{name: "failed", err: fmt.Errorf("create failed")}}},
+			},
+			wantResult: ConvergeResult{
+				CreatedResources: []ResourceDescriptor{{Type: "Test", Name: "created"}},
+				Reports: []ResourceReport{
+					{Type: "Test", Name: "created", Action: ActionCreated, Outputs: map[string]string{"command": "register created"}},
+					{Type: "Test", Name: "failed", Action: ActionFailed, Error: "create failed"},
+				},
+			},
+			wantErr: "create failed",
+		},
+		{
+			name: "failed_outputs",
+			children: []Converger{
+				&ResourceClientConverger{Client: testOutputClient{testResourceClient: testResourceClient{name: "created", changed: true}, outputErr: fmt.Errorf("token not found")}},
+			},
+			wantResult: ConvergeResult{
+				Reports: []ResourceReport{
+					{Type: "Test", Name: "created", Action: ActionFailed, Error: "Failed to read outputs of Test created, token not found"},
+				},
+			},
+			wantErr: "Failed to read outputs of Test created, token not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converger := &ResourceClientConverger{
+				Client:   client.EmptyResourceClient,
+				Children: tt.children,
+			}
+			result, err := converger.Converge(false)
+			if tt.wantErr != "" {
+				assert.NotOk(t, err, tt.wantErr)
+			} else {
+				assert.Ok(t, err)
+			}
+			for index := range result.Reports {
+				assert.Assert(t, result.Reports[index].Duration >= 0, "negative duration")
+				result.Reports[index].Duration = 0
+			}
+			assert.Equals(t, tt.wantResult, result)
+		})
+	}
+}
+
+func TestResourceClientConverger_ConvergeAll(t *testing.T) {
+	converger := &ResourceClientConverger{
+		Client: client.EmptyResourceClient,
+		Children: []Converger{
+			&ResourceClientConverger{
+				Client: testResourceClient{name: "failed-parent", err: fmt.Errorf("create failed")},
+				Children: []Converger{
+					&ResourceClientConverger{Client: testResourceClient{name: "skipped-child", changed: true}},
+				},
+			},
+			&ResourceClientConverger{Client: testResourceClient{name: "created", changed: true}},
+			&ResourceClientConverger{Client: testResourceClient{name: "failed", exists: true, err: fmt.Errorf("upgrade failed")}},
+		},
+	}
+	result, err := converger.ConvergeAll(false)
+	assert.NotOk(t, err, "2 resources failed:\n\t* Test failed-parent: create failed\n\t* Test failed: upgrade failed")
+	multiError, isMultiError := err.(MultiError)
+	assert.Assert(t, isMultiError, "expected a MultiError but was %T", err)
+	assert.Equals(t, ResourceError{Type: "Test", Name: "failed", Err: fmt.Errorf("upgrade failed")}, multiError[1])
+	assert.Equals(t, []ResourceDescriptor{{Type: "Test", Name: "created"}}, result.CreatedResources)
+	assert.Equals(t, 3, len(result.Reports))
+}
+
+func TestResourceClientConverger_Converge_Pending(t *testing.T) {
+	tests := []struct {
+		name          string
+		pending       bool
+		wantedCreated []ResourceDescriptor
+	}{
+		{
+			name:          "pending",
+			pending:       true,
+			wantedCreated: []ResourceDescriptor{{Type: "Test", Name: "cluster"}},
+		},
+		{
+			name:          "active",
+			pending:       false,
+			wantedCreated: []ResourceDescriptor{{Type: "Test", Name: "cluster"}, {Type: "Test", Name: "storage-class"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converger := &ResourceClientConverger{
+				Client: testPendingClient{testResourceClient: testResourceClient{name: "cluster", changed: true}, pending: tt.pending},
+				Children: []Converger{
+					&ResourceClientConverger{Client: testResourceClient{name: "storage-class", changed: true}},
+				},
+			}
+			result, err := converger.Converge(false)
+			assert.Ok(t, err)
+			assert.Equals(t, tt.wantedCreated, result.CreatedResources)
+		})
+	}
+}
+
+func TestMultiError_Append(t *testing.T) {
+	var errs MultiError
+	assert.Ok(t, errs.ErrorOrNil())
+	errs = errs.Append(fmt.Errorf("first"))
+	errs = errs.Append(MultiError{fmt.Errorf("second"), fmt.Errorf("third")})
+	assert.Equals(t, 3, len(errs))
+	assert.NotOk(t, errs.ErrorOrNil(), "3 resources failed:\n\t* first\n\t* second\n\t* third")
+	assert.NotOk(t, ResourceError{Type: "Deployment", Namespace: "web", Name: "nginx", Err: fmt.Errorf("failed")}, "Deployment web/nginx: failed")
+}
+
+type testOutputClient struct {
+	testResourceClient
+	outputErr error
+}
+
+func (client testOutputClient) Outputs() (map[string]string, error) {
+	if client.outputErr != nil {
+		return nil, client.outputErr
+	}
+	return map[string]string{"command": "register " + client.name}, nil
+}
+
+type testPendingClient struct {
+	testResourceClient
+	pending bool
+}
+
+func (client testPendingClient) Pending() (bool, error) {
+	return client.pending, nil
+}
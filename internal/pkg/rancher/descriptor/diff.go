@@ -0,0 +1,214 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// DiffCreated marks a resource which dose not exist and would be created
+	DiffCreated = "created"
+	// DiffChanged marks a existing resource which would be upgraded
+	DiffChanged = "changed"
+	// DiffUnchanged marks a existing resource matching the descriptor
+	DiffUnchanged = "unchanged"
+	// DiffDeleted marks a resource which would be deleted by prune
+	DiffDeleted = "deleted"
+	// DiffWouldSkip marks a existing resource which differs but is not upgraded by cattlectl
+	DiffWouldSkip = "would-skip"
+)
+
+// Differ compares a descriptor with the live state of rancher
+type Differ interface {
+	Diff() (DiffResult, error)
+}
+
+// DiffResult lists the differences of all resources of a descriptor
+type DiffResult struct {
+	Resources []ResourceDiff `json:"resources"`
+}
+
+// ResourceDiff is the difference of a single resource
+type ResourceDiff struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Action   string `json:"action"`
+	Compared bool   `json:"compared"`
+	Existing string `json:"existing,omitempty"`
+	Desired  string `json:"desired,omitempty"`
+}
+
+// Diff compares the resources of the converger tree without changing anything
+func (converger *ResourceClientConverger) Diff() (DiffResult, error) {
+	return converger.diff(true)
+}
+
+func (converger *ResourceClientConverger) diff(parentExists bool) (result DiffResult, err error) {
+	exists := false
+	if parentExists {
+		if exists, err = converger.Client.Exists(); err != nil {
+			return
+		}
+	}
+	if converger.Client != client.EmptyResourceClient {
+		var resourceDiff ResourceDiff
+		if resourceDiff, err = diffResource(converger.Client, exists); err != nil {
+			return
+		}
+		result.Resources = append(result.Resources, resourceDiff)
+	}
+	childResult, err := diffChildren(converger.Children, exists)
+	result.Resources = append(result.Resources, childResult.Resources...)
+	return
+}
+
+func diffChildren(children []Converger, parentExists bool) (result DiffResult, err error) {
+	for _, child := range children {
+		var childResult DiffResult
+		switch childConverger := child.(type) {
+		case *ResourceClientConverger:
+			childResult, err = childConverger.diff(parentExists)
+		case *ParallelConverger:
+			childResult, err = childConverger.diff(parentExists)
+		case Differ:
+			childResult, err = childConverger.Diff()
+		default:
+			err = fmt.Errorf("Diff not supported by %T", child)
+		}
+		if err != nil {
+			return
+		}
+		result.Resources = append(result.Resources, childResult.Resources...)
+	}
+	return
+}
+
+func diffResource(resourceClient client.ResourceClient, exists bool) (result ResourceDiff, err error) {
+	result.Type = resourceClient.Type()
+	if result.Name, err = resourceClient.Name(); err != nil {
+		return
+	}
+	diffableClient, isDiffable := resourceClient.(client.DiffableResourceClient)
+	if !isDiffable {
+		if exists {
+			result.Action = DiffUnchanged
+		} else {
+			result.Action = DiffCreated
+		}
+		return
+	}
+	result.Compared = true
+	desired, err := diffableClient.Desired()
+	if err != nil {
+		return
+	}
+	desiredObject, err := toPlainObject(desired)
+	if err != nil {
+		return
+	}
+	if result.Desired, err = toYaml(desiredObject); err != nil {
+		return
+	}
+	if !exists {
+		result.Action = DiffCreated
+		return
+	}
+	existing, err := diffableClient.Existing()
+	if err != nil {
+		return
+	}
+	existingObject, err := toPlainObject(existing)
+	if err != nil {
+		return
+	}
+	// only show the members managed by cattlectl, rancher adds a lot of
+	// status and default values to each object
+	if result.Existing, err = toYaml(pruneTo(existingObject, desiredObject)); err != nil {
+		return
+	}
+	// the action is decided by the same comparison apply uses
+	unchanged, err := diffableClient.Unchanged()
+	if err != nil {
+		return
+	}
+	switch {
+	case unchanged:
+		result.Action = DiffUnchanged
+	case diffableClient.SkipsUpgrade():
+		result.Action = DiffWouldSkip
+	default:
+		result.Action = DiffChanged
+	}
+	return
+}
+
+func toPlainObject(object interface{}) (result interface{}, err error) {
+	if object == nil {
+		return
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &result)
+	return
+}
+
+func toYaml(object interface{}) (string, error) {
+	if object == nil {
+		return "", nil
+	}
+	data, err := yaml.Marshal(object)
+	return string(data), err
+}
+
+// pruneTo removes all members of existing which are unknown to desired
+func pruneTo(existing, desired interface{}) interface{} {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		existingValue, isMap := existing.(map[string]interface{})
+		if !isMap {
+			return existing
+		}
+		result := make(map[string]interface{}, len(desiredValue))
+		for key, value := range desiredValue {
+			if existingMember, found := existingValue[key]; found {
+				result[key] = pruneTo(existingMember, value)
+			}
+		}
+		return result
+	case []interface{}:
+		existingValue, isList := existing.([]interface{})
+		if !isList {
+			return existing
+		}
+		result := make([]interface{}, len(existingValue))
+		for index, value := range existingValue {
+			if index < len(desiredValue) {
+				result[index] = pruneTo(value, desiredValue[index])
+			} else {
+				result[index] = value
+			}
+		}
+		return result
+	default:
+		return existing
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright © 2020 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+)
+
+func TestResourceClientConverger_Diff(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   testDiffableClient
+		children []Converger
+		want     []ResourceDiff
+	}{
+		{
+			name: "created_with_children",
+			client: testDiffableClient{
+				desired: map[string]interface{}{"name": "parent"},
+			},
+			children: []Converger{
+				&ResourceClientConverger{Client: testDiffableClient{
+					name:    "child",
+					exists:  true,
+					desired: map[string]interface{}{"name": "child"},
+				}},
+			},
+			want: []ResourceDiff{
+				{Type: "Test", Name: "test-resource", Action: DiffCreated, Compared: true, Desired: "name: parent\n"},
+				{Type: "Test", Name: "child", Action: DiffCreated, Compared: true, Desired: "name: child\n"},
+			},
+		},
+		{
+			name: "unchanged_ignoring_rancher_members",
+			client: testDiffableClient{
+				exists:    true,
+				unchanged: true,
+				desired: map[string]interface{}{
+					"name":       "test-resource",
+					"containers": []interface{}{map[string]interface{}{"image": "nginx"}},
+				},
+				existing: map[string]interface{}{
+					"name":       "test-resource",
+					"state":      "active",
+					"containers": []interface{}{map[string]interface{}{"image": "nginx", "imagePullPolicy": "Always"}},
+				},
+			},
+			want: []ResourceDiff{
+				{
+					Type:     "Test",
+					Name:     "test-resource",
+					Action:   DiffUnchanged,
+					Compared: true,
+					Existing: "containers:\n- image: nginx\nname: test-resource\n",
+					Desired:  "containers:\n- image: nginx\nname: test-resource\n",
+				},
+			},
+		},
+		{
+			name: "changed",
+			client: testDiffableClient{
+				exists:   true,
+				desired:  map[string]interface{}{"image": "nginx:2"},
+				existing: map[string]interface{}{"image": "nginx:1"},
+			},
+			want: []ResourceDiff{
+				{Type: "Test", Name: "test-resource", Action: DiffChanged, Compared: true, Existing: "image: nginx:1\n", Desired: "image: nginx:2\n"},
+			},
+		},
+		{
+			name: "changed_by_hash",
+			client: testDiffableClient{
+				exists:   true,
+				desired:  map[string]interface{}{"image": "nginx"},
+				existing: map[string]interface{}{"image": "nginx"},
+			},
+			want: []ResourceDiff{
+				{Type: "Test", Name: "test-resource", Action: DiffChanged, Compared: true, Existing: "image: nginx\n", Desired: "image: nginx\n"},
+			},
+		},
+		{
+			name: "would_skip",
+			client: testDiffableClient{
+				exists:       true,
+				skipsUpgrade: true,
+				desired:      map[string]interface{}{"image": "nginx:2"},
+				existing:     map[string]interface{}{"image": "nginx:1"},
+			},
+			want: []ResourceDiff{
+				{Type: "Test", Name: "test-resource", Action: DiffWouldSkip, Compared: true, Existing: "image: nginx:1\n", Desired: "image: nginx:2\n"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converger := &ResourceClientConverger{
+				Client:   tt.client,
+				Children: tt.children,
+			}
+			result, err := converger.Diff()
+			assert.Ok(t, err)
+			assert.Equals(t, tt.want, result.Resources)
+		})
+	}
+}
+
+func TestResourceClientConverger_Diff_NotDiffable(t *testing.T) {
+	converger := &ResourceClientConverger{
+		Client: client.EmptyResourceClient,
+		Children: []Converger{
+			&ResourceClientConverger{Client: testResourceClient{exists: true}},
+			&ResourceClientConverger{Client: testResourceClient{exists: false}},
+		},
+	}
+	result, err := converger.Diff()
+	assert.Ok(t, err)
+	assert.Equals(t, []ResourceDiff{
+		{Type: "Test", Name: "test-resource", Action: DiffUnchanged},
+		{Type: "Test", Name: "test-resource", Action: DiffCreated},
+	}, result.Resources)
+}
+
+type testResourceClient struct {
+	name    string
+	exists  bool
+	changed bool
+	err     error
+}
+
+func (client testResourceClient) ID() (string, error) {
+	return "", nil
+}
+func (client testResourceClient) Type() string {
+	return "Test"
+}
+func (client testResourceClient) Name() (string, error) {
+	if client.name == "" {
+		return "test-resource", nil
+	}
+	return client.name, nil
+}
+func (client testResourceClient) Exists() (bool, error) {
+	return client.exists, nil
+}
+func (client testResourceClient) Create(dryRun bool) (bool, error) {
+	return client.changed, client.err
+}
+func (client testResourceClient) Upgrade(dryRun bool) (bool, error) {
+	return client.changed, client.err
+}
+func (client testResourceClient) Delete(dryRun bool) (bool, error) {
+	return false, nil
+}
+
+type testDiffableClient struct {
+	name         string
+	exists       bool
+	skipsUpgrade bool
+	unchanged    bool
+	desired      map[string]interface{}
+	existing     map[string]interface{}
+}
+
+func (client testDiffableClient) ID() (string, error) {
+	return "", nil
+}
+func (client testDiffableClient) Type() string {
+	return "Test"
+}
+func (client testDiffableClient) Name() (string, error) {
+	return testResourceClient{name: client.name}.Name()
+}
+func (client testDiffableClient) Exists() (bool, error) {
+	return client.exists, nil
+}
+func (client testDiffableClient) Create(dryRun bool) (bool, error) {
+	return false, nil
+}
+func (client testDiffableClient) Upgrade(dryRun bool) (bool, error) {
+	return false, nil
+}
+func (client testDiffableClient) Delete(dryRun bool) (bool, error) {
+	return false, nil
+}
+func (client testDiffableClient) Desired() (interface{}, error) {
+	return client.desired, nil
+}
+func (client testDiffableClient) Existing() (interface{}, error) {
+	return client.existing, nil
+}
+func (client testDiffableClient) SkipsUpgrade() bool {
+	return client.skipsUpgrade
+}
+func (client testDiffableClient) Unchanged() (bool, error) {
+	return client.unchanged, nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceError is the failure to converge a single resource
+type ResourceError struct {
+	Type      string
+	Namespace string
+	Name      string
+	Err       error
+}
+
+func (err ResourceError) Error() string {
+	name := err.Name
+	if err.Namespace != "" {
+		name = err.Namespace + "/" + name
+	}
+	return fmt.Sprintf("%s %s: %v", err.Type, name, err.Err)
+}
+
+// MultiError collects the failures of all resources of a converge
+type MultiError []error
+
+func (errs MultiError) Error() string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+	messages := make([]string, len(errs))
+	for index, err := range errs {
+		messages[index] = err.Error()
+	}
+	return fmt.Sprintf("%d resources failed:\n\t* %s", len(errs), strings.Join(messages, "\n\t* "))
+}
+
+// Append adds err to the collected failures, the failures of a MultiError are added one by one
+func (errs MultiError) Append(err error) MultiError {
+	if multiError, isMultiError := err.(MultiError); isMultiError {
+		return append(errs, multiError...)
+	}
+	return append(errs, err)
+}
+
+// ErrorOrNil returns nil if no failure was collected
+func (errs MultiError) ErrorOrNil() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
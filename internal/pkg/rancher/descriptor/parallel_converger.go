@@ -0,0 +1,345 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParallelNode is a converger together with the keys it is referenced by
+// and the keys of the nodes it depends on
+type ParallelNode struct {
+	Keys      []string
+	DependsOn []string
+	// After are the keys of nodes which are converged first, unlike DependsOn
+	// a failure of them does not skip this node
+	After []string
+	// Converger may be nil for nodes which only group dependencies
+	Converger Converger
+}
+
+// ParallelConverger converges children which do not depend on each other concurrently.
+// At most Parallelism children are converged at the same time, a Parallelism below 2
+// converges the children one after another.
+// Children created with NewParallelConverger are converged in topological order of their dependencies,
+// children which do not depend on each other keep their order.
+type ParallelConverger struct {
+	Children    []Converger
+	Parallelism int
+	// names, dependencies and dependents of the children by their index, set by NewParallelConverger
+	names        []string
+	dependencies [][]int
+	dependents   [][]int
+	// requiredBy are the dependents which are skipped if the child fails
+	requiredBy [][]int
+}
+
+type parallelChildResult struct {
+	index  int
+	result ConvergeResult
+	err    error
+}
+
+// NewParallelConverger creates a ParallelConverger of nodes depending on each other,
+// unknown dependencies and dependency cycles are rejected
+func NewParallelConverger(nodes []ParallelNode, parallelism int) (*ParallelConverger, error) {
+	converger := &ParallelConverger{
+		Children:     make([]Converger, len(nodes)),
+		Parallelism:  parallelism,
+		names:        make([]string, len(nodes)),
+		dependencies: make([][]int, len(nodes)),
+		dependents:   make([][]int, len(nodes)),
+		requiredBy:   make([][]int, len(nodes)),
+	}
+	indexes := make(map[string][]int)
+	for index, node := range nodes {
+		converger.Children[index] = node.Converger
+		converger.names[index] = node.name()
+		for _, key := range node.Keys {
+			indexes[key] = append(indexes[key], index)
+		}
+	}
+	for index, node := range nodes {
+		known := make(map[int]bool)
+		dependencies := append(append([]string{}, node.DependsOn...), node.After...)
+		for position, dependency := range dependencies {
+			dependencyIndexes, found := indexes[dependency]
+			if !found {
+				return nil, fmt.Errorf("Unknown dependency %s of %s", dependency, node.name())
+			}
+			for _, dependencyIndex := range dependencyIndexes {
+				if dependencyIndex == index || known[dependencyIndex] {
+					continue
+				}
+				known[dependencyIndex] = true
+				converger.dependencies[index] = append(converger.dependencies[index], dependencyIndex)
+				converger.dependents[dependencyIndex] = append(converger.dependents[dependencyIndex], index)
+				if position < len(node.DependsOn) {
+					converger.requiredBy[dependencyIndex] = append(converger.requiredBy[dependencyIndex], index)
+				}
+			}
+		}
+	}
+	if cycle := converger.findCycle(); cycle != nil {
+		names := make([]string, len(cycle))
+		for position, index := range cycle {
+			names[position] = converger.names[index]
+		}
+		return nil, fmt.Errorf("Dependency cycle: %s", strings.Join(names, " -> "))
+	}
+	return converger, nil
+}
+
+func (node ParallelNode) name() string {
+	if len(node.Keys) == 0 {
+		return "<unnamed>"
+	}
+	return node.Keys[0]
+}
+
+// findCycle returns the indexes of a dependency cycle or nil
+func (converger *ParallelConverger) findCycle() []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(converger.Children))
+	path := make([]int, 0)
+	var visit func(index int) []int
+	visit = func(index int) []int {
+		state[index] = visiting
+		path = append(path, index)
+		for _, dependency := range converger.dependenciesOf(index) {
+			switch state[dependency] {
+			case visiting:
+				for position, pathIndex := range path {
+					if pathIndex == dependency {
+						return append(append([]int{}, path[position:]...), dependency)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dependency); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[index] = visited
+		return nil
+	}
+	for index := range converger.Children {
+		if state[index] == unvisited {
+			if cycle := visit(index); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// dependenciesOf is empty for children of a ParallelConverger not created by NewParallelConverger
+func (converger *ParallelConverger) dependenciesOf(index int) []int {
+	if converger.dependencies == nil {
+		return nil
+	}
+	return converger.dependencies[index]
+}
+
+func (converger *ParallelConverger) dependentsOf(index int) []int {
+	if converger.dependents == nil {
+		return nil
+	}
+	return converger.dependents[index]
+}
+
+func (converger *ParallelConverger) requiredByOf(index int) []int {
+	if converger.requiredBy == nil {
+		return nil
+	}
+	return converger.requiredBy[index]
+}
+
+func (converger *ParallelConverger) nameOf(index int) string {
+	if converger.names == nil {
+		return fmt.Sprintf("child %d", index)
+	}
+	return converger.names[index]
+}
+
+// Converge converges all children and stops starting new children after the first failure
+func (converger *ParallelConverger) Converge(dryRun bool) (ConvergeResult, error) {
+	return converger.converge(dryRun, false)
+}
+
+// ConvergeAll converges all children even if some of them fail,
+// only the children depending on a failed child are skipped, children which are only
+// converged after a failed child still run
+func (converger *ParallelConverger) ConvergeAll(dryRun bool) (ConvergeResult, error) {
+	return converger.converge(dryRun, true)
+}
+
+func (converger *ParallelConverger) converge(dryRun, keepGoing bool) (result ConvergeResult, err error) {
+	parallelism := converger.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	var (
+		pending = converger.pendingDependencies()
+		ready   = converger.initiallyReady(pending)
+		results = make([]ConvergeResult, len(converger.Children))
+		errs    = make([]error, len(converger.Children))
+		skipped = make([]bool, len(converger.Children))
+		done    = make(chan parallelChildResult)
+		running = 0
+		failed  = false
+	)
+	// complete releases the dependents of a finished child, skipped dependents are
+	// completed once their dependencies finished to keep the order of their own dependents
+	var complete func(index int)
+	complete = func(index int) {
+		for _, dependent := range converger.dependentsOf(index) {
+			pending[dependent]--
+			if pending[dependent] != 0 {
+				continue
+			}
+			if skipped[dependent] {
+				complete(dependent)
+			} else {
+				ready = insertSorted(ready, dependent)
+			}
+		}
+	}
+	for {
+		for running < parallelism && len(ready) > 0 && !(failed && !keepGoing) {
+			index := ready[0]
+			ready = ready[1:]
+			running++
+			go func(index int) {
+				childResult := parallelChildResult{index: index}
+				if child := converger.Children[index]; child != nil {
+					childResult.result, childResult.err = convergeChild(child, dryRun, keepGoing)
+				}
+				done <- childResult
+			}(index)
+		}
+		if running == 0 {
+			break
+		}
+		childResult := <-done
+		running--
+		results[childResult.index] = childResult.result
+		if childResult.err != nil {
+			failed = true
+			errs[childResult.index] = childResult.err
+			for _, dependent := range converger.skipDependents(childResult.index, skipped) {
+				errs[dependent] = fmt.Errorf("Skipped %s, it depends on failed %s", converger.nameOf(dependent), converger.nameOf(childResult.index))
+			}
+		}
+		complete(childResult.index)
+	}
+
+	// results and failures are merged in the order the children are converged one after another
+	var multiError MultiError
+	for _, index := range converger.order() {
+		result.merge(results[index])
+		if errs[index] == nil {
+			continue
+		}
+		if !keepGoing {
+			return result, errs[index]
+		}
+		multiError = multiError.Append(errs[index])
+	}
+	return result, multiError.ErrorOrNil()
+}
+
+// skipDependents marks all children requiring index directly or indirectly as skipped
+// and returns the ones with a converger which were not skipped before
+func (converger *ParallelConverger) skipDependents(index int, skipped []bool) (result []int) {
+	for _, dependent := range converger.requiredByOf(index) {
+		if skipped[dependent] {
+			continue
+		}
+		skipped[dependent] = true
+		if converger.Children[dependent] != nil {
+			result = append(result, dependent)
+		}
+		result = append(result, converger.skipDependents(dependent, skipped)...)
+	}
+	return
+}
+
+func (converger *ParallelConverger) pendingDependencies() []int {
+	pending := make([]int, len(converger.Children))
+	for index := range converger.Children {
+		pending[index] = len(converger.dependenciesOf(index))
+	}
+	return pending
+}
+
+func (converger *ParallelConverger) initiallyReady(pending []int) []int {
+	ready := make([]int, 0)
+	for index := range converger.Children {
+		if pending[index] == 0 {
+			ready = append(ready, index)
+		}
+	}
+	return ready
+}
+
+// order is the topological order of the children, children which do not depend on each other keep their order
+func (converger *ParallelConverger) order() []int {
+	pending := converger.pendingDependencies()
+	ready := converger.initiallyReady(pending)
+	order := make([]int, 0, len(converger.Children))
+	for len(ready) > 0 {
+		index := ready[0]
+		ready = ready[1:]
+		order = append(order, index)
+		for _, dependent := range converger.dependentsOf(index) {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = insertSorted(ready, dependent)
+			}
+		}
+	}
+	return order
+}
+
+func insertSorted(indexes []int, index int) []int {
+	position := sort.SearchInts(indexes, index)
+	indexes = append(indexes, 0)
+	copy(indexes[position+1:], indexes[position:])
+	indexes[position] = index
+	return indexes
+}
+
+// Diff compares the children one after another in topological order
+func (converger *ParallelConverger) Diff() (DiffResult, error) {
+	return converger.diff(true)
+}
+
+func (converger *ParallelConverger) diff(parentExists bool) (result DiffResult, err error) {
+	children := make([]Converger, 0, len(converger.Children))
+	for _, index := range converger.order() {
+		if child := converger.Children[index]; child != nil {
+			children = append(children, child)
+		}
+	}
+	return diffChildren(children, parentExists)
+}
@@ -0,0 +1,249 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+)
+
+func TestParallelConverger_Converge(t *testing.T) {
+	tests := []struct {
+		name            string
+		parallelism     int
+		keepGoing       bool
+		failing         map[int]bool
+		wantConverged   int
+		wantConcurrency int
+		wantErr         string
+	}{
+		{
+			name:            "sequential",
+			parallelism:     0,
+			wantConverged:   6,
+			wantConcurrency: 1,
+		},
+		{
+			name:            "bounded",
+			parallelism:     3,
+			wantConverged:   6,
+			wantConcurrency: 3,
+		},
+		{
+			name:            "stop_after_failure",
+			parallelism:     1,
+			failing:         map[int]bool{1: true},
+			wantConverged:   2,
+			wantConcurrency: 1,
+			wantErr:         "child-1 failed",
+		},
+		{
+			name:            "keep_going",
+			parallelism:     2,
+			keepGoing:       true,
+			failing:         map[int]bool{1: true, 4: true},
+			wantConverged:   6,
+			wantConcurrency: 2,
+			wantErr:         "2 resources failed:\n\t* child-1 failed\n\t* child-4 failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &concurrencyTracker{}
+			converger := &ParallelConverger{Parallelism: tt.parallelism}
+			for index := 0; index < 6; index++ {
+				converger.Children = append(converger.Children, &trackedConverger{
+					name:    fmt.Sprintf("child-%d", index),
+					failing: tt.failing[index],
+					tracker: tracker,
+				})
+			}
+			var (
+				result ConvergeResult
+				err    error
+			)
+			if tt.keepGoing {
+				result, err = converger.ConvergeAll(false)
+			} else {
+				result, err = converger.Converge(false)
+			}
+			if tt.wantErr != "" {
+				assert.NotOk(t, err, tt.wantErr)
+			} else {
+				assert.Ok(t, err)
+			}
+			assert.Equals(t, tt.wantConverged, tracker.converged)
+			assert.Equals(t, tt.wantConcurrency, tracker.maxConcurrent)
+			// the results keep the order of the children
+			for index, created := range result.CreatedResources {
+				if index > 0 {
+					assert.Assert(t, result.CreatedResources[index-1].Name < created.Name, "unordered result %v", result.CreatedResources)
+				}
+			}
+		})
+	}
+}
+
+func TestParallelConverger_Order(t *testing.T) {
+	tracker := &concurrencyTracker{}
+	node := func(name string, dependsOn ...string) ParallelNode {
+		return ParallelNode{
+			Keys:      []string{name},
+			DependsOn: dependsOn,
+			Converger: &trackedConverger{name: name, tracker: tracker},
+		}
+	}
+	converger, err := NewParallelConverger([]ParallelNode{
+		node("app", "secret", "namespace"),
+		node("secret", "namespace"),
+		node("namespace"),
+		node("catalog"),
+	}, 1)
+	assert.Ok(t, err)
+	result, err := converger.Converge(false)
+	assert.Ok(t, err)
+	names := make([]string, 0)
+	for _, created := range result.CreatedResources {
+		names = append(names, created.Name)
+	}
+	assert.Equals(t, []string{"namespace", "secret", "app", "catalog"}, names)
+}
+
+func TestParallelConverger_SkipDependents(t *testing.T) {
+	tracker := &concurrencyTracker{}
+	converger, err := NewParallelConverger([]ParallelNode{
+		{Keys: []string{"namespace"}, Converger: &trackedConverger{name: "namespace", failing: true, tracker: tracker}},
+		{Keys: []string{"phase"}, DependsOn: []string{"namespace"}},
+		{Keys: []string{"secret"}, DependsOn: []string{"phase"}, Converger: &trackedConverger{name: "secret", tracker: tracker}},
+		{Keys: []string{"catalog"}, Converger: &trackedConverger{name: "catalog", tracker: tracker}},
+	}, 1)
+	assert.Ok(t, err)
+	_, err = converger.ConvergeAll(false)
+	assert.NotOk(t, err, "2 resources failed:\n\t* namespace failed\n\t* Skipped secret, it depends on failed namespace")
+	assert.Equals(t, 2, tracker.converged)
+}
+
+func TestParallelConverger_ConvergeAll_After(t *testing.T) {
+	tracker := &concurrencyTracker{}
+	node := func(name string, failing bool, dependsOn, after []string) ParallelNode {
+		return ParallelNode{
+			Keys:      []string{name},
+			DependsOn: dependsOn,
+			After:     after,
+			Converger: &trackedConverger{name: name, failing: failing, tracker: tracker},
+		}
+	}
+	// the phases of a project: a failed base resource must not skip unrelated resources of later phases
+	converger, err := NewParallelConverger([]ParallelNode{
+		node("Namespace/ns", false, nil, nil),
+		{Keys: []string{"base"}, After: []string{"Namespace/ns"}},
+		node("Certificate/c", true, nil, []string{"base"}),
+		node("Secret/s", false, []string{"Certificate/c"}, []string{"base"}),
+		node("ConfigMap/cm", false, nil, []string{"base"}),
+		{Keys: []string{"resources"}, After: []string{"base", "Certificate/c", "Secret/s", "ConfigMap/cm"}},
+		node("App/a", false, nil, []string{"resources"}),
+		node("App/b", false, []string{"Secret/s"}, []string{"resources"}),
+	}, 2)
+	assert.Ok(t, err)
+	result, err := converger.ConvergeAll(false)
+	assert.NotOk(t, err, "3 resources failed:\n\t* Certificate/c failed\n\t* Skipped Secret/s, it depends on failed Certificate/c\n\t* Skipped App/b, it depends on failed Certificate/c")
+	names := make([]string, 0)
+	for _, created := range result.CreatedResources {
+		names = append(names, created.Name)
+	}
+	assert.Equals(t, []string{"Namespace/ns", "ConfigMap/cm", "App/a"}, names)
+}
+
+func TestNewParallelConverger(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []ParallelNode
+		wantErr string
+	}{
+		{
+			name: "valid",
+			nodes: []ParallelNode{
+				{Keys: []string{"Namespace/ns"}},
+				{Keys: []string{"ConfigMap/cm", "ConfigMap/ns/cm"}, DependsOn: []string{"Namespace/ns", "ConfigMap/cm"}},
+				{Keys: []string{"App/app"}, DependsOn: []string{"ConfigMap/ns/cm"}},
+			},
+		},
+		{
+			name: "unknown_dependency",
+			nodes: []ParallelNode{
+				{Keys: []string{"App/app"}, DependsOn: []string{"Secret/missing"}},
+			},
+			wantErr: "Unknown dependency Secret/missing of App/app",
+		},
+		{
+			name: "cycle",
+			nodes: []ParallelNode{
+				{Keys: []string{"Namespace/ns"}},
+				{Keys: []string{"ConfigMap/cm"}, DependsOn: []string{"App/app"}},
+				{Keys: []string{"App/app"}, DependsOn: []string{"Namespace/ns", "ConfigMap/cm"}},
+			},
+			wantErr: "Dependency cycle: ConfigMap/cm -> App/app -> ConfigMap/cm",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParallelConverger(tt.nodes, 1)
+			if tt.wantErr != "" {
+				assert.NotOk(t, err, tt.wantErr)
+			} else {
+				assert.Ok(t, err)
+			}
+		})
+	}
+}
+
+type concurrencyTracker struct {
+	lock          sync.Mutex
+	concurrent    int
+	maxConcurrent int
+	converged     int
+}
+
+type trackedConverger struct {
+	name    string
+	failing bool
+	tracker *concurrencyTracker
+}
+
+func (converger *trackedConverger) Converge(bool) (result ConvergeResult, err error) {
+	tracker := converger.tracker
+	tracker.lock.Lock()
+	tracker.concurrent++
+	tracker.converged++
+	if tracker.concurrent > tracker.maxConcurrent {
+		tracker.maxConcurrent = tracker.concurrent
+	}
+	tracker.lock.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	tracker.lock.Lock()
+	tracker.concurrent--
+	tracker.lock.Unlock()
+	if converger.failing {
+		return result, fmt.Errorf("%s failed", converger.name)
+	}
+	result.CreatedResources = append(result.CreatedResources, ResourceDescriptor{Type: "Test", Name: converger.name})
+	return
+}
@@ -0,0 +1,62 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"time"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+)
+
+// DeleteCandidates deletes the candidates of a pruner and reports each of them as deleted
+func DeleteCandidates(candidates []client.ResourceClient, dryRun, keepGoing bool) (result ConvergeResult, err error) {
+	var errs MultiError
+	for _, candidate := range candidates {
+		var name string
+		if name, err = candidate.Name(); err != nil {
+			return
+		}
+		start := time.Now()
+		_, err = candidate.Delete(dryRun)
+		var report ResourceReport
+		report, err = NewResourceReport(candidate, name, ActionDeleted, start, err)
+		result.Reports = append(result.Reports, report)
+		if err != nil {
+			if !keepGoing {
+				return
+			}
+			errs = errs.Append(ResourceError{Type: report.Type, Namespace: report.Namespace, Name: name, Err: err})
+			continue
+		}
+		result.DeletedResources = append(result.DeletedResources, ResourceDescriptor{Type: candidate.Type(), Name: name})
+	}
+	return result, errs.ErrorOrNil()
+}
+
+// DiffCandidates lists the candidates of a pruner as deleted
+func DiffCandidates(candidates []client.ResourceClient) (result DiffResult, err error) {
+	for _, candidate := range candidates {
+		var name string
+		if name, err = candidate.Name(); err != nil {
+			return
+		}
+		result.Resources = append(result.Resources, ResourceDiff{
+			Type:   candidate.Type(),
+			Name:   name,
+			Action: DiffDeleted,
+		})
+	}
+	return
+}
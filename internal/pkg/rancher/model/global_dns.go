@@ -0,0 +1,99 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "fmt"
+
+// GlobalDNSProvider is a DNS provider the global DNS entries are published with
+type GlobalDNSProvider struct {
+	Name       string                    `yaml:"name"`
+	RootDomain string                    `yaml:"root_domain"`
+	Route53    *Route53ProviderConfig    `yaml:"route53,omitempty"`
+	Cloudflare *CloudflareProviderConfig `yaml:"cloudflare,omitempty"`
+	Alidns     *AlidnsProviderConfig     `yaml:"alidns,omitempty"`
+}
+
+// Route53ProviderConfig are the credentials and the zone of an AWS Route 53 provider
+type Route53ProviderConfig struct {
+	AccessKey       string `yaml:"access_key,omitempty"`
+	SecretKey       string `yaml:"secret_key,omitempty"`
+	Region          string `yaml:"region,omitempty"`
+	ZoneType        string `yaml:"zone_type,omitempty"`
+	RoleArn         string `yaml:"role_arn,omitempty"`
+	CredentialsPath string `yaml:"credentials_path,omitempty"`
+}
+
+// CloudflareProviderConfig are the credentials of a Cloudflare provider
+type CloudflareProviderConfig struct {
+	APIEmail     string `yaml:"api_email"`
+	APIKey       string `yaml:"api_key"`
+	ProxySetting *bool  `yaml:"proxy_setting,omitempty"`
+}
+
+// AlidnsProviderConfig are the credentials of an Alibaba Cloud DNS provider
+type AlidnsProviderConfig struct {
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
+}
+
+// GlobalDNSEntry publishes a FQDN for the ingresses of projects or of a multi cluster app
+type GlobalDNSEntry struct {
+	Name     string `yaml:"name"`
+	FQDN     string `yaml:"fqdn"`
+	Provider string `yaml:"provider"`
+	TTL      int64  `yaml:"ttl,omitempty"`
+	// Projects are the projects of the entry given as cluster:project
+	Projects        []string `yaml:"projects,omitempty"`
+	MultiClusterApp string   `yaml:"multi_cluster_app,omitempty"`
+}
+
+// ValidateGlobalDNSProvider checks that a global DNS provider declares exactly one provider config
+func ValidateGlobalDNSProvider(provider GlobalDNSProvider) error {
+	if provider.RootDomain == "" {
+		return fmt.Errorf("Global DNS provider %v requires root_domain", provider.Name)
+	}
+	configs := 0
+	if provider.Route53 != nil {
+		configs++
+	}
+	if provider.Cloudflare != nil {
+		configs++
+	}
+	if provider.Alidns != nil {
+		configs++
+	}
+	if configs != 1 {
+		return fmt.Errorf("Global DNS provider %v requires exactly one of route53, cloudflare or alidns", provider.Name)
+	}
+	return nil
+}
+
+// ValidateGlobalDNSEntry checks that a global DNS entry targets either projects or a multi cluster app
+func ValidateGlobalDNSEntry(entry GlobalDNSEntry) error {
+	switch {
+	case entry.FQDN == "":
+		return fmt.Errorf("Global DNS entry %v requires fqdn", entry.Name)
+	case entry.Provider == "":
+		return fmt.Errorf("Global DNS entry %v requires provider", entry.Name)
+	case (len(entry.Projects) == 0) == (entry.MultiClusterApp == ""):
+		return fmt.Errorf("Global DNS entry %v requires either projects or a multi_cluster_app", entry.Name)
+	}
+	for _, project := range entry.Projects {
+		if clusterName, projectName := SplitTarget(project); clusterName == "" || projectName == "" {
+			return fmt.Errorf("Project %v of global DNS entry %v must be given as cluster:project", project, entry.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiClusterAppDescriptor rolls out one app of a global catalog to projects of several clusters
+type MultiClusterAppDescriptor struct {
+	APIVersion string          `yaml:"api_version"`
+	Kind       string          `yaml:"kind"`
+	Metadata   RancherMetadata `yaml:"metadata"`
+	Spec       MultiClusterApp `yaml:"spec"`
+}
+
+// MultiClusterApp is an app of a global catalog installed into the projects of its targets
+type MultiClusterApp struct {
+	Name    string `yaml:"name"`
+	Catalog string `yaml:"catalog"`
+	Chart   string `yaml:"chart"`
+	Version string `yaml:"version"`
+	// Targets are the projects to install the app into given as cluster:project
+	Targets []string `yaml:"targets"`
+	// Answers are used for all targets
+	Answers map[string]string `yaml:"answers,omitempty"`
+	// TargetAnswers override the answers for a single cluster or project
+	TargetAnswers        []MultiClusterAppAnswer `yaml:"target_answers,omitempty"`
+	Roles                []string                `yaml:"roles,omitempty"`
+	RevisionHistoryLimit int64                   `yaml:"revision_history_limit,omitempty"`
+	RollingUpdate        *RollingUpdate          `yaml:"rolling_update,omitempty"`
+}
+
+// MultiClusterAppAnswer overrides answers for a target given as cluster or cluster:project
+type MultiClusterAppAnswer struct {
+	Target  string            `yaml:"target"`
+	Answers map[string]string `yaml:"answers"`
+}
+
+// RollingUpdate upgrades the targets of a multi cluster app in batches
+type RollingUpdate struct {
+	// BatchSize is the number of targets upgraded at once
+	BatchSize int64 `yaml:"batch_size,omitempty"`
+	// Interval is the number of seconds to wait between two batches
+	Interval int64 `yaml:"interval,omitempty"`
+}
+
+// SplitTarget splits a target given as cluster:project, the project is empty for a target given as cluster
+func SplitTarget(target string) (clusterName, projectName string) {
+	parts := strings.SplitN(target, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// ValidateMultiClusterApp checks the required fields and the targets of a multi cluster app
+func ValidateMultiClusterApp(app MultiClusterApp) error {
+	switch {
+	case app.Name == "":
+		return fmt.Errorf("Multi cluster app requires name")
+	case app.Catalog == "":
+		return fmt.Errorf("Multi cluster app %v requires catalog", app.Name)
+	case app.Chart == "":
+		return fmt.Errorf("Multi cluster app %v requires chart", app.Name)
+	case app.Version == "":
+		return fmt.Errorf("Multi cluster app %v requires version", app.Name)
+	}
+	if len(app.Targets) == 0 {
+		return fmt.Errorf("Multi cluster app %v requires targets", app.Name)
+	}
+	for _, target := range app.Targets {
+		if clusterName, projectName := SplitTarget(target); clusterName == "" || projectName == "" {
+			return fmt.Errorf("Target %v of multi cluster app %v must be given as cluster:project", target, app.Name)
+		}
+	}
+	for _, answer := range app.TargetAnswers {
+		if clusterName, _ := SplitTarget(answer.Target); clusterName == "" {
+			return fmt.Errorf("Target answers of multi cluster app %v require a target given as cluster or cluster:project", app.Name)
+		}
+	}
+	return nil
+}
@@ -16,34 +16,54 @@ package model
 
 // Types of Descriptors which are expected values of the field 'kind'
 const (
-	RancherKind      = "Rancher"
-	ClusterKind      = "Cluster"
-	ProjectKind      = "Project"
-	JobKind          = "Job"
-	CronJobKind      = "CronJob"
-	DeploymentKind   = "Deployment"
-	DaemonSetKind    = "DaemonSet"
-	StatefulSetKind  = "StatefulSet"
-	App              = "App"
-	Certificate      = "Certificate"
-	ClusterCatalog   = "ClusterCatalog"
-	Cluster          = "Cluster"
-	ConfigMap        = "ConfigMap"
-	DockerCredential = "DockerCredential"
-	Namespace        = "Namespace"
-	PersistentVolume = "PersistentVolume"
-	ProjectCatalog   = "ProjectCatalog"
-	RancherCatalog   = "RancherCatalog"
-	Secret           = "Secret"
-	StorageClass     = "StorageClass"
+	RancherKind              = "Rancher"
+	ClusterKind              = "Cluster"
+	ProjectKind              = "Project"
+	JobKind                  = "Job"
+	CronJobKind              = "CronJob"
+	DeploymentKind           = "Deployment"
+	DaemonSetKind            = "DaemonSet"
+	StatefulSetKind          = "StatefulSet"
+	ServiceKind              = "Service"
+	IngressKind              = "Ingress"
+	MultiClusterAppKind      = "MultiClusterApp"
+	App                      = "App"
+	Certificate              = "Certificate"
+	ClusterCatalog           = "ClusterCatalog"
+	Cluster                  = "Cluster"
+	ClusterMember            = "ClusterMember"
+	ConfigMap                = "ConfigMap"
+	DockerCredential         = "DockerCredential"
+	HorizontalPodAutoscaler  = "HorizontalPodAutoscaler"
+	Namespace                = "Namespace"
+	NetworkPolicy            = "NetworkPolicy"
+	PersistentVolume         = "PersistentVolume"
+	PersistentVolumeClaim    = "PersistentVolumeClaim"
+	ProjectCatalog           = "ProjectCatalog"
+	ProjectMember            = "ProjectMember"
+	RancherCatalog           = "RancherCatalog"
+	RancherGlobalDNSEntry    = "GlobalDNS"
+	RancherGlobalDNSProvider = "GlobalDNSProvider"
+	RancherGlobalRoleBinding = "GlobalRoleBinding"
+	RancherRoleTemplate      = "RoleTemplate"
+	RancherSetting           = "Setting"
+	RancherUser              = "User"
+	Secret                   = "Secret"
+	StorageClass             = "StorageClass"
 )
 
 // Rancher represents global members
 type Rancher struct {
-	APIVersion string          `yaml:"api_version"`
-	Kind       string          `yaml:"kind"`
-	Metadata   RancherMetadata `yaml:"metadata"`
-	Catalogs   []Catalog       `yaml:"catalogs,omitempty"`
+	APIVersion         string              `yaml:"api_version"`
+	Kind               string              `yaml:"kind"`
+	Metadata           RancherMetadata     `yaml:"metadata"`
+	Settings           []Setting           `yaml:"settings,omitempty"`
+	Catalogs           []Catalog           `yaml:"catalogs,omitempty"`
+	RoleTemplates      []RoleTemplate      `yaml:"role_templates,omitempty"`
+	Users              []User              `yaml:"users,omitempty"`
+	GlobalRoleBindings []GlobalRoleBinding `yaml:"global_role_bindings,omitempty"`
+	GlobalDNSProviders []GlobalDNSProvider `yaml:"global_dns_providers,omitempty"`
+	GlobalDNSEntries   []GlobalDNSEntry    `yaml:"global_dns_entries,omitempty"`
 }
 
 // RancherMetadata are global meta informations
@@ -62,3 +82,48 @@ type Catalog struct {
 	Username string `yaml:"username,omitempty"`
 	Password string `yaml:"password,omitempty"`
 }
+
+// Setting is a global setting of rancher e.g. server-url
+type Setting struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// RoleTemplate is a custom cluster or project role
+type RoleTemplate struct {
+	Name                  string       `yaml:"name"`
+	Context               string       `yaml:"context"`
+	Description           string       `yaml:"description,omitempty"`
+	RoleTemplateIDs       []string     `yaml:"role_template_ids,omitempty"`
+	Rules                 []PolicyRule `yaml:"rules,omitempty"`
+	Locked                bool         `yaml:"locked,omitempty"`
+	ClusterCreatorDefault bool         `yaml:"cluster_creator_default,omitempty"`
+	ProjectCreatorDefault bool         `yaml:"project_creator_default,omitempty"`
+}
+
+// PolicyRule grants verbs on resources to a role template
+type PolicyRule struct {
+	APIGroups       []string `yaml:"api_groups,omitempty"`
+	Resources       []string `yaml:"resources,omitempty"`
+	ResourceNames   []string `yaml:"resource_names,omitempty"`
+	NonResourceURLs []string `yaml:"non_resource_urls,omitempty"`
+	Verbs           []string `yaml:"verbs"`
+}
+
+// User is a local account of rancher
+type User struct {
+	Username           string `yaml:"username"`
+	Name               string `yaml:"name,omitempty"`
+	Description        string `yaml:"description,omitempty"`
+	Password           string `yaml:"password,omitempty"`
+	MustChangePassword bool   `yaml:"must_change_password,omitempty"`
+	Enabled            *bool  `yaml:"enabled,omitempty"`
+	// SetPassword sets the password of an existing user, the password is not compared with the existing one
+	SetPassword bool `yaml:"set_password,omitempty"`
+}
+
+// GlobalRoleBinding binds a global role e.g. admin or user to a user
+type GlobalRoleBinding struct {
+	User       string `yaml:"user"`
+	GlobalRole string `yaml:"global_role"`
+}
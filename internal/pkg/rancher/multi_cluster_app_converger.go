@@ -0,0 +1,37 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rancher
+
+import (
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/client"
+	"github.com/bitgrip/cattlectl/internal/pkg/rancher/descriptor"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+)
+
+// NewMultiClusterAppConverger creates a Converger for a given github.com/bitgrip/cattlectl/internal/pkg/rancher/model.MultiClusterAppDescriptor
+func NewMultiClusterAppConverger(multiClusterAppDescriptor rancherModel.MultiClusterAppDescriptor, rancherConfig client.RancherConfig) (descriptor.Converger, error) {
+	rancherClient, err := client.NewRancherClient(rancherConfig)
+	if err != nil {
+		return nil, err
+	}
+	multiClusterAppClient, err := rancherClient.MultiClusterApp(multiClusterAppDescriptor.Spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	multiClusterAppClient.SetData(multiClusterAppDescriptor.Spec)
+	return &descriptor.ResourceClientConverger{
+		Client: multiClusterAppClient,
+	}, nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rancher
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+)
+
+func TestMultiClusterAppParser_Parse(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		wantedTargets []string
+		wantErr       bool
+		wantedErr     string
+	}{
+		{
+			name: "targets",
+			data: `---
+api_version: v1.0
+kind: MultiClusterApp
+spec:
+  name: wordpress
+  catalog: library
+  chart: wordpress
+  version: 7.3.8
+  targets:
+  - production:web
+  - staging:web
+  target_answers:
+  - target: staging
+    answers:
+      replicaCount: "1"
+  rolling_update:
+    batch_size: 1
+    interval: 30
+`,
+			wantedTargets: []string{"production:web", "staging:web"},
+		},
+		{
+			name: "target-without-project",
+			data: `---
+api_version: v1.0
+kind: MultiClusterApp
+spec:
+  name: wordpress
+  catalog: library
+  chart: wordpress
+  version: 7.3.8
+  targets:
+  - production
+`,
+			wantErr:   true,
+			wantedErr: "Target production of multi cluster app wordpress must be given as cluster:project",
+		},
+		{
+			name: "missing-chart",
+			data: `---
+api_version: v1.0
+kind: MultiClusterApp
+spec:
+  name: wordpress
+  catalog: library
+  version: 7.3.8
+  targets:
+  - production:web
+`,
+			wantErr:   true,
+			wantedErr: "Multi cluster app wordpress requires chart",
+		},
+		{
+			name: "missing-name",
+			data: `---
+api_version: v1.0
+kind: MultiClusterApp
+spec:
+  catalog: library
+  chart: wordpress
+  version: 7.3.8
+  targets:
+  - production:web
+`,
+			wantErr:   true,
+			wantedErr: "Multi cluster app requires name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			multiClusterAppDescriptor := rancherModel.MultiClusterAppDescriptor{}
+			err := NewMultiClusterAppParser("multi-cluster-app.yaml", map[string]interface{}{}).Parse([]byte(tt.data), &multiClusterAppDescriptor)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Equals(t, tt.wantedErr, err.Error())
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wantedTargets, multiClusterAppDescriptor.Spec.Targets)
+			}
+		})
+	}
+}
@@ -27,6 +27,16 @@ func NewRancherConverger(rancher rancherModel.Rancher, rancherConfig client.Ranc
 		return nil, err
 	}
 	childConvergers := make([]descriptor.Converger, 0)
+	for _, setting := range rancher.Settings {
+		settingClient, err := rancherClient.Setting(setting.Name)
+		if err != nil {
+			return nil, err
+		}
+		settingClient.SetData(setting)
+		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
+			Client: settingClient,
+		})
+	}
 	for _, catalog := range rancher.Catalogs {
 		catalogClient, err := rancherClient.Catalog(catalog.Name)
 		if err != nil {
@@ -37,6 +47,57 @@ func NewRancherConverger(rancher rancherModel.Rancher, rancherConfig client.Ranc
 			Client: catalogClient,
 		})
 	}
+	for _, roleTemplate := range rancher.RoleTemplates {
+		roleTemplateClient, err := rancherClient.RoleTemplate(roleTemplate.Name)
+		if err != nil {
+			return nil, err
+		}
+		roleTemplateClient.SetData(roleTemplate)
+		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
+			Client: roleTemplateClient,
+		})
+	}
+	for _, user := range rancher.Users {
+		userClient, err := rancherClient.User(user.Username)
+		if err != nil {
+			return nil, err
+		}
+		userClient.SetData(user)
+		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
+			Client: userClient,
+		})
+	}
+	// global role bindings are applied last as they may bind the users declared above
+	for _, globalRoleBinding := range rancher.GlobalRoleBindings {
+		globalRoleBindingClient, err := rancherClient.GlobalRoleBinding(globalRoleBinding)
+		if err != nil {
+			return nil, err
+		}
+		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
+			Client: globalRoleBindingClient,
+		})
+	}
+	for _, provider := range rancher.GlobalDNSProviders {
+		providerClient, err := rancherClient.GlobalDNSProvider(provider.Name)
+		if err != nil {
+			return nil, err
+		}
+		providerClient.SetData(provider)
+		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
+			Client: providerClient,
+		})
+	}
+	// global DNS entries are applied after the providers they are published with
+	for _, entry := range rancher.GlobalDNSEntries {
+		entryClient, err := rancherClient.GlobalDNSEntry(entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		entryClient.SetData(entry)
+		childConvergers = append(childConvergers, &descriptor.ResourceClientConverger{
+			Client: entryClient,
+		})
+	}
 	return &descriptor.ResourceClientConverger{
 		Client:   client.EmptyResourceClient,
 		Children: childConvergers,
@@ -25,5 +25,33 @@ func NewRancherParser(descriptorFile string, values map[string]interface{}) desc
 	logger := logrus.WithFields(logrus.Fields{
 		"descriptor_file": descriptorFile,
 	})
-	return descriptor.NewLogginParser(rancherModel.RancherKind, logger, values)
+	return rancherParser{
+		Parser: descriptor.NewLogginParser(rancherModel.RancherKind, logger, values),
+	}
+}
+
+// rancherParser validates the global DNS providers and entries of the parsed rancher
+type rancherParser struct {
+	descriptor.Parser
+}
+
+func (parser rancherParser) Parse(data []byte, target interface{}) error {
+	if err := parser.Parser.Parse(data, target); err != nil {
+		return err
+	}
+	rancher, isRancher := target.(*rancherModel.Rancher)
+	if !isRancher {
+		return nil
+	}
+	for _, provider := range rancher.GlobalDNSProviders {
+		if err := rancherModel.ValidateGlobalDNSProvider(provider); err != nil {
+			return err
+		}
+	}
+	for _, entry := range rancher.GlobalDNSEntries {
+		if err := rancherModel.ValidateGlobalDNSEntry(entry); err != nil {
+			return err
+		}
+	}
+	return nil
 }
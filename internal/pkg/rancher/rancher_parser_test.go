@@ -0,0 +1,116 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by cataloglicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rancher
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	rancherModel "github.com/bitgrip/cattlectl/internal/pkg/rancher/model"
+)
+
+func TestRancherParser_Parse(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		wantedEntries  int
+		wantedProvider string
+		wantErr        bool
+		wantedErr      string
+	}{
+		{
+			name: "global-dns",
+			data: `---
+api_version: v1.0
+kind: Rancher
+global_dns_providers:
+- name: route53
+  root_domain: example.com
+  route53:
+    access_key: access
+    secret_key: secret
+global_dns_entries:
+- name: shop
+  fqdn: shop.example.com
+  provider: route53
+  projects:
+  - production:web
+- name: blog
+  fqdn: blog.example.com
+  provider: route53
+  multi_cluster_app: wordpress
+`,
+			wantedEntries:  2,
+			wantedProvider: "route53",
+		},
+		{
+			name: "provider-without-config",
+			data: `---
+api_version: v1.0
+kind: Rancher
+global_dns_providers:
+- name: route53
+  root_domain: example.com
+`,
+			wantErr:   true,
+			wantedErr: "Global DNS provider route53 requires exactly one of route53, cloudflare or alidns",
+		},
+		{
+			name: "entry-with-projects-and-multi-cluster-app",
+			data: `---
+api_version: v1.0
+kind: Rancher
+global_dns_entries:
+- name: shop
+  fqdn: shop.example.com
+  provider: route53
+  projects:
+  - production:web
+  multi_cluster_app: wordpress
+`,
+			wantErr:   true,
+			wantedErr: "Global DNS entry shop requires either projects or a multi_cluster_app",
+		},
+		{
+			name: "entry-project-without-cluster",
+			data: `---
+api_version: v1.0
+kind: Rancher
+global_dns_entries:
+- name: shop
+  fqdn: shop.example.com
+  provider: route53
+  projects:
+  - web
+`,
+			wantErr:   true,
+			wantedErr: "Project web of global DNS entry shop must be given as cluster:project",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rancher := rancherModel.Rancher{}
+			err := NewRancherParser("rancher.yaml", map[string]interface{}{}).Parse([]byte(tt.data), &rancher)
+			if tt.wantErr {
+				assert.NotOk(t, err, tt.wantedErr)
+				assert.Equals(t, tt.wantedErr, err.Error())
+			} else {
+				assert.Ok(t, err)
+				assert.Equals(t, tt.wantedEntries, len(rancher.GlobalDNSEntries))
+				assert.Equals(t, tt.wantedProvider, rancher.GlobalDNSProviders[0].Name)
+			}
+		})
+	}
+}
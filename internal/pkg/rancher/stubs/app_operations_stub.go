@@ -38,15 +38,20 @@ func CreateAppOperationsStub(tb testing.TB) *AppOperationsStub {
 			assert.FailInStub(tb, 2, "Unexpected call of ActionUpgrade")
 			return nil
 		},
+		DoActionRollback: func(resource *projectClient.App, input *projectClient.RollbackRevision) error {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionRollback")
+			return nil
+		},
 	}
 }
 
 // AppOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/project/v3/AppOperations
 type AppOperationsStub struct {
-	tb              testing.TB
-	DoList          func(opts *types.ListOpts) (*projectClient.AppCollection, error)
-	DoCreate        func(opts *projectClient.App) (*projectClient.App, error)
-	DoActionUpgrade func(resource *projectClient.App, input *projectClient.AppUpgradeConfig) error
+	tb               testing.TB
+	DoList           func(opts *types.ListOpts) (*projectClient.AppCollection, error)
+	DoCreate         func(opts *projectClient.App) (*projectClient.App, error)
+	DoActionUpgrade  func(resource *projectClient.App, input *projectClient.AppUpgradeConfig) error
+	DoActionRollback func(resource *projectClient.App, input *projectClient.RollbackRevision) error
 }
 
 // List implements github.com/rancher/types/client/project/v3/AppOperations.List(...)
@@ -85,8 +90,7 @@ func (stub AppOperationsStub) Delete(container *projectClient.App) error {
 
 // ActionRollback implements github.com/rancher/types/client/project/v3/AppOperations.ActionRollback(...)
 func (stub AppOperationsStub) ActionRollback(resource *projectClient.App, input *projectClient.RollbackRevision) error {
-	assert.FailInStub(stub.tb, 2, "Unexpected call of List")
-	return nil
+	return stub.DoActionRollback(resource, input)
 }
 
 // ActionUpgrade implements github.com/rancher/types/client/project/v3/AppOperations.ActionUpgrade(...)
@@ -34,14 +34,19 @@ func CreateClusterOperationsStub(tb testing.TB) *ClusterOperationsStub {
 			assert.FailInStub(tb, 2, "Unexpected call of Create")
 			return nil, nil
 		},
+		DoReplace: func(existing *managementClient.Cluster) (*managementClient.Cluster, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
 	}
 }
 
 // ClusterOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/ClusterOperations
 type ClusterOperationsStub struct {
-	tb       testing.TB
-	DoList   func(opts *types.ListOpts) (*managementClient.ClusterCollection, error)
-	DoCreate func(opts *managementClient.Cluster) (*managementClient.Cluster, error)
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*managementClient.ClusterCollection, error)
+	DoCreate  func(opts *managementClient.Cluster) (*managementClient.Cluster, error)
+	DoReplace func(existing *managementClient.Cluster) (*managementClient.Cluster, error)
 }
 
 // List implements github.com/rancher/types/client/management/v3/ClusterOperations.List(...)
@@ -65,8 +70,7 @@ func (stub ClusterOperationsStub) Update(existing *managementClient.Cluster, upd
 
 // Replace implements github.com/rancher/types/client/management/v3/ClusterOperations.Replace(...)
 func (stub ClusterOperationsStub) Replace(existing *managementClient.Cluster) (*managementClient.Cluster, error) {
-	assert.FailInStub(stub.tb, 2, "Unexpected call of Replace")
-	return nil, nil
+	return stub.DoReplace(existing)
 
 }
 
@@ -0,0 +1,95 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateClusterRegistrationTokenOperationsStub creates a stub of github.com/rancher/types/client/management/v3/ClusterRegistrationTokenOperations
+func CreateClusterRegistrationTokenOperationsStub(tb testing.TB) *ClusterRegistrationTokenOperationsStub {
+	return &ClusterRegistrationTokenOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.ClusterRegistrationTokenCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.ClusterRegistrationToken) (*rancherClient.ClusterRegistrationToken, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.ClusterRegistrationToken, updates interface{}) (*rancherClient.ClusterRegistrationToken, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.ClusterRegistrationToken) (*rancherClient.ClusterRegistrationToken, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.ClusterRegistrationToken, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.ClusterRegistrationToken) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// ClusterRegistrationTokenOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/ClusterRegistrationTokenOperations
+type ClusterRegistrationTokenOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*rancherClient.ClusterRegistrationTokenCollection, error)
+	DoCreate  func(opts *rancherClient.ClusterRegistrationToken) (*rancherClient.ClusterRegistrationToken, error)
+	DoUpdate  func(existing *rancherClient.ClusterRegistrationToken, updates interface{}) (*rancherClient.ClusterRegistrationToken, error)
+	DoReplace func(existing *rancherClient.ClusterRegistrationToken) (*rancherClient.ClusterRegistrationToken, error)
+	DoByID    func(id string) (*rancherClient.ClusterRegistrationToken, error)
+	DoDelete  func(container *rancherClient.ClusterRegistrationToken) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/ClusterRegistrationTokenOperations.List(...)
+func (stub ClusterRegistrationTokenOperationsStub) List(opts *types.ListOpts) (*rancherClient.ClusterRegistrationTokenCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/ClusterRegistrationTokenOperations.Create(...)
+func (stub ClusterRegistrationTokenOperationsStub) Create(opts *rancherClient.ClusterRegistrationToken) (*rancherClient.ClusterRegistrationToken, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/ClusterRegistrationTokenOperations.Update(...)
+func (stub ClusterRegistrationTokenOperationsStub) Update(existing *rancherClient.ClusterRegistrationToken, updates interface{}) (*rancherClient.ClusterRegistrationToken, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/ClusterRegistrationTokenOperations.Replace(...)
+func (stub ClusterRegistrationTokenOperationsStub) Replace(existing *rancherClient.ClusterRegistrationToken) (*rancherClient.ClusterRegistrationToken, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/ClusterRegistrationTokenOperations.ByID(...)
+func (stub ClusterRegistrationTokenOperationsStub) ByID(id string) (*rancherClient.ClusterRegistrationToken, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/ClusterRegistrationTokenOperations.Delete(...)
+func (stub ClusterRegistrationTokenOperationsStub) Delete(container *rancherClient.ClusterRegistrationToken) error {
+	return stub.DoDelete(container)
+}
@@ -0,0 +1,95 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateClusterRoleTemplateBindingOperationsStub creates a stub of github.com/rancher/types/client/management/v3/ClusterRoleTemplateBindingOperations
+func CreateClusterRoleTemplateBindingOperationsStub(tb testing.TB) *ClusterRoleTemplateBindingOperationsStub {
+	return &ClusterRoleTemplateBindingOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.ClusterRoleTemplateBindingCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.ClusterRoleTemplateBinding) (*rancherClient.ClusterRoleTemplateBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.ClusterRoleTemplateBinding, updates interface{}) (*rancherClient.ClusterRoleTemplateBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.ClusterRoleTemplateBinding) (*rancherClient.ClusterRoleTemplateBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.ClusterRoleTemplateBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.ClusterRoleTemplateBinding) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// ClusterRoleTemplateBindingOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/ClusterRoleTemplateBindingOperations
+type ClusterRoleTemplateBindingOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*rancherClient.ClusterRoleTemplateBindingCollection, error)
+	DoCreate  func(opts *rancherClient.ClusterRoleTemplateBinding) (*rancherClient.ClusterRoleTemplateBinding, error)
+	DoUpdate  func(existing *rancherClient.ClusterRoleTemplateBinding, updates interface{}) (*rancherClient.ClusterRoleTemplateBinding, error)
+	DoReplace func(existing *rancherClient.ClusterRoleTemplateBinding) (*rancherClient.ClusterRoleTemplateBinding, error)
+	DoByID    func(id string) (*rancherClient.ClusterRoleTemplateBinding, error)
+	DoDelete  func(container *rancherClient.ClusterRoleTemplateBinding) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/ClusterRoleTemplateBindingOperations.List(...)
+func (stub ClusterRoleTemplateBindingOperationsStub) List(opts *types.ListOpts) (*rancherClient.ClusterRoleTemplateBindingCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/ClusterRoleTemplateBindingOperations.Create(...)
+func (stub ClusterRoleTemplateBindingOperationsStub) Create(opts *rancherClient.ClusterRoleTemplateBinding) (*rancherClient.ClusterRoleTemplateBinding, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/ClusterRoleTemplateBindingOperations.Update(...)
+func (stub ClusterRoleTemplateBindingOperationsStub) Update(existing *rancherClient.ClusterRoleTemplateBinding, updates interface{}) (*rancherClient.ClusterRoleTemplateBinding, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/ClusterRoleTemplateBindingOperations.Replace(...)
+func (stub ClusterRoleTemplateBindingOperationsStub) Replace(existing *rancherClient.ClusterRoleTemplateBinding) (*rancherClient.ClusterRoleTemplateBinding, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/ClusterRoleTemplateBindingOperations.ByID(...)
+func (stub ClusterRoleTemplateBindingOperationsStub) ByID(id string) (*rancherClient.ClusterRoleTemplateBinding, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/ClusterRoleTemplateBindingOperations.Delete(...)
+func (stub ClusterRoleTemplateBindingOperationsStub) Delete(container *rancherClient.ClusterRoleTemplateBinding) error {
+	return stub.DoDelete(container)
+}
@@ -0,0 +1,115 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateGlobalDNSOperationsStub creates a stub of github.com/rancher/types/client/management/v3/GlobalDNSOperations
+func CreateGlobalDNSOperationsStub(tb testing.TB) *GlobalDNSOperationsStub {
+	return &GlobalDNSOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.GlobalDNSCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.GlobalDNS) (*rancherClient.GlobalDNS, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.GlobalDNS, updates interface{}) (*rancherClient.GlobalDNS, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.GlobalDNS) (*rancherClient.GlobalDNS, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.GlobalDNS, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.GlobalDNS) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+		DoActionAddProjects: func(resource *rancherClient.GlobalDNS, input *rancherClient.UpdateGlobalDNSTargetsInput) error {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionAddProjects")
+			return nil
+		},
+		DoActionRemoveProjects: func(resource *rancherClient.GlobalDNS, input *rancherClient.UpdateGlobalDNSTargetsInput) error {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionRemoveProjects")
+			return nil
+		},
+	}
+}
+
+// GlobalDNSOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/GlobalDNSOperations
+type GlobalDNSOperationsStub struct {
+	tb                     testing.TB
+	DoList                 func(opts *types.ListOpts) (*rancherClient.GlobalDNSCollection, error)
+	DoCreate               func(opts *rancherClient.GlobalDNS) (*rancherClient.GlobalDNS, error)
+	DoUpdate               func(existing *rancherClient.GlobalDNS, updates interface{}) (*rancherClient.GlobalDNS, error)
+	DoReplace              func(existing *rancherClient.GlobalDNS) (*rancherClient.GlobalDNS, error)
+	DoByID                 func(id string) (*rancherClient.GlobalDNS, error)
+	DoDelete               func(container *rancherClient.GlobalDNS) error
+	DoActionAddProjects    func(resource *rancherClient.GlobalDNS, input *rancherClient.UpdateGlobalDNSTargetsInput) error
+	DoActionRemoveProjects func(resource *rancherClient.GlobalDNS, input *rancherClient.UpdateGlobalDNSTargetsInput) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/GlobalDNSOperations.List(...)
+func (stub GlobalDNSOperationsStub) List(opts *types.ListOpts) (*rancherClient.GlobalDNSCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/GlobalDNSOperations.Create(...)
+func (stub GlobalDNSOperationsStub) Create(opts *rancherClient.GlobalDNS) (*rancherClient.GlobalDNS, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/GlobalDNSOperations.Update(...)
+func (stub GlobalDNSOperationsStub) Update(existing *rancherClient.GlobalDNS, updates interface{}) (*rancherClient.GlobalDNS, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/GlobalDNSOperations.Replace(...)
+func (stub GlobalDNSOperationsStub) Replace(existing *rancherClient.GlobalDNS) (*rancherClient.GlobalDNS, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/GlobalDNSOperations.ByID(...)
+func (stub GlobalDNSOperationsStub) ByID(id string) (*rancherClient.GlobalDNS, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/GlobalDNSOperations.Delete(...)
+func (stub GlobalDNSOperationsStub) Delete(container *rancherClient.GlobalDNS) error {
+	return stub.DoDelete(container)
+}
+
+// ActionAddProjects implements github.com/rancher/types/client/management/v3/GlobalDNSOperations.ActionAddProjects(...)
+func (stub GlobalDNSOperationsStub) ActionAddProjects(resource *rancherClient.GlobalDNS, input *rancherClient.UpdateGlobalDNSTargetsInput) error {
+	return stub.DoActionAddProjects(resource, input)
+}
+
+// ActionRemoveProjects implements github.com/rancher/types/client/management/v3/GlobalDNSOperations.ActionRemoveProjects(...)
+func (stub GlobalDNSOperationsStub) ActionRemoveProjects(resource *rancherClient.GlobalDNS, input *rancherClient.UpdateGlobalDNSTargetsInput) error {
+	return stub.DoActionRemoveProjects(resource, input)
+}
@@ -0,0 +1,95 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateGlobalDNSProviderOperationsStub creates a stub of github.com/rancher/types/client/management/v3/GlobalDNSProviderOperations
+func CreateGlobalDNSProviderOperationsStub(tb testing.TB) *GlobalDNSProviderOperationsStub {
+	return &GlobalDNSProviderOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.GlobalDNSProviderCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.GlobalDNSProvider) (*rancherClient.GlobalDNSProvider, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.GlobalDNSProvider, updates interface{}) (*rancherClient.GlobalDNSProvider, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.GlobalDNSProvider) (*rancherClient.GlobalDNSProvider, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.GlobalDNSProvider, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.GlobalDNSProvider) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// GlobalDNSProviderOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/GlobalDNSProviderOperations
+type GlobalDNSProviderOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*rancherClient.GlobalDNSProviderCollection, error)
+	DoCreate  func(opts *rancherClient.GlobalDNSProvider) (*rancherClient.GlobalDNSProvider, error)
+	DoUpdate  func(existing *rancherClient.GlobalDNSProvider, updates interface{}) (*rancherClient.GlobalDNSProvider, error)
+	DoReplace func(existing *rancherClient.GlobalDNSProvider) (*rancherClient.GlobalDNSProvider, error)
+	DoByID    func(id string) (*rancherClient.GlobalDNSProvider, error)
+	DoDelete  func(container *rancherClient.GlobalDNSProvider) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/GlobalDNSProviderOperations.List(...)
+func (stub GlobalDNSProviderOperationsStub) List(opts *types.ListOpts) (*rancherClient.GlobalDNSProviderCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/GlobalDNSProviderOperations.Create(...)
+func (stub GlobalDNSProviderOperationsStub) Create(opts *rancherClient.GlobalDNSProvider) (*rancherClient.GlobalDNSProvider, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/GlobalDNSProviderOperations.Update(...)
+func (stub GlobalDNSProviderOperationsStub) Update(existing *rancherClient.GlobalDNSProvider, updates interface{}) (*rancherClient.GlobalDNSProvider, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/GlobalDNSProviderOperations.Replace(...)
+func (stub GlobalDNSProviderOperationsStub) Replace(existing *rancherClient.GlobalDNSProvider) (*rancherClient.GlobalDNSProvider, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/GlobalDNSProviderOperations.ByID(...)
+func (stub GlobalDNSProviderOperationsStub) ByID(id string) (*rancherClient.GlobalDNSProvider, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/GlobalDNSProviderOperations.Delete(...)
+func (stub GlobalDNSProviderOperationsStub) Delete(container *rancherClient.GlobalDNSProvider) error {
+	return stub.DoDelete(container)
+}
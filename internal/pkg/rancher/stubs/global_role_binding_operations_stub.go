@@ -0,0 +1,95 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateGlobalRoleBindingOperationsStub creates a stub of github.com/rancher/types/client/management/v3/GlobalRoleBindingOperations
+func CreateGlobalRoleBindingOperationsStub(tb testing.TB) *GlobalRoleBindingOperationsStub {
+	return &GlobalRoleBindingOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.GlobalRoleBindingCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.GlobalRoleBinding) (*rancherClient.GlobalRoleBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.GlobalRoleBinding, updates interface{}) (*rancherClient.GlobalRoleBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.GlobalRoleBinding) (*rancherClient.GlobalRoleBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.GlobalRoleBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.GlobalRoleBinding) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// GlobalRoleBindingOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/GlobalRoleBindingOperations
+type GlobalRoleBindingOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*rancherClient.GlobalRoleBindingCollection, error)
+	DoCreate  func(opts *rancherClient.GlobalRoleBinding) (*rancherClient.GlobalRoleBinding, error)
+	DoUpdate  func(existing *rancherClient.GlobalRoleBinding, updates interface{}) (*rancherClient.GlobalRoleBinding, error)
+	DoReplace func(existing *rancherClient.GlobalRoleBinding) (*rancherClient.GlobalRoleBinding, error)
+	DoByID    func(id string) (*rancherClient.GlobalRoleBinding, error)
+	DoDelete  func(container *rancherClient.GlobalRoleBinding) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/GlobalRoleBindingOperations.List(...)
+func (stub GlobalRoleBindingOperationsStub) List(opts *types.ListOpts) (*rancherClient.GlobalRoleBindingCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/GlobalRoleBindingOperations.Create(...)
+func (stub GlobalRoleBindingOperationsStub) Create(opts *rancherClient.GlobalRoleBinding) (*rancherClient.GlobalRoleBinding, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/GlobalRoleBindingOperations.Update(...)
+func (stub GlobalRoleBindingOperationsStub) Update(existing *rancherClient.GlobalRoleBinding, updates interface{}) (*rancherClient.GlobalRoleBinding, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/GlobalRoleBindingOperations.Replace(...)
+func (stub GlobalRoleBindingOperationsStub) Replace(existing *rancherClient.GlobalRoleBinding) (*rancherClient.GlobalRoleBinding, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/GlobalRoleBindingOperations.ByID(...)
+func (stub GlobalRoleBindingOperationsStub) ByID(id string) (*rancherClient.GlobalRoleBinding, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/GlobalRoleBindingOperations.Delete(...)
+func (stub GlobalRoleBindingOperationsStub) Delete(container *rancherClient.GlobalRoleBinding) error {
+	return stub.DoDelete(container)
+}
@@ -0,0 +1,95 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	projectClient "github.com/rancher/types/client/project/v3"
+)
+
+// CreateHorizontalPodAutoscalerOperationsStub creates a stub of github.com/rancher/types/client/project/v3/HorizontalPodAutoscalerOperations
+func CreateHorizontalPodAutoscalerOperationsStub(tb testing.TB) *HorizontalPodAutoscalerOperationsStub {
+	return &HorizontalPodAutoscalerOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*projectClient.HorizontalPodAutoscalerCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *projectClient.HorizontalPodAutoscaler) (*projectClient.HorizontalPodAutoscaler, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *projectClient.HorizontalPodAutoscaler, updates interface{}) (*projectClient.HorizontalPodAutoscaler, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *projectClient.HorizontalPodAutoscaler) (*projectClient.HorizontalPodAutoscaler, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*projectClient.HorizontalPodAutoscaler, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *projectClient.HorizontalPodAutoscaler) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// HorizontalPodAutoscalerOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/project/v3/HorizontalPodAutoscalerOperations
+type HorizontalPodAutoscalerOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*projectClient.HorizontalPodAutoscalerCollection, error)
+	DoCreate  func(opts *projectClient.HorizontalPodAutoscaler) (*projectClient.HorizontalPodAutoscaler, error)
+	DoUpdate  func(existing *projectClient.HorizontalPodAutoscaler, updates interface{}) (*projectClient.HorizontalPodAutoscaler, error)
+	DoReplace func(existing *projectClient.HorizontalPodAutoscaler) (*projectClient.HorizontalPodAutoscaler, error)
+	DoByID    func(id string) (*projectClient.HorizontalPodAutoscaler, error)
+	DoDelete  func(container *projectClient.HorizontalPodAutoscaler) error
+}
+
+// List implements github.com/rancher/types/client/project/v3/HorizontalPodAutoscalerOperations.List(...)
+func (stub HorizontalPodAutoscalerOperationsStub) List(opts *types.ListOpts) (*projectClient.HorizontalPodAutoscalerCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/project/v3/HorizontalPodAutoscalerOperations.Create(...)
+func (stub HorizontalPodAutoscalerOperationsStub) Create(opts *projectClient.HorizontalPodAutoscaler) (*projectClient.HorizontalPodAutoscaler, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/project/v3/HorizontalPodAutoscalerOperations.Update(...)
+func (stub HorizontalPodAutoscalerOperationsStub) Update(existing *projectClient.HorizontalPodAutoscaler, updates interface{}) (*projectClient.HorizontalPodAutoscaler, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/project/v3/HorizontalPodAutoscalerOperations.Replace(...)
+func (stub HorizontalPodAutoscalerOperationsStub) Replace(existing *projectClient.HorizontalPodAutoscaler) (*projectClient.HorizontalPodAutoscaler, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/project/v3/HorizontalPodAutoscalerOperations.ByID(...)
+func (stub HorizontalPodAutoscalerOperationsStub) ByID(id string) (*projectClient.HorizontalPodAutoscaler, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/project/v3/HorizontalPodAutoscalerOperations.Delete(...)
+func (stub HorizontalPodAutoscalerOperationsStub) Delete(container *projectClient.HorizontalPodAutoscaler) error {
+	return stub.DoDelete(container)
+}
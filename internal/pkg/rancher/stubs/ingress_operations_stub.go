@@ -0,0 +1,95 @@
+// Copyright © 2018 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	projectClient "github.com/rancher/types/client/project/v3"
+)
+
+// CreateIngressOperationsStub creates a stub of github.com/rancher/types/client/project/v3/IngressOperations
+func CreateIngressOperationsStub(tb testing.TB) *IngressOperationsStub {
+	return &IngressOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*projectClient.IngressCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *projectClient.Ingress) (*projectClient.Ingress, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *projectClient.Ingress, updates interface{}) (*projectClient.Ingress, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *projectClient.Ingress) (*projectClient.Ingress, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*projectClient.Ingress, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *projectClient.Ingress) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// IngressOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/project/v3/IngressOperations
+type IngressOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*projectClient.IngressCollection, error)
+	DoCreate  func(opts *projectClient.Ingress) (*projectClient.Ingress, error)
+	DoUpdate  func(existing *projectClient.Ingress, updates interface{}) (*projectClient.Ingress, error)
+	DoReplace func(existing *projectClient.Ingress) (*projectClient.Ingress, error)
+	DoByID    func(id string) (*projectClient.Ingress, error)
+	DoDelete  func(container *projectClient.Ingress) error
+}
+
+// List implements github.com/rancher/types/client/project/v3/IngressOperations.List(...)
+func (stub IngressOperationsStub) List(opts *types.ListOpts) (*projectClient.IngressCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/project/v3/IngressOperations.Create(...)
+func (stub IngressOperationsStub) Create(opts *projectClient.Ingress) (*projectClient.Ingress, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/project/v3/IngressOperations.Update(...)
+func (stub IngressOperationsStub) Update(existing *projectClient.Ingress, updates interface{}) (*projectClient.Ingress, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/project/v3/IngressOperations.Replace(...)
+func (stub IngressOperationsStub) Replace(existing *projectClient.Ingress) (*projectClient.Ingress, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/project/v3/IngressOperations.ByID(...)
+func (stub IngressOperationsStub) ByID(id string) (*projectClient.Ingress, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/project/v3/IngressOperations.Delete(...)
+func (stub IngressOperationsStub) Delete(container *projectClient.Ingress) error {
+	return stub.DoDelete(container)
+}
@@ -42,6 +42,10 @@ func CreateJobOperationsStub(tb testing.TB) *JobOperationsStub {
 			assert.FailInStub(tb, 2, "Unexpected call of List")
 			return nil, nil
 		},
+		DoDelete: func(container *projectClient.Job) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
 	}
 }
 
@@ -52,6 +56,7 @@ type JobOperationsStub struct {
 	DoCreate  func(opts *projectClient.Job) (*projectClient.Job, error)
 	DoUpdate  func(existing *projectClient.Job, updates interface{}) (*projectClient.Job, error)
 	DoReplace func(existing *projectClient.Job) (*projectClient.Job, error)
+	DoDelete  func(container *projectClient.Job) error
 }
 
 // List implements github.com/rancher/types/client/project/v3/JobOperations.List(...)
@@ -82,6 +87,5 @@ func (stub JobOperationsStub) ByID(id string) (*projectClient.Job, error) {
 
 // Delete implements github.com/rancher/types/client/project/v3/JobOperations.Delete(...)
 func (stub JobOperationsStub) Delete(container *projectClient.Job) error {
-	assert.FailInStub(stub.tb, 2, "Unexpected call of List")
-	return nil
+	return stub.DoDelete(container)
 }
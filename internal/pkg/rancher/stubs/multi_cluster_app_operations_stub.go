@@ -0,0 +1,125 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateMultiClusterAppOperationsStub creates a stub of github.com/rancher/types/client/management/v3/MultiClusterAppOperations
+func CreateMultiClusterAppOperationsStub(tb testing.TB) *MultiClusterAppOperationsStub {
+	return &MultiClusterAppOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.MultiClusterAppCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.MultiClusterApp) (*rancherClient.MultiClusterApp, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.MultiClusterApp, updates interface{}) (*rancherClient.MultiClusterApp, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.MultiClusterApp) (*rancherClient.MultiClusterApp, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.MultiClusterApp, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.MultiClusterApp) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+		DoActionAddProjects: func(resource *rancherClient.MultiClusterApp, input *rancherClient.UpdateMultiClusterAppTargetsInput) error {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionAddProjects")
+			return nil
+		},
+		DoActionRemoveProjects: func(resource *rancherClient.MultiClusterApp, input *rancherClient.UpdateMultiClusterAppTargetsInput) error {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionRemoveProjects")
+			return nil
+		},
+		DoActionRollback: func(resource *rancherClient.MultiClusterApp, input *rancherClient.MultiClusterAppRollbackInput) error {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionRollback")
+			return nil
+		},
+	}
+}
+
+// MultiClusterAppOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/MultiClusterAppOperations
+type MultiClusterAppOperationsStub struct {
+	tb                     testing.TB
+	DoList                 func(opts *types.ListOpts) (*rancherClient.MultiClusterAppCollection, error)
+	DoCreate               func(opts *rancherClient.MultiClusterApp) (*rancherClient.MultiClusterApp, error)
+	DoUpdate               func(existing *rancherClient.MultiClusterApp, updates interface{}) (*rancherClient.MultiClusterApp, error)
+	DoReplace              func(existing *rancherClient.MultiClusterApp) (*rancherClient.MultiClusterApp, error)
+	DoByID                 func(id string) (*rancherClient.MultiClusterApp, error)
+	DoDelete               func(container *rancherClient.MultiClusterApp) error
+	DoActionAddProjects    func(resource *rancherClient.MultiClusterApp, input *rancherClient.UpdateMultiClusterAppTargetsInput) error
+	DoActionRemoveProjects func(resource *rancherClient.MultiClusterApp, input *rancherClient.UpdateMultiClusterAppTargetsInput) error
+	DoActionRollback       func(resource *rancherClient.MultiClusterApp, input *rancherClient.MultiClusterAppRollbackInput) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/MultiClusterAppOperations.List(...)
+func (stub MultiClusterAppOperationsStub) List(opts *types.ListOpts) (*rancherClient.MultiClusterAppCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/MultiClusterAppOperations.Create(...)
+func (stub MultiClusterAppOperationsStub) Create(opts *rancherClient.MultiClusterApp) (*rancherClient.MultiClusterApp, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/MultiClusterAppOperations.Update(...)
+func (stub MultiClusterAppOperationsStub) Update(existing *rancherClient.MultiClusterApp, updates interface{}) (*rancherClient.MultiClusterApp, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/MultiClusterAppOperations.Replace(...)
+func (stub MultiClusterAppOperationsStub) Replace(existing *rancherClient.MultiClusterApp) (*rancherClient.MultiClusterApp, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/MultiClusterAppOperations.ByID(...)
+func (stub MultiClusterAppOperationsStub) ByID(id string) (*rancherClient.MultiClusterApp, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/MultiClusterAppOperations.Delete(...)
+func (stub MultiClusterAppOperationsStub) Delete(container *rancherClient.MultiClusterApp) error {
+	return stub.DoDelete(container)
+}
+
+// ActionAddProjects implements github.com/rancher/types/client/management/v3/MultiClusterAppOperations.ActionAddProjects(...)
+func (stub MultiClusterAppOperationsStub) ActionAddProjects(resource *rancherClient.MultiClusterApp, input *rancherClient.UpdateMultiClusterAppTargetsInput) error {
+	return stub.DoActionAddProjects(resource, input)
+}
+
+// ActionRemoveProjects implements github.com/rancher/types/client/management/v3/MultiClusterAppOperations.ActionRemoveProjects(...)
+func (stub MultiClusterAppOperationsStub) ActionRemoveProjects(resource *rancherClient.MultiClusterApp, input *rancherClient.UpdateMultiClusterAppTargetsInput) error {
+	return stub.DoActionRemoveProjects(resource, input)
+}
+
+// ActionRollback implements github.com/rancher/types/client/management/v3/MultiClusterAppOperations.ActionRollback(...)
+func (stub MultiClusterAppOperationsStub) ActionRollback(resource *rancherClient.MultiClusterApp, input *rancherClient.MultiClusterAppRollbackInput) error {
+	return stub.DoActionRollback(resource, input)
+}
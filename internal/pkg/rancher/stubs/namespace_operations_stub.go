@@ -34,14 +34,24 @@ func CreateNamespaceOperationsStub(tb testing.TB) *NamespaceOperationsStub {
 			assert.FailInStub(tb, 2, "Unexpected call of Create")
 			return nil, nil
 		},
+		DoReplace: func(existing *clusterClient.Namespace) (*clusterClient.Namespace, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoActionMove: func(resource *clusterClient.Namespace, input *clusterClient.NamespaceMove) error {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionMove")
+			return nil
+		},
 	}
 }
 
 // NamespaceOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/cluster/v3/NamespaceOperations
 type NamespaceOperationsStub struct {
-	tb       testing.TB
-	DoList   func(opts *types.ListOpts) (*clusterClient.NamespaceCollection, error)
-	DoCreate func(opts *clusterClient.Namespace) (*clusterClient.Namespace, error)
+	tb           testing.TB
+	DoList       func(opts *types.ListOpts) (*clusterClient.NamespaceCollection, error)
+	DoCreate     func(opts *clusterClient.Namespace) (*clusterClient.Namespace, error)
+	DoReplace    func(existing *clusterClient.Namespace) (*clusterClient.Namespace, error)
+	DoActionMove func(resource *clusterClient.Namespace, input *clusterClient.NamespaceMove) error
 }
 
 // List implements github.com/rancher/types/client/cluster/v3/NamespaceOperations.List(...)
@@ -62,8 +72,7 @@ func (stub NamespaceOperationsStub) Update(existing *clusterClient.Namespace, up
 
 // Replace implements github.com/rancher/types/client/cluster/v3/NamespaceOperations.Replace(...)
 func (stub NamespaceOperationsStub) Replace(existing *clusterClient.Namespace) (*clusterClient.Namespace, error) {
-	assert.FailInStub(stub.tb, 2, "Unexpected call of List")
-	return nil, nil
+	return stub.DoReplace(existing)
 }
 
 // ByID implements github.com/rancher/types/client/cluster/v3/NamespaceOperations.ByID(...)
@@ -80,6 +89,5 @@ func (stub NamespaceOperationsStub) Delete(container *clusterClient.Namespace) e
 
 // ActionMove implements github.com/rancher/types/client/cluster/v3/NamespaceOperations.ActionMove(...)
 func (stub NamespaceOperationsStub) ActionMove(resource *clusterClient.Namespace, input *clusterClient.NamespaceMove) error {
-	assert.FailInStub(stub.tb, 2, "Unexpected call of List")
-	return nil
+	return stub.DoActionMove(resource, input)
 }
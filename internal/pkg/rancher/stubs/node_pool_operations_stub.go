@@ -0,0 +1,95 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateNodePoolOperationsStub creates a stub of github.com/rancher/types/client/management/v3/NodePoolOperations
+func CreateNodePoolOperationsStub(tb testing.TB) *NodePoolOperationsStub {
+	return &NodePoolOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.NodePoolCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.NodePool) (*rancherClient.NodePool, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.NodePool, updates interface{}) (*rancherClient.NodePool, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.NodePool) (*rancherClient.NodePool, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.NodePool, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.NodePool) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// NodePoolOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/NodePoolOperations
+type NodePoolOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*rancherClient.NodePoolCollection, error)
+	DoCreate  func(opts *rancherClient.NodePool) (*rancherClient.NodePool, error)
+	DoUpdate  func(existing *rancherClient.NodePool, updates interface{}) (*rancherClient.NodePool, error)
+	DoReplace func(existing *rancherClient.NodePool) (*rancherClient.NodePool, error)
+	DoByID    func(id string) (*rancherClient.NodePool, error)
+	DoDelete  func(container *rancherClient.NodePool) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/NodePoolOperations.List(...)
+func (stub NodePoolOperationsStub) List(opts *types.ListOpts) (*rancherClient.NodePoolCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/NodePoolOperations.Create(...)
+func (stub NodePoolOperationsStub) Create(opts *rancherClient.NodePool) (*rancherClient.NodePool, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/NodePoolOperations.Update(...)
+func (stub NodePoolOperationsStub) Update(existing *rancherClient.NodePool, updates interface{}) (*rancherClient.NodePool, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/NodePoolOperations.Replace(...)
+func (stub NodePoolOperationsStub) Replace(existing *rancherClient.NodePool) (*rancherClient.NodePool, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/NodePoolOperations.ByID(...)
+func (stub NodePoolOperationsStub) ByID(id string) (*rancherClient.NodePool, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/NodePoolOperations.Delete(...)
+func (stub NodePoolOperationsStub) Delete(container *rancherClient.NodePool) error {
+	return stub.DoDelete(container)
+}
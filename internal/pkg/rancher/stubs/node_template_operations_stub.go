@@ -0,0 +1,95 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateNodeTemplateOperationsStub creates a stub of github.com/rancher/types/client/management/v3/NodeTemplateOperations
+func CreateNodeTemplateOperationsStub(tb testing.TB) *NodeTemplateOperationsStub {
+	return &NodeTemplateOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.NodeTemplateCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.NodeTemplate) (*rancherClient.NodeTemplate, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.NodeTemplate, updates interface{}) (*rancherClient.NodeTemplate, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.NodeTemplate) (*rancherClient.NodeTemplate, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.NodeTemplate, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.NodeTemplate) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// NodeTemplateOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/NodeTemplateOperations
+type NodeTemplateOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*rancherClient.NodeTemplateCollection, error)
+	DoCreate  func(opts *rancherClient.NodeTemplate) (*rancherClient.NodeTemplate, error)
+	DoUpdate  func(existing *rancherClient.NodeTemplate, updates interface{}) (*rancherClient.NodeTemplate, error)
+	DoReplace func(existing *rancherClient.NodeTemplate) (*rancherClient.NodeTemplate, error)
+	DoByID    func(id string) (*rancherClient.NodeTemplate, error)
+	DoDelete  func(container *rancherClient.NodeTemplate) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/NodeTemplateOperations.List(...)
+func (stub NodeTemplateOperationsStub) List(opts *types.ListOpts) (*rancherClient.NodeTemplateCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/NodeTemplateOperations.Create(...)
+func (stub NodeTemplateOperationsStub) Create(opts *rancherClient.NodeTemplate) (*rancherClient.NodeTemplate, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/NodeTemplateOperations.Update(...)
+func (stub NodeTemplateOperationsStub) Update(existing *rancherClient.NodeTemplate, updates interface{}) (*rancherClient.NodeTemplate, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/NodeTemplateOperations.Replace(...)
+func (stub NodeTemplateOperationsStub) Replace(existing *rancherClient.NodeTemplate) (*rancherClient.NodeTemplate, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/NodeTemplateOperations.ByID(...)
+func (stub NodeTemplateOperationsStub) ByID(id string) (*rancherClient.NodeTemplate, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/NodeTemplateOperations.Delete(...)
+func (stub NodeTemplateOperationsStub) Delete(container *rancherClient.NodeTemplate) error {
+	return stub.DoDelete(container)
+}
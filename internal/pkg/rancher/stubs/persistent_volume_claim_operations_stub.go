@@ -0,0 +1,95 @@
+// Copyright © 2018 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	projectClient "github.com/rancher/types/client/project/v3"
+)
+
+// CreatePersistentVolumeClaimOperationsStub creates a stub of github.com/rancher/types/client/project/v3/PersistentVolumeClaimOperations
+func CreatePersistentVolumeClaimOperationsStub(tb testing.TB) *PersistentVolumeClaimOperationsStub {
+	return &PersistentVolumeClaimOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*projectClient.PersistentVolumeClaimCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *projectClient.PersistentVolumeClaim) (*projectClient.PersistentVolumeClaim, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *projectClient.PersistentVolumeClaim, updates interface{}) (*projectClient.PersistentVolumeClaim, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *projectClient.PersistentVolumeClaim) (*projectClient.PersistentVolumeClaim, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*projectClient.PersistentVolumeClaim, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *projectClient.PersistentVolumeClaim) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// PersistentVolumeClaimOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/project/v3/PersistentVolumeClaimOperations
+type PersistentVolumeClaimOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*projectClient.PersistentVolumeClaimCollection, error)
+	DoCreate  func(opts *projectClient.PersistentVolumeClaim) (*projectClient.PersistentVolumeClaim, error)
+	DoUpdate  func(existing *projectClient.PersistentVolumeClaim, updates interface{}) (*projectClient.PersistentVolumeClaim, error)
+	DoReplace func(existing *projectClient.PersistentVolumeClaim) (*projectClient.PersistentVolumeClaim, error)
+	DoByID    func(id string) (*projectClient.PersistentVolumeClaim, error)
+	DoDelete  func(container *projectClient.PersistentVolumeClaim) error
+}
+
+// List implements github.com/rancher/types/client/project/v3/PersistentVolumeClaimOperations.List(...)
+func (stub PersistentVolumeClaimOperationsStub) List(opts *types.ListOpts) (*projectClient.PersistentVolumeClaimCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/project/v3/PersistentVolumeClaimOperations.Create(...)
+func (stub PersistentVolumeClaimOperationsStub) Create(opts *projectClient.PersistentVolumeClaim) (*projectClient.PersistentVolumeClaim, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/project/v3/PersistentVolumeClaimOperations.Update(...)
+func (stub PersistentVolumeClaimOperationsStub) Update(existing *projectClient.PersistentVolumeClaim, updates interface{}) (*projectClient.PersistentVolumeClaim, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/project/v3/PersistentVolumeClaimOperations.Replace(...)
+func (stub PersistentVolumeClaimOperationsStub) Replace(existing *projectClient.PersistentVolumeClaim) (*projectClient.PersistentVolumeClaim, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/project/v3/PersistentVolumeClaimOperations.ByID(...)
+func (stub PersistentVolumeClaimOperationsStub) ByID(id string) (*projectClient.PersistentVolumeClaim, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/project/v3/PersistentVolumeClaimOperations.Delete(...)
+func (stub PersistentVolumeClaimOperationsStub) Delete(container *projectClient.PersistentVolumeClaim) error {
+	return stub.DoDelete(container)
+}
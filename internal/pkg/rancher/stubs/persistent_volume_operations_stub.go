@@ -34,6 +34,10 @@ func CreatePersistentVolumeOperationsStub(tb testing.TB) *PersistentVolumeOperat
 			assert.FailInStub(tb, 2, "Unexpected call of Create")
 			return nil, nil
 		},
+		DoDelete: func(container *clusterClient.PersistentVolume) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
 	}
 }
 
@@ -42,6 +46,7 @@ type PersistentVolumeOperationsStub struct {
 	tb       testing.TB
 	DoList   func(opts *types.ListOpts) (*clusterClient.PersistentVolumeCollection, error)
 	DoCreate func(opts *clusterClient.PersistentVolume) (*clusterClient.PersistentVolume, error)
+	DoDelete func(container *clusterClient.PersistentVolume) error
 }
 
 // List implements github.com/rancher/types/client/cluster/v3/PersistentVolumeOperations.List(...)
@@ -74,6 +79,5 @@ func (stub PersistentVolumeOperationsStub) ByID(id string) (*clusterClient.Persi
 
 // Delete implements github.com/rancher/types/client/cluster/v3/PersistentVolumeOperations.Delete(...)
 func (stub PersistentVolumeOperationsStub) Delete(container *clusterClient.PersistentVolume) error {
-	assert.FailInStub(stub.tb, 2, "Unexpected call of List")
-	return nil
+	return stub.DoDelete(container)
 }
@@ -0,0 +1,115 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateProjectRoleTemplateBindingOperationsStub creates a stub of github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations
+func CreateProjectRoleTemplateBindingOperationsStub(tb testing.TB) *ProjectRoleTemplateBindingOperationsStub {
+	return &ProjectRoleTemplateBindingOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.ProjectRoleTemplateBindingCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.ProjectRoleTemplateBinding) (*rancherClient.ProjectRoleTemplateBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.ProjectRoleTemplateBinding, updates interface{}) (*rancherClient.ProjectRoleTemplateBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.ProjectRoleTemplateBinding) (*rancherClient.ProjectRoleTemplateBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.ProjectRoleTemplateBinding, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.ProjectRoleTemplateBinding) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+		DoActionRefresh: func(container *rancherClient.ProjectRoleTemplateBinding) (*rancherClient.CatalogRefresh, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionRefresh")
+			return nil, nil
+		},
+		DoCollectionActionRefresh: func(container *rancherClient.ProjectRoleTemplateBindingCollection) (*rancherClient.CatalogRefresh, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of CollectionActionRefresh")
+			return nil, nil
+		},
+	}
+}
+
+// ProjectRoleTemplateBindingOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations
+type ProjectRoleTemplateBindingOperationsStub struct {
+	tb                        testing.TB
+	DoList                    func(opts *types.ListOpts) (*rancherClient.ProjectRoleTemplateBindingCollection, error)
+	DoCreate                  func(opts *rancherClient.ProjectRoleTemplateBinding) (*rancherClient.ProjectRoleTemplateBinding, error)
+	DoUpdate                  func(existing *rancherClient.ProjectRoleTemplateBinding, updates interface{}) (*rancherClient.ProjectRoleTemplateBinding, error)
+	DoReplace                 func(existing *rancherClient.ProjectRoleTemplateBinding) (*rancherClient.ProjectRoleTemplateBinding, error)
+	DoByID                    func(id string) (*rancherClient.ProjectRoleTemplateBinding, error)
+	DoDelete                  func(container *rancherClient.ProjectRoleTemplateBinding) error
+	DoActionRefresh           func(container *rancherClient.ProjectRoleTemplateBinding) (*rancherClient.CatalogRefresh, error)
+	DoCollectionActionRefresh func(container *rancherClient.ProjectRoleTemplateBindingCollection) (*rancherClient.CatalogRefresh, error)
+}
+
+// List implements github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations.List(...)
+func (stub ProjectRoleTemplateBindingOperationsStub) List(opts *types.ListOpts) (*rancherClient.ProjectRoleTemplateBindingCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations.Create(...)
+func (stub ProjectRoleTemplateBindingOperationsStub) Create(opts *rancherClient.ProjectRoleTemplateBinding) (*rancherClient.ProjectRoleTemplateBinding, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations.Update(...)
+func (stub ProjectRoleTemplateBindingOperationsStub) Update(existing *rancherClient.ProjectRoleTemplateBinding, updates interface{}) (*rancherClient.ProjectRoleTemplateBinding, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations.Replace(...)
+func (stub ProjectRoleTemplateBindingOperationsStub) Replace(existing *rancherClient.ProjectRoleTemplateBinding) (*rancherClient.ProjectRoleTemplateBinding, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations.ByID(...)
+func (stub ProjectRoleTemplateBindingOperationsStub) ByID(id string) (*rancherClient.ProjectRoleTemplateBinding, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations.Delete(...)
+func (stub ProjectRoleTemplateBindingOperationsStub) Delete(container *rancherClient.ProjectRoleTemplateBinding) error {
+	return stub.DoDelete(container)
+}
+
+// ActionRefresh implements github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations.ActionRefresh(...)
+func (stub ProjectRoleTemplateBindingOperationsStub) ActionRefresh(resource *rancherClient.ProjectRoleTemplateBinding) (*rancherClient.CatalogRefresh, error) {
+	return stub.DoActionRefresh(resource)
+}
+
+// CollectionActionRefresh implements github.com/rancher/types/client/management/v3/ProjectRoleTemplateBindingOperations.CollectionActionRefresh(...)
+func (stub ProjectRoleTemplateBindingOperationsStub) CollectionActionRefresh(resource *rancherClient.ProjectRoleTemplateBindingCollection) (*rancherClient.CatalogRefresh, error) {
+	return stub.DoCollectionActionRefresh(resource)
+}
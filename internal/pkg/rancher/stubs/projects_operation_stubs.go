@@ -34,14 +34,19 @@ func CreateProjectOperationsStub(tb testing.TB) *ProjectOperationsStub {
 			assert.FailInStub(tb, 2, "Unexpected call of Create")
 			return nil, nil
 		},
+		DoReplace: func(existing *managementClient.Project) (*managementClient.Project, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
 	}
 }
 
 // ProjectOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/ProjectOperations
 type ProjectOperationsStub struct {
-	tb       testing.TB
-	DoList   func(opts *types.ListOpts) (*managementClient.ProjectCollection, error)
-	DoCreate func(opts *managementClient.Project) (*managementClient.Project, error)
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*managementClient.ProjectCollection, error)
+	DoCreate  func(opts *managementClient.Project) (*managementClient.Project, error)
+	DoReplace func(existing *managementClient.Project) (*managementClient.Project, error)
 }
 
 // List implements github.com/rancher/types/client/management/v3/ProjectOperations.List(...)
@@ -65,8 +70,7 @@ func (stub ProjectOperationsStub) Update(existing *managementClient.Project, upd
 
 // Replace implements github.com/rancher/types/client/management/v3/ProjectOperations.Replace(...)
 func (stub ProjectOperationsStub) Replace(existing *managementClient.Project) (*managementClient.Project, error) {
-	assert.FailInStub(stub.tb, 2, "Unexpected call of Replace")
-	return nil, nil
+	return stub.DoReplace(existing)
 
 }
 
@@ -0,0 +1,95 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateRoleTemplateOperationsStub creates a stub of github.com/rancher/types/client/management/v3/RoleTemplateOperations
+func CreateRoleTemplateOperationsStub(tb testing.TB) *RoleTemplateOperationsStub {
+	return &RoleTemplateOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.RoleTemplateCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.RoleTemplate) (*rancherClient.RoleTemplate, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.RoleTemplate, updates interface{}) (*rancherClient.RoleTemplate, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.RoleTemplate) (*rancherClient.RoleTemplate, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.RoleTemplate, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.RoleTemplate) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// RoleTemplateOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/RoleTemplateOperations
+type RoleTemplateOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*rancherClient.RoleTemplateCollection, error)
+	DoCreate  func(opts *rancherClient.RoleTemplate) (*rancherClient.RoleTemplate, error)
+	DoUpdate  func(existing *rancherClient.RoleTemplate, updates interface{}) (*rancherClient.RoleTemplate, error)
+	DoReplace func(existing *rancherClient.RoleTemplate) (*rancherClient.RoleTemplate, error)
+	DoByID    func(id string) (*rancherClient.RoleTemplate, error)
+	DoDelete  func(container *rancherClient.RoleTemplate) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/RoleTemplateOperations.List(...)
+func (stub RoleTemplateOperationsStub) List(opts *types.ListOpts) (*rancherClient.RoleTemplateCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/RoleTemplateOperations.Create(...)
+func (stub RoleTemplateOperationsStub) Create(opts *rancherClient.RoleTemplate) (*rancherClient.RoleTemplate, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/RoleTemplateOperations.Update(...)
+func (stub RoleTemplateOperationsStub) Update(existing *rancherClient.RoleTemplate, updates interface{}) (*rancherClient.RoleTemplate, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/RoleTemplateOperations.Replace(...)
+func (stub RoleTemplateOperationsStub) Replace(existing *rancherClient.RoleTemplate) (*rancherClient.RoleTemplate, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/RoleTemplateOperations.ByID(...)
+func (stub RoleTemplateOperationsStub) ByID(id string) (*rancherClient.RoleTemplate, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/RoleTemplateOperations.Delete(...)
+func (stub RoleTemplateOperationsStub) Delete(container *rancherClient.RoleTemplate) error {
+	return stub.DoDelete(container)
+}
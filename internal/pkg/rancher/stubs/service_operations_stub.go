@@ -0,0 +1,95 @@
+// Copyright © 2018 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	projectClient "github.com/rancher/types/client/project/v3"
+)
+
+// CreateServiceOperationsStub creates a stub of github.com/rancher/types/client/project/v3/ServiceOperations
+func CreateServiceOperationsStub(tb testing.TB) *ServiceOperationsStub {
+	return &ServiceOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*projectClient.ServiceCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *projectClient.Service) (*projectClient.Service, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *projectClient.Service, updates interface{}) (*projectClient.Service, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *projectClient.Service) (*projectClient.Service, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*projectClient.Service, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *projectClient.Service) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// ServiceOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/project/v3/ServiceOperations
+type ServiceOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*projectClient.ServiceCollection, error)
+	DoCreate  func(opts *projectClient.Service) (*projectClient.Service, error)
+	DoUpdate  func(existing *projectClient.Service, updates interface{}) (*projectClient.Service, error)
+	DoReplace func(existing *projectClient.Service) (*projectClient.Service, error)
+	DoByID    func(id string) (*projectClient.Service, error)
+	DoDelete  func(container *projectClient.Service) error
+}
+
+// List implements github.com/rancher/types/client/project/v3/ServiceOperations.List(...)
+func (stub ServiceOperationsStub) List(opts *types.ListOpts) (*projectClient.ServiceCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/project/v3/ServiceOperations.Create(...)
+func (stub ServiceOperationsStub) Create(opts *projectClient.Service) (*projectClient.Service, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/project/v3/ServiceOperations.Update(...)
+func (stub ServiceOperationsStub) Update(existing *projectClient.Service, updates interface{}) (*projectClient.Service, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/project/v3/ServiceOperations.Replace(...)
+func (stub ServiceOperationsStub) Replace(existing *projectClient.Service) (*projectClient.Service, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/project/v3/ServiceOperations.ByID(...)
+func (stub ServiceOperationsStub) ByID(id string) (*projectClient.Service, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/project/v3/ServiceOperations.Delete(...)
+func (stub ServiceOperationsStub) Delete(container *projectClient.Service) error {
+	return stub.DoDelete(container)
+}
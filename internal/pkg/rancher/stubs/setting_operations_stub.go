@@ -0,0 +1,95 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateSettingOperationsStub creates a stub of github.com/rancher/types/client/management/v3/SettingOperations
+func CreateSettingOperationsStub(tb testing.TB) *SettingOperationsStub {
+	return &SettingOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.SettingCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.Setting) (*rancherClient.Setting, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.Setting, updates interface{}) (*rancherClient.Setting, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.Setting) (*rancherClient.Setting, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.Setting, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.Setting) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+	}
+}
+
+// SettingOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/SettingOperations
+type SettingOperationsStub struct {
+	tb        testing.TB
+	DoList    func(opts *types.ListOpts) (*rancherClient.SettingCollection, error)
+	DoCreate  func(opts *rancherClient.Setting) (*rancherClient.Setting, error)
+	DoUpdate  func(existing *rancherClient.Setting, updates interface{}) (*rancherClient.Setting, error)
+	DoReplace func(existing *rancherClient.Setting) (*rancherClient.Setting, error)
+	DoByID    func(id string) (*rancherClient.Setting, error)
+	DoDelete  func(container *rancherClient.Setting) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/SettingOperations.List(...)
+func (stub SettingOperationsStub) List(opts *types.ListOpts) (*rancherClient.SettingCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/SettingOperations.Create(...)
+func (stub SettingOperationsStub) Create(opts *rancherClient.Setting) (*rancherClient.Setting, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/SettingOperations.Update(...)
+func (stub SettingOperationsStub) Update(existing *rancherClient.Setting, updates interface{}) (*rancherClient.Setting, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/SettingOperations.Replace(...)
+func (stub SettingOperationsStub) Replace(existing *rancherClient.Setting) (*rancherClient.Setting, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/SettingOperations.ByID(...)
+func (stub SettingOperationsStub) ByID(id string) (*rancherClient.Setting, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/SettingOperations.Delete(...)
+func (stub SettingOperationsStub) Delete(container *rancherClient.Setting) error {
+	return stub.DoDelete(container)
+}
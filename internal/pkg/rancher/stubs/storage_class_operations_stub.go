@@ -34,6 +34,10 @@ func CreateStorageClassOperationsStub(tb testing.TB) *StorageClassOperationsStub
 			assert.FailInStub(tb, 2, "Unexpected call of Create")
 			return nil, nil
 		},
+		DoDelete: func(container *clusterClient.StorageClass) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
 	}
 }
 
@@ -42,6 +46,7 @@ type StorageClassOperationsStub struct {
 	tb       testing.TB
 	DoList   func(opts *types.ListOpts) (*clusterClient.StorageClassCollection, error)
 	DoCreate func(opts *clusterClient.StorageClass) (*clusterClient.StorageClass, error)
+	DoDelete func(container *clusterClient.StorageClass) error
 }
 
 // List implements github.com/rancher/types/client/cluster/v3/StorageClassOperations.List(...)
@@ -74,6 +79,5 @@ func (stub StorageClassOperationsStub) ByID(id string) (*clusterClient.StorageCl
 
 // Delete implements github.com/rancher/types/client/cluster/v3/StorageClassOperations.Delete(...)
 func (stub StorageClassOperationsStub) Delete(container *clusterClient.StorageClass) error {
-	assert.FailInStub(stub.tb, 2, "Unexpected call of List")
-	return nil
+	return stub.DoDelete(container)
 }
@@ -0,0 +1,135 @@
+// Copyright © 2019 Bitgrip <berlin@bitgrip.de>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stubs
+
+import (
+	"testing"
+
+	"github.com/bitgrip/cattlectl/internal/pkg/assert"
+	"github.com/rancher/norman/types"
+	rancherClient "github.com/rancher/types/client/management/v3"
+)
+
+// CreateUserOperationsStub creates a stub of github.com/rancher/types/client/management/v3/UserOperations
+func CreateUserOperationsStub(tb testing.TB) *UserOperationsStub {
+	return &UserOperationsStub{
+		tb: tb,
+		DoList: func(opts *types.ListOpts) (*rancherClient.UserCollection, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of List")
+			return nil, nil
+		},
+		DoCreate: func(opts *rancherClient.User) (*rancherClient.User, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Create")
+			return nil, nil
+		},
+		DoUpdate: func(existing *rancherClient.User, updates interface{}) (*rancherClient.User, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Update")
+			return nil, nil
+		},
+		DoReplace: func(existing *rancherClient.User) (*rancherClient.User, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of Replace")
+			return nil, nil
+		},
+		DoByID: func(id string) (*rancherClient.User, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ByID")
+			return nil, nil
+		},
+		DoDelete: func(container *rancherClient.User) error {
+			assert.FailInStub(tb, 2, "Unexpected call of Delete")
+			return nil
+		},
+		DoActionRefreshauthprovideraccess: func(resource *rancherClient.User) error {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionRefreshauthprovideraccess")
+			return nil
+		},
+		DoActionSetpassword: func(resource *rancherClient.User, input *rancherClient.SetPasswordInput) (*rancherClient.User, error) {
+			assert.FailInStub(tb, 2, "Unexpected call of ActionSetpassword")
+			return nil, nil
+		},
+		DoCollectionActionChangepassword: func(resource *rancherClient.UserCollection, input *rancherClient.ChangePasswordInput) error {
+			assert.FailInStub(tb, 2, "Unexpected call of CollectionActionChangepassword")
+			return nil
+		},
+		DoCollectionActionRefreshauthprovideraccess: func(resource *rancherClient.UserCollection) error {
+			assert.FailInStub(tb, 2, "Unexpected call of CollectionActionRefreshauthprovideraccess")
+			return nil
+		},
+	}
+}
+
+// UserOperationsStub structure to hold callbacks used to stub github.com/rancher/types/client/management/v3/UserOperations
+type UserOperationsStub struct {
+	tb                                          testing.TB
+	DoList                                      func(opts *types.ListOpts) (*rancherClient.UserCollection, error)
+	DoCreate                                    func(opts *rancherClient.User) (*rancherClient.User, error)
+	DoUpdate                                    func(existing *rancherClient.User, updates interface{}) (*rancherClient.User, error)
+	DoReplace                                   func(existing *rancherClient.User) (*rancherClient.User, error)
+	DoByID                                      func(id string) (*rancherClient.User, error)
+	DoDelete                                    func(container *rancherClient.User) error
+	DoActionRefreshauthprovideraccess           func(resource *rancherClient.User) error
+	DoActionSetpassword                         func(resource *rancherClient.User, input *rancherClient.SetPasswordInput) (*rancherClient.User, error)
+	DoCollectionActionChangepassword            func(resource *rancherClient.UserCollection, input *rancherClient.ChangePasswordInput) error
+	DoCollectionActionRefreshauthprovideraccess func(resource *rancherClient.UserCollection) error
+}
+
+// List implements github.com/rancher/types/client/management/v3/UserOperations.List(...)
+func (stub UserOperationsStub) List(opts *types.ListOpts) (*rancherClient.UserCollection, error) {
+	return stub.DoList(opts)
+}
+
+// Create implements github.com/rancher/types/client/management/v3/UserOperations.Create(...)
+func (stub UserOperationsStub) Create(opts *rancherClient.User) (*rancherClient.User, error) {
+	return stub.DoCreate(opts)
+}
+
+// Update implements github.com/rancher/types/client/management/v3/UserOperations.Update(...)
+func (stub UserOperationsStub) Update(existing *rancherClient.User, updates interface{}) (*rancherClient.User, error) {
+	return stub.DoUpdate(existing, updates)
+}
+
+// Replace implements github.com/rancher/types/client/management/v3/UserOperations.Replace(...)
+func (stub UserOperationsStub) Replace(existing *rancherClient.User) (*rancherClient.User, error) {
+	return stub.DoReplace(existing)
+}
+
+// ByID implements github.com/rancher/types/client/management/v3/UserOperations.ByID(...)
+func (stub UserOperationsStub) ByID(id string) (*rancherClient.User, error) {
+	return stub.DoByID(id)
+}
+
+// Delete implements github.com/rancher/types/client/management/v3/UserOperations.Delete(...)
+func (stub UserOperationsStub) Delete(container *rancherClient.User) error {
+	return stub.DoDelete(container)
+}
+
+// ActionRefreshauthprovideraccess implements github.com/rancher/types/client/management/v3/UserOperations.ActionRefreshauthprovideraccess(...)
+func (stub UserOperationsStub) ActionRefreshauthprovideraccess(resource *rancherClient.User) error {
+	return stub.DoActionRefreshauthprovideraccess(resource)
+}
+
+// ActionSetpassword implements github.com/rancher/types/client/management/v3/UserOperations.ActionSetpassword(...)
+func (stub UserOperationsStub) ActionSetpassword(resource *rancherClient.User, input *rancherClient.SetPasswordInput) (*rancherClient.User, error) {
+	return stub.DoActionSetpassword(resource, input)
+}
+
+// CollectionActionChangepassword implements github.com/rancher/types/client/management/v3/UserOperations.CollectionActionChangepassword(...)
+func (stub UserOperationsStub) CollectionActionChangepassword(resource *rancherClient.UserCollection, input *rancherClient.ChangePasswordInput) error {
+	return stub.DoCollectionActionChangepassword(resource, input)
+}
+
+// CollectionActionRefreshauthprovideraccess implements github.com/rancher/types/client/management/v3/UserOperations.CollectionActionRefreshauthprovideraccess(...)
+func (stub UserOperationsStub) CollectionActionRefreshauthprovideraccess(resource *rancherClient.UserCollection) error {
+	return stub.DoCollectionActionRefreshauthprovideraccess(resource)
+}